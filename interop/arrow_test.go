@@ -0,0 +1,245 @@
+package interop
+
+import (
+	"bytes"
+	"flag"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/ipc"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+
+	"pango/series"
+)
+
+var update = flag.Bool("update", false, "regenerate the files written by arrow-go in series/testdata")
+
+// testdata is the directory of the files read by the tests of pango
+const testdata = "../series/testdata"
+
+// fixtureColumn is a column of the files written by arrow-go, appendRows appends the rows lo to hi to a builder
+type fixtureColumn struct {
+	field      arrow.Field
+	appendRows func(b array.Builder, lo, hi int)
+}
+
+// appendTo returns an appendRows for a column with the given values, valid is nil if no value is null
+func appendTo[B interface{ AppendValues([]T, []bool) }, T any](values []T, valid []bool) func(array.Builder, int, int) {
+	return func(b array.Builder, lo, hi int) {
+		if valid == nil {
+			b.(B).AppendValues(values[lo:hi], nil)
+			return
+		}
+		b.(B).AppendValues(values[lo:hi], valid[lo:hi])
+	}
+}
+
+// fixtureRows is the number of rows of the files written by arrow-go
+const fixtureRows = 5
+
+// fixtureColumns are the columns of the files written by arrow-go
+// the tests of pango expect exactly these values, change them together
+func fixtureColumns() []fixtureColumn {
+	column := func(name string, typ arrow.DataType, appendRows func(array.Builder, int, int)) fixtureColumn {
+		return fixtureColumn{arrow.Field{Name: name, Type: typ, Nullable: true}, appendRows}
+	}
+	every := []bool{true, false, true, false, true}
+	third := []bool{true, true, false, true, true}
+	second := []bool{true, false, true, true, true}
+	return []fixtureColumn{
+		column("i8", arrow.PrimitiveTypes.Int8, appendTo[*array.Int8Builder]([]int8{1, -2, 3, -4, 5}, nil)),
+		column("i16", arrow.PrimitiveTypes.Int16, appendTo[*array.Int16Builder]([]int16{100, -200, 300, -400, 500}, nil)),
+		column("i32", arrow.PrimitiveTypes.Int32, appendTo[*array.Int32Builder]([]int32{1, 2, 3, 4, 5}, every)),
+		column("i64", arrow.PrimitiveTypes.Int64, appendTo[*array.Int64Builder]([]int64{1 << 40, -1, 0, math.MaxInt64, math.MinInt64}, nil)),
+		column("u8", arrow.PrimitiveTypes.Uint8, appendTo[*array.Uint8Builder]([]uint8{0, 1, 2, 254, 255}, nil)),
+		column("u16", arrow.PrimitiveTypes.Uint16, appendTo[*array.Uint16Builder]([]uint16{0, math.MaxUint16, 1, 2, 3}, nil)),
+		column("u32", arrow.PrimitiveTypes.Uint32, appendTo[*array.Uint32Builder]([]uint32{0, math.MaxUint32, 1, 2, 3}, nil)),
+		column("u64", arrow.PrimitiveTypes.Uint64, appendTo[*array.Uint64Builder]([]uint64{0, math.MaxUint64, 1, 2, 3}, nil)),
+		column("f32", arrow.PrimitiveTypes.Float32, appendTo[*array.Float32Builder]([]float32{0.5, -1.5, 0, 2, 3}, third)),
+		column("f64", arrow.PrimitiveTypes.Float64, appendTo[*array.Float64Builder]([]float64{1.5, 0, math.Inf(1), -0.25, 2.25}, second)),
+		column("s", arrow.BinaryTypes.String, appendTo[*array.StringBuilder]([]string{"a", "b", "", "ünïcode", "e"}, second)),
+		column("ls", arrow.BinaryTypes.LargeString, appendTo[*array.LargeStringBuilder]([]string{"x", "y", "z", "w", "v"}, nil)),
+		column("b", arrow.FixedWidthTypes.Boolean, appendTo[*array.BooleanBuilder]([]bool{true, false, true, true, false}, third)),
+		column("ts_s", &arrow.TimestampType{Unit: arrow.Second, TimeZone: "UTC"},
+			appendTo[*array.TimestampBuilder]([]arrow.Timestamp{-1, 0, 1704164645, 1704251045, 4102444800}, nil)),
+		column("ts_ms", &arrow.TimestampType{Unit: arrow.Millisecond},
+			appendTo[*array.TimestampBuilder]([]arrow.Timestamp{-1500, 0, 1704164645123, 1704251045123, 4102444800000}, nil)),
+		column("ts_us", &arrow.TimestampType{Unit: arrow.Microsecond, TimeZone: "Europe/Paris"},
+			appendTo[*array.TimestampBuilder]([]arrow.Timestamp{-1500000, 0, 1704164645123456, 1704251045123456, 4102444800000000}, every)),
+		column("ts_ns", &arrow.TimestampType{Unit: arrow.Nanosecond},
+			appendTo[*array.TimestampBuilder]([]arrow.Timestamp{-1500000000, 0, 1704164645123456789, 1704251045123456789, 9223372036000000000}, nil)),
+		column(series.ArrowIndexColumn, arrow.BinaryTypes.String, appendTo[*array.StringBuilder]([]string{"r0", "r1", "r2", "r3", "r4"}, nil)),
+	}
+}
+
+// fixtureRecords returns the rows of the fixture split into record batches of the given size
+func fixtureRecords(t *testing.T, batchSize int) (*arrow.Schema, []arrow.RecordBatch) {
+	t.Helper()
+	columns := fixtureColumns()
+	fields := make([]arrow.Field, len(columns))
+	for i, c := range columns {
+		fields[i] = c.field
+	}
+	schema := arrow.NewSchema(fields, nil)
+
+	b := array.NewRecordBuilder(memory.DefaultAllocator, schema)
+	defer b.Release()
+	var records []arrow.RecordBatch
+	for lo := 0; lo < fixtureRows; lo += batchSize {
+		for i, c := range columns {
+			c.appendRows(b.Field(i), lo, min(lo+batchSize, fixtureRows))
+		}
+		records = append(records, b.NewRecordBatch())
+	}
+	t.Cleanup(func() {
+		for _, r := range records {
+			r.Release()
+		}
+	})
+	return schema, records
+}
+
+// writeFixture writes a file read by the tests of pango
+func writeFixture(t *testing.T, name string, data []byte) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(testdata, name), data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestArrowFixture(t *testing.T) {
+	if !*update {
+		t.Skip("run with -update to regenerate the files written by arrow-go")
+	}
+	schema, records := fixtureRecords(t, 3)
+	var buf bytes.Buffer
+	w := ipc.NewWriter(&buf, ipc.WithSchema(schema))
+	for _, r := range records {
+		if err := w.Write(r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	writeFixture(t, "arrowgo.arrows", buf.Bytes())
+}
+
+// goldenDataFrame is the DataFrame of the golden files of pango in series/testdata
+func goldenDataFrame() *series.DataFrame[int] {
+	index := []int{10, 20, 30, 40}
+	return series.NewDataFrame[int](
+		series.NewSeries("id", []int32{1, -2, 3, -4}, index),
+		series.NewSeries("count", []uint8{0, 1, 254, 255}, index),
+		series.NewSeries("ratio", []float32{0.5, 0.25, 0, -1}, index),
+		series.NewSeries("name", []string{"alice", "", "bob", "ünïcode"}, index),
+		series.NewSeries("ok", []bool{true, false, false, true}, index),
+		series.NewSeries("at", goldenTimes(), index),
+		series.NewCategoricalSeries("grade", []string{"b", "x", "a", "b"}, index, []string{"a", "b"}, true),
+	)
+}
+
+func goldenTimes() []time.Time {
+	return []time.Time{
+		time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC),
+		time.Date(1969, 12, 31, 23, 59, 59, 0, time.UTC),
+		time.Unix(0, 0).UTC(),
+		time.Date(2262, 4, 11, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+// goldenColumns are the columns of goldenDataFrame as read by arrow-go
+var goldenColumns = []column{
+	{"id", arrow.INT32, []any{int32(1), int32(-2), int32(3), int32(-4)}},
+	{"count", arrow.UINT8, []any{uint8(0), uint8(1), uint8(254), uint8(255)}},
+	{"ratio", arrow.FLOAT32, []any{float32(0.5), float32(0.25), float32(0), float32(-1)}},
+	{"name", arrow.STRING, []any{"alice", "", "bob", "ünïcode"}},
+	{"ok", arrow.BOOL, []any{true, false, false, true}},
+	{"at", arrow.TIMESTAMP, toAny(goldenTimes())},
+	{"grade", arrow.STRING, []any{"b", nil, "a", "b"}},
+	{series.ArrowIndexColumn, arrow.INT64, []any{int64(10), int64(20), int64(30), int64(40)}},
+}
+
+func toAny[T any](values []T) []any {
+	out := make([]any, len(values))
+	for i, v := range values {
+		out[i] = v
+	}
+	return out
+}
+
+func TestArrowGoReadsArrow(t *testing.T) {
+	t.Run("golden files", func(t *testing.T) {
+		f, err := os.Open(filepath.Join(testdata, "dataframe.arrows"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+		checkColumns(t, readArrow(t, f), goldenColumns)
+	})
+
+	t.Run("every type", func(t *testing.T) {
+		index := []string{"a", "b", "c"}
+		nan := math.NaN()
+		at := time.Date(2024, 5, 6, 7, 8, 9, 10, time.FixedZone("UTC+2", 2*60*60))
+		df := series.NewDataFrame[string](
+			series.NewSeries("int", []int{math.MinInt, 0, math.MaxInt}, index),
+			series.NewSeries("int8", []int8{-128, 0, 127}, index),
+			series.NewSeries("int16", []int16{-1, 0, 1}, index),
+			series.NewSeries("int64", []int64{math.MinInt64, 0, math.MaxInt64}, index),
+			series.NewSeries("uint", []uint{0, 1, math.MaxUint}, index),
+			series.NewSeries("uint16", []uint16{0, 1, math.MaxUint16}, index),
+			series.NewSeries("uint32", []uint32{0, 1, math.MaxUint32}, index),
+			series.NewSeries("uint64", []uint64{0, 1, math.MaxUint64}, index),
+			series.NewSeries("float64", []float64{nan, math.Inf(-1), 0.1}, index),
+			series.NewSeries("time", []time.Time{at, time.Unix(0, 0), time.Unix(0, -1)}, index),
+		)
+		var buf bytes.Buffer
+		if err := df.WriteArrowIPC(&buf); err != nil {
+			t.Fatal(err)
+		}
+		checkColumns(t, readArrow(t, &buf), []column{
+			{"int", arrow.INT64, []any{int64(math.MinInt64), int64(0), int64(math.MaxInt64)}},
+			{"int8", arrow.INT8, []any{int8(-128), int8(0), int8(127)}},
+			{"int16", arrow.INT16, []any{int16(-1), int16(0), int16(1)}},
+			{"int64", arrow.INT64, []any{int64(math.MinInt64), int64(0), int64(math.MaxInt64)}},
+			{"uint", arrow.UINT64, []any{uint64(0), uint64(1), uint64(math.MaxUint64)}},
+			{"uint16", arrow.UINT16, []any{uint16(0), uint16(1), uint16(math.MaxUint16)}},
+			{"uint32", arrow.UINT32, []any{uint32(0), uint32(1), uint32(math.MaxUint32)}},
+			{"uint64", arrow.UINT64, []any{uint64(0), uint64(1), uint64(math.MaxUint64)}},
+			{"float64", arrow.FLOAT64, []any{nan, math.Inf(-1), 0.1}},
+			{"time", arrow.TIMESTAMP, []any{at, time.Unix(0, 0), time.Unix(0, -1)}},
+			{series.ArrowIndexColumn, arrow.STRING, []any{"a", "b", "c"}},
+		})
+	})
+}
+
+// readArrow reads all record batches of an Arrow IPC stream with arrow-go
+func readArrow(t *testing.T, r interface{ Read([]byte) (int, error) }) []column {
+	t.Helper()
+	reader, err := ipc.NewReader(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reader.Release()
+
+	columns := make([]column, reader.Schema().NumFields())
+	for i, f := range reader.Schema().Fields() {
+		columns[i] = column{name: f.Name, typ: f.Type.ID()}
+	}
+	for reader.Next() {
+		record := reader.RecordBatch()
+		for i := range columns {
+			columns[i].values = append(columns[i].values, arrayValues(t, record.Column(i))...)
+		}
+	}
+	if err := reader.Err(); err != nil {
+		t.Fatal(err)
+	}
+	return columns
+}
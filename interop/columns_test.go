@@ -0,0 +1,119 @@
+package interop
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+)
+
+// column is a column as read by arrow-go, nulls are nil
+type column struct {
+	name   string
+	typ    arrow.Type
+	values []any
+}
+
+// valuer is an arrow-go array of values of type T
+type valuer[T any] interface {
+	Len() int
+	IsNull(i int) bool
+	Value(i int) T
+}
+
+// valuesOf returns the values of an arrow-go array, nulls are nil
+func valuesOf[T any](a valuer[T], convert func(T) any) []any {
+	values := make([]any, a.Len())
+	for i := range values {
+		if !a.IsNull(i) {
+			values[i] = convert(a.Value(i))
+		}
+	}
+	return values
+}
+
+// same returns the value unchanged
+func same[T any](v T) any {
+	return v
+}
+
+// arrayValues returns the values of an arrow-go array, timestamps are converted to time.Time
+func arrayValues(t *testing.T, a arrow.Array) []any {
+	t.Helper()
+	switch a := a.(type) {
+	case *array.Int8:
+		return valuesOf(a, same)
+	case *array.Int16:
+		return valuesOf(a, same)
+	case *array.Int32:
+		return valuesOf(a, same)
+	case *array.Int64:
+		return valuesOf(a, same)
+	case *array.Uint8:
+		return valuesOf(a, same)
+	case *array.Uint16:
+		return valuesOf(a, same)
+	case *array.Uint32:
+		return valuesOf(a, same)
+	case *array.Uint64:
+		return valuesOf(a, same)
+	case *array.Float32:
+		return valuesOf(a, same)
+	case *array.Float64:
+		return valuesOf(a, same)
+	case *array.String:
+		return valuesOf(a, same)
+	case *array.LargeString:
+		return valuesOf(a, same)
+	case *array.Boolean:
+		return valuesOf(a, same)
+	case *array.Timestamp:
+		unit := a.DataType().(*arrow.TimestampType).Unit
+		return valuesOf(a, func(v arrow.Timestamp) any { return v.ToTime(unit) })
+	}
+	t.Fatalf("unexpected arrow-go array %T", a)
+	return nil
+}
+
+// checkColumns compares the columns read by arrow-go with the expected ones
+// NaN values are equal and times are compared as instants
+func checkColumns(t *testing.T, got, want []column) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("expected %d columns, got %d", len(want), len(got))
+	}
+	for i, w := range want {
+		g := got[i]
+		if g.name != w.name || g.typ != w.typ {
+			t.Errorf("column %d: expected %s of type %s, got %s of type %s", i, w.name, w.typ, g.name, g.typ)
+			continue
+		}
+		if len(g.values) != len(w.values) {
+			t.Errorf("%s: expected %d values, got %d", w.name, len(w.values), len(g.values))
+			continue
+		}
+		for j, v := range w.values {
+			if !sameValue(g.values[j], v) {
+				t.Errorf("%s[%d]: expected %v (%T), got %v (%T)", w.name, j, v, v, g.values[j], g.values[j])
+			}
+		}
+	}
+}
+
+// sameValue compares two values treating NaN as equal to NaN and times as instants
+func sameValue(a, b any) bool {
+	switch a := a.(type) {
+	case float64:
+		b, ok := b.(float64)
+		return ok && (a == b || math.IsNaN(a) && math.IsNaN(b))
+	case float32:
+		b, ok := b.(float32)
+		return ok && (a == b || a != a && b != b)
+	case time.Time:
+		b, ok := b.(time.Time)
+		return ok && a.Equal(b)
+	}
+	return a == b
+}
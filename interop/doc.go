@@ -0,0 +1,8 @@
+// Package interop checks the Arrow and Parquet files of pango against the reference Go implementation
+// of Arrow, arrow-go. It is a module of its own so pango itself keeps having no dependencies.
+//
+// The tests read the files written by pango with arrow-go. Run with -update, they regenerate the files
+// written by arrow-go which the tests of pango read, in series/testdata:
+//
+//	go test -run Fixture -update
+package interop
@@ -0,0 +1,200 @@
+package interop
+
+import (
+	"bytes"
+	"context"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/arrow-go/v18/parquet"
+	"github.com/apache/arrow-go/v18/parquet/compress"
+	"github.com/apache/arrow-go/v18/parquet/file"
+	"github.com/apache/arrow-go/v18/parquet/pqarrow"
+	"github.com/apache/arrow-go/v18/parquet/schema"
+
+	"pango/series"
+)
+
+func TestParquetFixture(t *testing.T) {
+	if !*update {
+		t.Skip("run with -update to regenerate the files written by arrow-go")
+	}
+
+	write := func(name string, props *parquet.WriterProperties, arrowProps pqarrow.ArrowWriterProperties) {
+		t.Helper()
+		schema, records := fixtureRecords(t, fixtureRows)
+		table := array.NewTableFromRecords(schema, records)
+		defer table.Release()
+		var buf bytes.Buffer
+		// a row group has at most 3 rows
+		if err := pqarrow.WriteTable(table, &buf, 3, props, arrowProps); err != nil {
+			t.Fatal(err)
+		}
+		writeFixture(t, name, buf.Bytes())
+	}
+
+	// dictionary encoded gzip pages, seconds are coerced to milliseconds
+	write("arrowgo.parquet", parquet.NewWriterProperties(
+		parquet.WithCompression(compress.Codecs.Gzip),
+		parquet.WithDictionaryDefault(true),
+	), pqarrow.DefaultWriterProps())
+
+	// plain encoded version 2 data pages, arrow-go writes the nanosecond timestamps as INT96
+	write("arrowgo_int96.parquet", parquet.NewWriterProperties(
+		parquet.WithDataPageVersion(parquet.DataPageV2),
+		parquet.WithDictionaryDefault(false),
+	), pqarrow.NewArrowWriterProperties(pqarrow.WithDeprecatedInt96Timestamps(true)))
+
+	writeFixture(t, "arrowgo_legacy.parquet", legacyParquet(t))
+}
+
+// legacyParquet writes a Parquet 1.0 file annotated with converted types, as written before logical types
+// arrow-go adds logical types for UTF8, INT_8 and UINT_8 but not for the timestamps
+func legacyParquet(t *testing.T) []byte {
+	t.Helper()
+	node := func(name string, repetition parquet.Repetition, typ parquet.Type, converted schema.ConvertedType) schema.Node {
+		n, err := schema.NewPrimitiveNodeConverted(name, repetition, typ, converted, 0, 0, 0, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return n
+	}
+	root, err := schema.NewGroupNode("schema", parquet.Repetitions.Required, schema.FieldList{
+		node("ts_ms", parquet.Repetitions.Required, parquet.Types.Int64, schema.ConvertedTypes.TimestampMillis),
+		node("ts_us", parquet.Repetitions.Optional, parquet.Types.Int64, schema.ConvertedTypes.TimestampMicros),
+		node("name", parquet.Repetitions.Optional, parquet.Types.ByteArray, schema.ConvertedTypes.UTF8),
+		node("i8", parquet.Repetitions.Required, parquet.Types.Int32, schema.ConvertedTypes.Int8),
+		node("u8", parquet.Repetitions.Required, parquet.Types.Int32, schema.ConvertedTypes.Uint8),
+	}, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	w := file.NewParquetWriter(&buf, root, file.WithWriterProps(parquet.NewWriterProperties(
+		parquet.WithVersion(parquet.V1_0),
+		parquet.WithDictionaryDefault(true),
+	)))
+	rg := w.AppendRowGroup()
+	next := func() file.ColumnChunkWriter {
+		t.Helper()
+		c, err := rg.NextColumn()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return c
+	}
+	check := func(_ int64, err error) {
+		t.Helper()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	defined := []int16{1, 0, 1, 1, 0}
+	check(next().(*file.Int64ColumnChunkWriter).WriteBatch([]int64{-1500, 0, 1704164645123, 1704164645123, 4102444800000}, nil, nil))
+	check(next().(*file.Int64ColumnChunkWriter).WriteBatch([]int64{1704164645123456, -1, 1704164645123456}, defined, nil))
+	check(next().(*file.ByteArrayColumnChunkWriter).WriteBatch([]parquet.ByteArray{[]byte("x"), []byte("ünïcode"), []byte("x")}, defined, nil))
+	check(next().(*file.Int32ColumnChunkWriter).WriteBatch([]int32{-128, 127, 0, -1, -1}, nil, nil))
+	check(next().(*file.Int32ColumnChunkWriter).WriteBatch([]int32{0, 255, 1, 255, 255}, nil, nil))
+	if err := rg.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// parquetDataFrame is the DataFrame of the golden Parquet file of pango in series/testdata
+func parquetDataFrame() *series.DataFrame[string] {
+	index := []string{"a", "b", "c", "d", "e", "f"}
+	return series.NewDataFrame[string](
+		series.NewSeries("i8", []int8{-128, 0, 127, 1, 1, 1}, index),
+		series.NewSeries("u16", []uint16{0, 65535, 2, 2, 2, 2}, index),
+		series.NewSeries("i64", []int{1 << 40, -1, 0, 5, 5, 5}, index),
+		series.NewSeries("u64", []uint64{math.MaxUint64, 0, 1, 1, 1, 1}, index),
+		series.NewSeries("f32", []float32{0.5, -1, 0, 0, 0, 0}, index),
+		series.NewSeries("f64", []float64{math.NaN(), math.Inf(-1), 1e300, 2, 2, 2}, index),
+		series.NewSeries("flag", []bool{true, false, true, true, false, false}, index),
+		series.NewSeries("name", []string{"x", "", "ünïcode", "x", "x", "y"}, index),
+		series.NewSeries("at", parquetTimes(), index),
+		series.NewCategoricalSeries("grade", []string{"b", "x", "a", "", "b", "b"}, index, []string{"a", "b"}, false),
+	)
+}
+
+func parquetTimes() []time.Time {
+	return []time.Time{
+		time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC),
+		time.Unix(0, 0).UTC(),
+		time.Date(1950, 6, 1, 0, 0, 0, 0, time.UTC),
+		time.Unix(1, 0).UTC(),
+		time.Unix(2, 0).UTC(),
+		time.Unix(3, 0).UTC(),
+	}
+}
+
+// parquetColumns are the columns of parquetDataFrame as read by arrow-go
+var parquetColumns = []column{
+	{"i8", arrow.INT8, []any{int8(-128), int8(0), int8(127), int8(1), int8(1), int8(1)}},
+	{"u16", arrow.UINT16, []any{uint16(0), uint16(65535), uint16(2), uint16(2), uint16(2), uint16(2)}},
+	{"i64", arrow.INT64, []any{int64(1 << 40), int64(-1), int64(0), int64(5), int64(5), int64(5)}},
+	{"u64", arrow.UINT64, []any{uint64(math.MaxUint64), uint64(0), uint64(1), uint64(1), uint64(1), uint64(1)}},
+	{"f32", arrow.FLOAT32, []any{float32(0.5), float32(-1), float32(0), float32(0), float32(0), float32(0)}},
+	{"f64", arrow.FLOAT64, []any{math.NaN(), math.Inf(-1), 1e300, 2.0, 2.0, 2.0}},
+	{"flag", arrow.BOOL, []any{true, false, true, true, false, false}},
+	{"name", arrow.STRING, []any{"x", "", "ünïcode", "x", "x", "y"}},
+	{"at", arrow.TIMESTAMP, toAny(parquetTimes())},
+	{"grade", arrow.STRING, []any{"b", nil, "a", nil, "b", "b"}},
+	{series.ArrowIndexColumn, arrow.STRING, []any{"a", "b", "c", "d", "e", "f"}},
+}
+
+func TestArrowGoReadsParquet(t *testing.T) {
+	t.Run("golden file", func(t *testing.T) {
+		data, err := os.ReadFile(filepath.Join(testdata, "dataframe.parquet"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkColumns(t, readParquet(t, data), parquetColumns)
+	})
+
+	for _, opts := range []series.ParquetWriteOptions{
+		{},
+		{Compression: series.ParquetGzip},
+		{Dictionary: true},
+		{RowGroupSize: 4},
+		{DataPageV2: true},
+		{Compression: series.ParquetGzip, Dictionary: true, RowGroupSize: 1, DataPageV2: true},
+	} {
+		var buf bytes.Buffer
+		if err := parquetDataFrame().WriteParquet(&buf, opts); err != nil {
+			t.Fatalf("%+v: %v", opts, err)
+		}
+		checkColumns(t, readParquet(t, buf.Bytes()), parquetColumns)
+	}
+}
+
+// readParquet reads all columns of a Parquet file with arrow-go
+func readParquet(t *testing.T, data []byte) []column {
+	t.Helper()
+	table, err := pqarrow.ReadTable(context.Background(), bytes.NewReader(data), nil, pqarrow.ArrowReadProperties{}, memory.DefaultAllocator)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer table.Release()
+
+	columns := make([]column, table.NumCols())
+	for i := range columns {
+		c := table.Column(i)
+		columns[i] = column{name: c.Name(), typ: c.DataType().ID()}
+		for _, chunk := range c.Data().Chunks() {
+			columns[i].values = append(columns[i].values, arrayValues(t, chunk)...)
+		}
+	}
+	return columns
+}
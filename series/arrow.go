@@ -0,0 +1,889 @@
+package series
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"time"
+)
+
+// ids of the Type union in the Arrow schema
+const (
+	arrowInt       = 2
+	arrowFloat     = 3
+	arrowUtf8      = 5
+	arrowBool      = 6
+	arrowTimestamp = 10
+	arrowLargeUtf8 = 20
+)
+
+// ids of the MessageHeader union and the metadata version written
+const (
+	arrowSchemaMessage      = 1
+	arrowRecordBatchMessage = 3
+	arrowMetadataV5         = 4
+)
+
+// precisions of the FloatingPoint type and units of the Timestamp type
+const (
+	arrowSingle = 1
+	arrowDouble = 2
+
+	arrowSecond      = 0
+	arrowMillisecond = 1
+	arrowMicrosecond = 2
+	arrowNanosecond  = 3
+)
+
+// arrowContinuation starts every message of the stream
+const arrowContinuation = 0xFFFFFFFF
+
+// ArrowIndexColumn is the name of the column holding the index, the same name pyarrow uses for a pandas index
+const ArrowIndexColumn = "__index_level_0__"
+
+// arrowType is the Arrow data type of a column
+type arrowType struct {
+	id        uint8
+	bitWidth  int
+	signed    bool
+	precision int
+	unit      int
+	timezone  string
+}
+
+// arrowColumn is a decoded or to be encoded Arrow column
+// values is a slice of one of the supported Go types, valid is nil if no value is null
+type arrowColumn struct {
+	name   string
+	values any
+	valid  []bool
+}
+
+// WriteArrowIPC writes the Series in the Arrow IPC streaming format
+// the values are written to a column named like the Series and the index to ArrowIndexColumn
+func WriteArrowIPC[T comparable, R comparable](w io.Writer, s *Series[T, R]) error {
+	return NewDataFrame[R](s).WriteArrowIPC(w)
+}
+
+// WriteArrowIPC writes the DataFrame in the Arrow IPC streaming format as a single record batch
+// the index is written as the last column, named ArrowIndexColumn
+// supported value and label types are the Numeric types, string, bool and time.Time which is written as a UTC nanosecond timestamp
+// int and uint are written as 64 bit integers and missing values of a CategoricalSeries as nulls
+func (df *DataFrame[R]) WriteArrowIPC(w io.Writer) error {
+	columns := make([]arrowColumn, 0, len(df.columns)+1)
+	for _, c := range df.columns {
+		columns = append(columns, columnToArrow(c))
+	}
+	columns = append(columns, arrowColumn{name: ArrowIndexColumn, values: df.index})
+	return writeArrowStream(w, columns, len(df.index))
+}
+
+// ReadArrowIPC reads a Series from the Arrow IPC streaming format
+// the values are taken from the first column which is not ArrowIndexColumn and converted to T,
+// numeric columns can be read into any Numeric type and are converted like a Go conversion
+// without an index column R must be int and the positions are used as labels
+// nulls are read as NaN for floating point values and as the zero value otherwise
+func ReadArrowIPC[T comparable, R comparable](r io.Reader) (*Series[T, R], error) {
+	columns, length, err := readArrowStream(r)
+	if err != nil {
+		return nil, err
+	}
+
+	index, err := arrowIndex[R](columns, length)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range columns {
+		if c.name == ArrowIndexColumn {
+			continue
+		}
+		values, ok := convertArrowValues[T](c.values)
+		if !ok {
+			return nil, fmt.Errorf("cannot read arrow column %q of type %T into %T", c.name, c.values, values)
+		}
+		return NewSeries(c.name, values, index), nil
+	}
+	return nil, errors.New("arrow stream has no value column")
+}
+
+// ReadDataFrameArrowIPC reads a DataFrame from the Arrow IPC streaming format
+// every column but ArrowIndexColumn becomes a Series of the Go type matching its Arrow type,
+// e.g. int64 for 64 bit integers and time.Time for timestamps
+// without an index column R must be int and the positions are used as labels
+// nulls are read as NaN for floating point values and as the zero value otherwise
+func ReadDataFrameArrowIPC[R comparable](r io.Reader) (*DataFrame[R], error) {
+	columns, length, err := readArrowStream(r)
+	if err != nil {
+		return nil, err
+	}
+
+	index, err := arrowIndex[R](columns, length)
+	if err != nil {
+		return nil, err
+	}
+	var dfColumns []Column[R]
+	for _, c := range columns {
+		if c.name != ArrowIndexColumn {
+			dfColumns = append(dfColumns, arrowToColumn(c, index))
+		}
+	}
+	if len(dfColumns) == 0 {
+		return nil, errors.New("arrow stream has no value column")
+	}
+	return NewDataFrame(dfColumns...), nil
+}
+
+// arrowIndex returns the labels of the index column or the positions if there is none
+func arrowIndex[R comparable](columns []arrowColumn, length int) ([]R, error) {
+	if length == 0 {
+		return nil, errors.New("cannot read Series with no data from arrow stream")
+	}
+
+	for _, c := range columns {
+		if c.name == ArrowIndexColumn {
+			index, ok := convertArrowValues[R](c.values)
+			if !ok {
+				return nil, fmt.Errorf("cannot read arrow index of type %T into %T", c.values, index)
+			}
+			return index, nil
+		}
+	}
+
+	index, ok := convertArrowValues[R](rangePositions(0, length))
+	if !ok {
+		return nil, fmt.Errorf("arrow stream has no index column, cannot create labels of type %T", index)
+	}
+	return index, nil
+}
+
+// columnToArrow returns the values of a DataFrame column
+func columnToArrow[R comparable](c Column[R]) arrowColumn {
+	if cs, ok := c.(*CategoricalSeries[R]); ok {
+		valid := make([]bool, cs.Len())
+		for i, code := range cs.codes {
+			valid[i] = code != missingCode
+		}
+		return arrowColumn{name: cs.name, values: cs.Values(), valid: valid}
+	}
+
+	return arrowColumn{name: c.Name(), values: c.(interface{ rawValues() any }).rawValues()}
+}
+
+// rawValues returns the value slice without copying it
+func (s *Series[T, R]) rawValues() any {
+	return s.values
+}
+
+// arrowToColumn returns a Series holding the values of the decoded column
+func arrowToColumn[R comparable](c arrowColumn, index []R) Column[R] {
+	switch v := c.values.(type) {
+	case []int8:
+		return NewSeries(c.name, v, index)
+	case []int16:
+		return NewSeries(c.name, v, index)
+	case []int32:
+		return NewSeries(c.name, v, index)
+	case []int64:
+		return NewSeries(c.name, v, index)
+	case []uint8:
+		return NewSeries(c.name, v, index)
+	case []uint16:
+		return NewSeries(c.name, v, index)
+	case []uint32:
+		return NewSeries(c.name, v, index)
+	case []uint64:
+		return NewSeries(c.name, v, index)
+	case []float32:
+		return NewSeries(c.name, v, index)
+	case []float64:
+		return NewSeries(c.name, v, index)
+	case []string:
+		return NewSeries(c.name, v, index)
+	case []bool:
+		return NewSeries(c.name, v, index)
+	case []time.Time:
+		return NewSeries(c.name, v, index)
+	}
+	panic(fmt.Sprintf("unexpected arrow values %T", c.values))
+}
+
+// convertArrowValues returns the decoded values as []T
+func convertArrowValues[T comparable](values any) ([]T, bool) {
+	if v, ok := values.([]T); ok {
+		return v, true
+	}
+
+	switch v := values.(type) {
+	case []int:
+		return castNumeric[T](v)
+	case []int8:
+		return castNumeric[T](v)
+	case []int16:
+		return castNumeric[T](v)
+	case []int32:
+		return castNumeric[T](v)
+	case []int64:
+		return castNumeric[T](v)
+	case []uint:
+		return castNumeric[T](v)
+	case []uint8:
+		return castNumeric[T](v)
+	case []uint16:
+		return castNumeric[T](v)
+	case []uint32:
+		return castNumeric[T](v)
+	case []uint64:
+		return castNumeric[T](v)
+	case []float32:
+		return castNumeric[T](v)
+	case []float64:
+		return castNumeric[T](v)
+	}
+	return nil, false
+}
+
+// castNumeric converts the numbers to []T if T is one of the predeclared Numeric types
+func castNumeric[T comparable, S Numeric](src []S) ([]T, bool) {
+	var out any
+	switch any(*new(T)).(type) {
+	case int:
+		out = castSlice[int](src)
+	case int8:
+		out = castSlice[int8](src)
+	case int16:
+		out = castSlice[int16](src)
+	case int32:
+		out = castSlice[int32](src)
+	case int64:
+		out = castSlice[int64](src)
+	case uint:
+		out = castSlice[uint](src)
+	case uint8:
+		out = castSlice[uint8](src)
+	case uint16:
+		out = castSlice[uint16](src)
+	case uint32:
+		out = castSlice[uint32](src)
+	case uint64:
+		out = castSlice[uint64](src)
+	case float32:
+		out = castSlice[float32](src)
+	case float64:
+		out = castSlice[float64](src)
+	default:
+		return nil, false
+	}
+	return out.([]T), true
+}
+
+// castSlice converts every number of src to D
+func castSlice[D Numeric, S Numeric](src []S) []D {
+	out := make([]D, len(src))
+	for i, v := range src {
+		out[i] = D(v)
+	}
+	return out
+}
+
+// encodeArrowValues returns the Arrow type and the data buffers following the validity bitmap
+func encodeArrowValues(values any) (arrowType, [][]byte, error) {
+	switch v := values.(type) {
+	case []int:
+		return encodeFixed(castSlice[int64](v), true)
+	case []int8:
+		return encodeFixed(v, true)
+	case []int16:
+		return encodeFixed(v, true)
+	case []int32:
+		return encodeFixed(v, true)
+	case []int64:
+		return encodeFixed(v, true)
+	case []uint:
+		return encodeFixed(castSlice[uint64](v), false)
+	case []uint8:
+		return encodeFixed(v, false)
+	case []uint16:
+		return encodeFixed(v, false)
+	case []uint32:
+		return encodeFixed(v, false)
+	case []uint64:
+		return encodeFixed(v, false)
+	case []float32:
+		typ, buffers, err := encodeFixed(v, true)
+		typ = arrowType{id: arrowFloat, precision: arrowSingle}
+		return typ, buffers, err
+	case []float64:
+		typ, buffers, err := encodeFixed(v, true)
+		typ = arrowType{id: arrowFloat, precision: arrowDouble}
+		return typ, buffers, err
+	case []bool:
+		return arrowType{id: arrowBool}, [][]byte{packBits(v)}, nil
+	case []time.Time:
+		nanos := make([]int64, len(v))
+		for i, t := range v {
+			nanos[i] = t.UnixNano()
+		}
+		_, buffers, err := encodeFixed(nanos, true)
+		return arrowType{id: arrowTimestamp, unit: arrowNanosecond, timezone: "UTC"}, buffers, err
+	case []string:
+		offsets := make([]int32, 0, len(v)+1)
+		var data []byte
+		for _, s := range v {
+			offsets = append(offsets, int32(len(data)))
+			data = append(data, s...)
+			if len(data) > math.MaxInt32 {
+				return arrowType{}, nil, errors.New("string column is too large for arrow utf8")
+			}
+		}
+		offsets = append(offsets, int32(len(data)))
+		_, buffers, err := encodeFixed(offsets, true)
+		return arrowType{id: arrowUtf8}, append(buffers, data), err
+	}
+	return arrowType{}, nil, fmt.Errorf("cannot write values of type %T to arrow", values)
+}
+
+// encodeFixed returns the integer type and data buffer of fixed width values
+func encodeFixed[S Numeric](values []S, signed bool) (arrowType, [][]byte, error) {
+	data, err := binary.Append(nil, binary.LittleEndian, values)
+	if err != nil {
+		return arrowType{}, nil, err
+	}
+	var zero S
+	return arrowType{id: arrowInt, bitWidth: 8 * binary.Size(zero), signed: signed}, [][]byte{data}, nil
+}
+
+// packBits returns the LSB first bitmap of the bools
+func packBits(bits []bool) []byte {
+	bitmap := make([]byte, (len(bits)+7)/8)
+	for i, b := range bits {
+		if b {
+			bitmap[i/8] |= 1 << (i % 8)
+		}
+	}
+	return bitmap
+}
+
+// unpackBits appends the first n bits of the LSB first bitmap to bits
+func unpackBits(bits []bool, bitmap []byte, n int) ([]bool, error) {
+	if n < 0 || len(bitmap)*8 < n {
+		return nil, errors.New("arrow bitmap is too short")
+	}
+	for i := range n {
+		bits = append(bits, bitmap[i/8]&(1<<(i%8)) != 0)
+	}
+	return bits, nil
+}
+
+// writeArrowStream writes the schema, one record batch and the end of stream marker
+func writeArrowStream(w io.Writer, columns []arrowColumn, length int) error {
+	fields := make(fbTables, len(columns))
+	var nodes, buffers, body []byte
+	addBuffer := func(b []byte) {
+		buffers = binary.LittleEndian.AppendUint64(buffers, uint64(len(body)))
+		buffers = binary.LittleEndian.AppendUint64(buffers, uint64(len(b)))
+		body = append(body, b...)
+		for len(body)%8 != 0 {
+			body = append(body, 0)
+		}
+	}
+
+	for i, c := range columns {
+		typ, data, err := encodeArrowValues(c.values)
+		if err != nil {
+			return fmt.Errorf("column %q: %w", c.name, err)
+		}
+		fields[i] = arrowField(c.name, typ, c.valid != nil)
+
+		nulls := 0
+		var validity []byte
+		if c.valid != nil {
+			for _, ok := range c.valid {
+				if !ok {
+					nulls++
+				}
+			}
+			if nulls > 0 {
+				validity = packBits(c.valid)
+			}
+		}
+		nodes = binary.LittleEndian.AppendUint64(nodes, uint64(length))
+		nodes = binary.LittleEndian.AppendUint64(nodes, uint64(nulls))
+		addBuffer(validity)
+		for _, b := range data {
+			addBuffer(b)
+		}
+	}
+
+	schema := fbTable{nil, fbRef(fields)}
+	if err := writeArrowMessage(w, arrowSchemaMessage, schema, nil); err != nil {
+		return err
+	}
+	batch := fbTable{
+		fbScalar(8, uint64(length)),
+		fbRef(fbStructs{elemSize: 16, data: nodes}),
+		fbRef(fbStructs{elemSize: 16, data: buffers}),
+	}
+	if err := writeArrowMessage(w, arrowRecordBatchMessage, batch, body); err != nil {
+		return err
+	}
+
+	var eos [8]byte
+	binary.LittleEndian.PutUint32(eos[:], arrowContinuation)
+	_, err := w.Write(eos[:])
+	return err
+}
+
+// arrowField returns the Field table of a column
+func arrowField(name string, typ arrowType, nullable bool) fbTable {
+	var typeTable fbTable
+	switch typ.id {
+	case arrowInt:
+		signed := uint64(0)
+		if typ.signed {
+			signed = 1
+		}
+		typeTable = fbTable{fbScalar(4, uint64(typ.bitWidth)), fbScalar(1, signed)}
+	case arrowFloat:
+		typeTable = fbTable{fbScalar(2, uint64(typ.precision))}
+	case arrowTimestamp:
+		typeTable = fbTable{fbScalar(2, uint64(typ.unit)), fbRef(fbString(typ.timezone))}
+	}
+
+	isNullable := uint64(0)
+	if nullable {
+		isNullable = 1
+	}
+	return fbTable{
+		fbRef(fbString(name)),
+		fbScalar(1, isNullable),
+		fbScalar(1, uint64(typ.id)),
+		fbRef(typeTable),
+		nil,
+		fbRef(fbTables{}),
+	}
+}
+
+// writeArrowMessage writes an encapsulated message: continuation marker, metadata length, metadata and body
+func writeArrowMessage(w io.Writer, headerType uint8, header fbTable, body []byte) error {
+	metadata := fbBuild(fbTable{
+		fbScalar(2, arrowMetadataV5),
+		fbScalar(1, uint64(headerType)),
+		fbRef(header),
+		fbScalar(8, uint64(len(body))),
+	})
+
+	prefix := binary.LittleEndian.AppendUint32(nil, arrowContinuation)
+	prefix = binary.LittleEndian.AppendUint32(prefix, uint32(len(metadata)))
+	for _, b := range [][]byte{prefix, metadata, body} {
+		if _, err := w.Write(b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readArrowStream reads all record batches of a stream and returns their columns concatenated and the number of rows
+func readArrowStream(r io.Reader) ([]arrowColumn, int, error) {
+	var columns []arrowColumn
+	var types []arrowType
+	length := 0
+
+	for {
+		metadata, err := readArrowMetadata(r)
+		if err != nil {
+			return nil, 0, err
+		}
+		if metadata == nil {
+			break
+		}
+
+		var fbErr error
+		message := fbRoot(metadata, &fbErr)
+		headerType := message.scalar(1, 1, 0)
+		header, ok := message.table(2)
+		bodyLength := int64(message.scalar(3, 8, 0))
+		if fbErr != nil {
+			return nil, 0, fbErr
+		}
+		if !ok {
+			return nil, 0, errors.New("arrow message has no header")
+		}
+		if bodyLength < 0 {
+			return nil, 0, errors.New("negative arrow body length")
+		}
+
+		var body bytes.Buffer
+		if _, err := io.CopyN(&body, r, bodyLength); err != nil {
+			return nil, 0, fmt.Errorf("reading arrow message body: %w", err)
+		}
+
+		switch headerType {
+		case arrowSchemaMessage:
+			if columns != nil {
+				return nil, 0, errors.New("arrow stream has more than one schema")
+			}
+			columns, types, err = readArrowSchema(header)
+		case arrowRecordBatchMessage:
+			if columns == nil {
+				return nil, 0, errors.New("arrow record batch before schema")
+			}
+			var n int
+			n, err = readArrowBatch(header, body.Bytes(), columns, types)
+			length += n
+		default:
+			err = fmt.Errorf("unsupported arrow message type %d", headerType)
+		}
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+
+	if columns == nil {
+		return nil, 0, errors.New("arrow stream has no schema")
+	}
+	for i := range columns {
+		columns[i].values = nullsToZero(columns[i].values, columns[i].valid)
+	}
+	return columns, length, nil
+}
+
+// readArrowMetadata reads the prefix and metadata of the next message, it returns nil at the end of the stream
+// streams written before the continuation marker was introduced start directly with the length
+func readArrowMetadata(r io.Reader) ([]byte, error) {
+	var word [4]byte
+	if _, err := io.ReadFull(r, word[:]); err != nil {
+		if err == io.EOF {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading arrow message: %w", err)
+	}
+	size := binary.LittleEndian.Uint32(word[:])
+	if size == arrowContinuation {
+		if _, err := io.ReadFull(r, word[:]); err != nil {
+			return nil, fmt.Errorf("reading arrow message: %w", err)
+		}
+		size = binary.LittleEndian.Uint32(word[:])
+	}
+	if size == 0 {
+		return nil, nil
+	}
+
+	var metadata bytes.Buffer
+	if _, err := io.CopyN(&metadata, r, int64(size)); err != nil {
+		return nil, fmt.Errorf("reading arrow message: %w", err)
+	}
+	return metadata.Bytes(), nil
+}
+
+// readArrowSchema returns empty columns and the types of the fields of the Schema table
+func readArrowSchema(schema fbReader) ([]arrowColumn, []arrowType, error) {
+	start, n := schema.vector(1, 4)
+	columns := make([]arrowColumn, n)
+	types := make([]arrowType, n)
+	for i := range n {
+		field := schema.tableAt(start + 4*i)
+		if _, ok := field.table(4); ok {
+			return nil, nil, errors.New("dictionary encoded arrow columns are not supported")
+		}
+		if _, children := field.vector(5, 4); children > 0 {
+			return nil, nil, errors.New("nested arrow columns are not supported")
+		}
+
+		typ := arrowType{id: uint8(field.scalar(2, 1, 0))}
+		typeTable, _ := field.table(3)
+		switch typ.id {
+		case arrowInt:
+			typ.bitWidth = int(int32(typeTable.scalar(0, 4, 0)))
+			typ.signed = typeTable.scalar(1, 1, 0) != 0
+		case arrowFloat:
+			typ.precision = int(typeTable.scalar(0, 2, 0))
+		case arrowTimestamp:
+			typ.unit = int(typeTable.scalar(0, 2, 0))
+			typ.timezone = typeTable.string(1)
+		}
+		columns[i].name = field.string(0)
+		types[i] = typ
+	}
+	if *schema.err != nil {
+		return nil, nil, *schema.err
+	}
+	return columns, types, nil
+}
+
+// readArrowBatch appends the values of the RecordBatch table to the columns and returns its number of rows
+func readArrowBatch(batch fbReader, body []byte, columns []arrowColumn, types []arrowType) (int, error) {
+	length := int(int64(batch.scalar(0, 8, 0)))
+	if length < 0 {
+		return 0, fmt.Errorf("arrow record batch has a negative length %d", length)
+	}
+	if _, ok := batch.table(3); ok {
+		return 0, errors.New("compressed arrow record batches are not supported")
+	}
+
+	nodesStart, nodes := batch.vector(1, 16)
+	buffersStart, nBuffers := batch.vector(2, 16)
+	if nodes != len(columns) {
+		return 0, fmt.Errorf("arrow record batch has %d columns, schema has %d", nodes, len(columns))
+	}
+	buffer := func(i int) ([]byte, error) {
+		if i >= nBuffers {
+			return nil, errors.New("arrow record batch has too few buffers")
+		}
+		offset := int64(batch.u64(buffersStart + 16*i))
+		size := int64(batch.u64(buffersStart + 16*i + 8))
+		if offset < 0 || size < 0 || offset+size > int64(len(body)) {
+			return nil, errors.New("arrow buffer is out of bounds of the message body")
+		}
+		return body[offset : offset+size], nil
+	}
+
+	// every column has as many values as the record batch, checked before any buffer is decoded
+	for i := range columns {
+		if n := int(int64(batch.u64(nodesStart + 16*i))); n != length {
+			return 0, fmt.Errorf("arrow column %q has %d values, record batch has %d", columns[i].name, n, length)
+		}
+	}
+	if *batch.err != nil {
+		return 0, *batch.err
+	}
+
+	next := 0
+	for i := range columns {
+		n := length
+		nulls := int64(batch.u64(nodesStart + 16*i + 8))
+
+		buffers := 2
+		if types[i].id == arrowUtf8 || types[i].id == arrowLargeUtf8 {
+			buffers = 3
+		}
+		data := make([][]byte, buffers)
+		for j := range data {
+			b, err := buffer(next)
+			if err != nil {
+				return 0, err
+			}
+			data[j] = b
+			next++
+		}
+		if *batch.err != nil {
+			return 0, *batch.err
+		}
+
+		values, err := decodeArrowValues(columns[i].values, types[i], data[1:], n)
+		if err != nil {
+			return 0, fmt.Errorf("arrow column %q: %w", columns[i].name, err)
+		}
+		columns[i].values = values
+
+		if nulls > 0 && columns[i].valid == nil {
+			// every value of earlier batches was valid
+			columns[i].valid = slices.Repeat([]bool{true}, valuesLen(values)-n)
+		}
+		if columns[i].valid != nil {
+			if nulls > 0 {
+				columns[i].valid, err = unpackBits(columns[i].valid, data[0], n)
+			} else {
+				columns[i].valid = append(columns[i].valid, slices.Repeat([]bool{true}, n)...)
+			}
+			if err != nil {
+				return 0, fmt.Errorf("arrow column %q: %w", columns[i].name, err)
+			}
+		}
+	}
+	return length, nil
+}
+
+// valuesLen returns the length of a slice of decoded values
+func valuesLen(values any) int {
+	switch v := values.(type) {
+	case []int8:
+		return len(v)
+	case []int16:
+		return len(v)
+	case []int32:
+		return len(v)
+	case []int64:
+		return len(v)
+	case []uint8:
+		return len(v)
+	case []uint16:
+		return len(v)
+	case []uint32:
+		return len(v)
+	case []uint64:
+		return len(v)
+	case []float32:
+		return len(v)
+	case []float64:
+		return len(v)
+	case []string:
+		return len(v)
+	case []bool:
+		return len(v)
+	case []time.Time:
+		return len(v)
+	}
+	return 0
+}
+
+// decodeArrowValues appends n values of the data buffers to the decoded values of earlier batches
+func decodeArrowValues(prev any, typ arrowType, data [][]byte, n int) (any, error) {
+	switch {
+	case typ.id == arrowInt && typ.signed:
+		switch typ.bitWidth {
+		case 8:
+			return appendFixed[int8](prev, data[0], n)
+		case 16:
+			return appendFixed[int16](prev, data[0], n)
+		case 32:
+			return appendFixed[int32](prev, data[0], n)
+		case 64:
+			return appendFixed[int64](prev, data[0], n)
+		}
+	case typ.id == arrowInt:
+		switch typ.bitWidth {
+		case 8:
+			return appendFixed[uint8](prev, data[0], n)
+		case 16:
+			return appendFixed[uint16](prev, data[0], n)
+		case 32:
+			return appendFixed[uint32](prev, data[0], n)
+		case 64:
+			return appendFixed[uint64](prev, data[0], n)
+		}
+	case typ.id == arrowFloat && typ.precision == arrowSingle:
+		return appendFixed[float32](prev, data[0], n)
+	case typ.id == arrowFloat && typ.precision == arrowDouble:
+		return appendFixed[float64](prev, data[0], n)
+	case typ.id == arrowBool:
+		values, _ := prev.([]bool)
+		return unpackBits(values, data[0], n)
+	case typ.id == arrowTimestamp:
+		return appendTimestamps(prev, typ, data[0], n)
+	case typ.id == arrowUtf8:
+		offsets, err := appendFixed[int32](nil, data[0], n+1)
+		if err != nil {
+			return nil, err
+		}
+		return appendStrings(prev, offsets.([]int32), data[1])
+	case typ.id == arrowLargeUtf8:
+		offsets, err := appendFixed[int64](nil, data[0], n+1)
+		if err != nil {
+			return nil, err
+		}
+		return appendStrings(prev, offsets.([]int64), data[1])
+	}
+	return nil, fmt.Errorf("unsupported arrow type %+v", typ)
+}
+
+// appendFixed appends n little endian values of type S read from data
+func appendFixed[S Numeric](prev any, data []byte, n int) (any, error) {
+	var zero S
+	// n comes from the stream, dividing instead of multiplying cannot overflow
+	if n < 0 || n > len(data)/binary.Size(zero) {
+		return nil, errors.New("arrow data buffer is too short")
+	}
+
+	values, _ := prev.([]S)
+	start := len(values)
+	values = slices.Grow(values, n)[:start+n]
+	if _, err := binary.Decode(data, binary.LittleEndian, values[start:]); err != nil {
+		return nil, errors.New("arrow data buffer is too short")
+	}
+	return values, nil
+}
+
+// appendStrings appends the strings between consecutive offsets into data
+func appendStrings[O int32 | int64](prev any, offsets []O, data []byte) (any, error) {
+	values, _ := prev.([]string)
+	for i := range len(offsets) - 1 {
+		start, end := offsets[i], offsets[i+1]
+		if start < 0 || start > end || int64(end) > int64(len(data)) {
+			return nil, errors.New("arrow string offsets are out of bounds")
+		}
+		values = append(values, string(data[start:end]))
+	}
+	return values, nil
+}
+
+// appendTimestamps appends n timestamps, they are returned in the location of the timezone or UTC
+func appendTimestamps(prev any, typ arrowType, data []byte, n int) (any, error) {
+	raw, err := appendFixed[int64](nil, data, n)
+	if err != nil {
+		return nil, err
+	}
+
+	loc := time.UTC
+	if typ.timezone != "" {
+		if l, err := time.LoadLocation(typ.timezone); err == nil {
+			loc = l
+		}
+	}
+
+	values, _ := prev.([]time.Time)
+	for _, v := range raw.([]int64) {
+		var t time.Time
+		switch typ.unit {
+		case arrowSecond:
+			t = time.Unix(v, 0)
+		case arrowMillisecond:
+			t = time.UnixMilli(v)
+		case arrowMicrosecond:
+			t = time.UnixMicro(v)
+		default:
+			t = time.Unix(0, v)
+		}
+		values = append(values, t.In(loc))
+	}
+	return values, nil
+}
+
+// nullsToZero replaces null values by NaN for floating point values and by the zero value otherwise
+func nullsToZero(values any, valid []bool) any {
+	if valid == nil {
+		return values
+	}
+
+	switch v := values.(type) {
+	case []int8:
+		clearNulls(v, valid, 0)
+	case []int16:
+		clearNulls(v, valid, 0)
+	case []int32:
+		clearNulls(v, valid, 0)
+	case []int64:
+		clearNulls(v, valid, 0)
+	case []uint8:
+		clearNulls(v, valid, 0)
+	case []uint16:
+		clearNulls(v, valid, 0)
+	case []uint32:
+		clearNulls(v, valid, 0)
+	case []uint64:
+		clearNulls(v, valid, 0)
+	case []float32:
+		clearNulls(v, valid, float32(math.NaN()))
+	case []float64:
+		clearNulls(v, valid, math.NaN())
+	case []string:
+		clearNulls(v, valid, "")
+	case []bool:
+		clearNulls(v, valid, false)
+	case []time.Time:
+		clearNulls(v, valid, time.Time{})
+	}
+	return values
+}
+
+// clearNulls sets every value which is not valid to null
+func clearNulls[T any](values []T, valid []bool, null T) {
+	for i, ok := range valid {
+		if !ok {
+			values[i] = null
+		}
+	}
+}
@@ -0,0 +1,413 @@
+package series
+
+import (
+	"bytes"
+	"encoding/binary"
+	"flag"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+// the golden files pin the exact bytes written, regenerate them with go test -run Golden -update
+// interop/arrow_test.go checks that arrow-go reads them
+var update = flag.Bool("update", false, "update the golden files in testdata")
+
+func goldenSeries() *Series[float64, string] {
+	return NewSeries("price", []float64{1.5, -2, math.Inf(1)}, []string{"a", "b", "c"})
+}
+
+func goldenDataFrame() *DataFrame[int] {
+	index := []int{10, 20, 30, 40}
+	return NewDataFrame[int](
+		NewSeries("id", []int32{1, -2, 3, -4}, index),
+		NewSeries("count", []uint8{0, 1, 254, 255}, index),
+		NewSeries("ratio", []float32{0.5, 0.25, 0, -1}, index),
+		NewSeries("name", []string{"alice", "", "bob", "ünïcode"}, index),
+		NewSeries("ok", []bool{true, false, false, true}, index),
+		NewSeries("at", []time.Time{
+			time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC),
+			time.Date(1969, 12, 31, 23, 59, 59, 0, time.UTC),
+			time.Unix(0, 0).UTC(),
+			time.Date(2262, 4, 11, 0, 0, 0, 0, time.UTC),
+		}, index),
+		NewCategoricalSeries("grade", []string{"b", "x", "a", "b"}, index, []string{"a", "b"}, true),
+	)
+}
+
+func checkGolden(t *testing.T, name string, got []byte) {
+	t.Helper()
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("written bytes differ from %s", path)
+	}
+}
+
+func TestArrowIPC_Golden(t *testing.T) {
+	t.Run("series", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := WriteArrowIPC(&buf, goldenSeries()); err != nil {
+			t.Fatal(err)
+		}
+		checkGolden(t, "series.arrows", buf.Bytes())
+	})
+
+	t.Run("dataframe", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := goldenDataFrame().WriteArrowIPC(&buf); err != nil {
+			t.Fatal(err)
+		}
+		checkGolden(t, "dataframe.arrows", buf.Bytes())
+	})
+
+	t.Run("reads golden series", func(t *testing.T) {
+		f, err := os.Open(filepath.Join("testdata", "series.arrows"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+
+		s, err := ReadArrowIPC[float64, string](f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := goldenSeries()
+		if s.Name() != want.Name() || !slices.Equal(s.Values(), want.Values()) || !slices.Equal(s.Index(), want.Index()) {
+			t.Errorf("expected %v, got %v", want, s)
+		}
+	})
+}
+
+// checkArrowGoDataFrame checks the values of the files written by arrow-go, see interop/arrow_test.go
+// nulls are read as NaN into floats and as the zero value otherwise
+func checkArrowGoDataFrame(t *testing.T, df *DataFrame[string]) {
+	t.Helper()
+	nan := math.NaN()
+	if !slices.Equal(df.Index(), []string{"r0", "r1", "r2", "r3", "r4"}) {
+		t.Errorf("unexpected index %v", df.Index())
+	}
+	checkColumn(t, df, "i8", []int8{1, -2, 3, -4, 5})
+	checkColumn(t, df, "i16", []int16{100, -200, 300, -400, 500})
+	checkColumn(t, df, "i32", []int32{1, 0, 3, 0, 5})
+	checkColumn(t, df, "i64", []int64{1 << 40, -1, 0, math.MaxInt64, math.MinInt64})
+	checkColumn(t, df, "u8", []uint8{0, 1, 2, 254, 255})
+	checkColumn(t, df, "u16", []uint16{0, math.MaxUint16, 1, 2, 3})
+	checkColumn(t, df, "u32", []uint32{0, math.MaxUint32, 1, 2, 3})
+	checkColumn(t, df, "u64", []uint64{0, math.MaxUint64, 1, 2, 3})
+	if f32 := GetColumn[float32](df, "f32").Values(); !slices.EqualFunc(f32, []float32{0.5, -1.5, float32(nan), 2, 3}, func(a, b float32) bool {
+		return a == b || a != a && b != b
+	}) {
+		t.Errorf("unexpected f32 %v", f32)
+	}
+	if f64 := GetColumn[float64](df, "f64").Values(); !equalNaN(f64, []float64{1.5, nan, math.Inf(1), -0.25, 2.25}) {
+		t.Errorf("unexpected f64 %v", f64)
+	}
+	checkColumn(t, df, "s", []string{"a", "", "", "ünïcode", "e"})
+	checkColumn(t, df, "ls", []string{"x", "y", "z", "w", "v"})
+	checkColumn(t, df, "b", []bool{true, false, false, true, false})
+
+	checkTimes := func(name string, want []time.Time) {
+		t.Helper()
+		got := GetColumn[time.Time](df, name).Values()
+		if !slices.EqualFunc(got, want, time.Time.Equal) {
+			t.Errorf("expected %s %v, got %v", name, want, got)
+		}
+	}
+	checkTimes("ts_s", []time.Time{time.Unix(-1, 0), time.Unix(0, 0), time.Unix(1704164645, 0), time.Unix(1704251045, 0), time.Unix(4102444800, 0)})
+	checkTimes("ts_ms", []time.Time{time.UnixMilli(-1500), time.Unix(0, 0), time.UnixMilli(1704164645123), time.UnixMilli(1704251045123), time.UnixMilli(4102444800000)})
+	// null timestamps are read as the zero time
+	checkTimes("ts_us", []time.Time{time.UnixMicro(-1500000), {}, time.UnixMicro(1704164645123456), {}, time.UnixMicro(4102444800000000)})
+	checkTimes("ts_ns", []time.Time{time.Unix(0, -1500000000), time.Unix(0, 0), time.Unix(0, 1704164645123456789), time.Unix(0, 1704251045123456789), time.Unix(0, 9223372036000000000)})
+}
+
+// checkColumn checks the values of a column of a DataFrame
+func checkColumn[T comparable](t *testing.T, df *DataFrame[string], name string, want []T) {
+	t.Helper()
+	if got := GetColumn[T](df, name).Values(); !slices.Equal(got, want) {
+		t.Errorf("expected %s %v, got %v", name, want, got)
+	}
+}
+
+func TestArrowIPC_ArrowGo(t *testing.T) {
+	f, err := os.Open(filepath.Join("testdata", "arrowgo.arrows"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	// written by arrow-go in two record batches, see interop/arrow_test.go
+	df, err := ReadDataFrameArrowIPC[string](f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkArrowGoDataFrame(t, df)
+}
+
+func TestArrowIPC_Layout(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteArrowIPC(&buf, NewIndexSeries("x", []int64{7, 8})); err != nil {
+		t.Fatal(err)
+	}
+	stream := buf.Bytes()
+
+	if binary.LittleEndian.Uint32(stream) != arrowContinuation {
+		t.Fatal("stream must start with the continuation marker")
+	}
+	if !bytes.Equal(stream[len(stream)-8:], []byte{0xFF, 0xFF, 0xFF, 0xFF, 0, 0, 0, 0}) {
+		t.Error("stream must end with the end of stream marker")
+	}
+
+	size := int(binary.LittleEndian.Uint32(stream[4:]))
+	if size%8 != 0 {
+		t.Errorf("metadata must be padded to 8 bytes, got %d", size)
+	}
+
+	var err error
+	message := fbRoot(stream[8:8+size], &err)
+	if message.scalar(0, 2, 0) != arrowMetadataV5 || message.scalar(1, 1, 0) != arrowSchemaMessage {
+		t.Fatal("first message must be a V5 schema")
+	}
+	schema, _ := message.table(2)
+	start, n := schema.vector(1, 4)
+	if n != 2 {
+		t.Fatalf("expected 2 fields, got %d", n)
+	}
+	for i, name := range []string{"x", ArrowIndexColumn} {
+		field := schema.tableAt(start + 4*i)
+		typ, _ := field.table(3)
+		if field.string(0) != name || field.scalar(2, 1, 0) != arrowInt {
+			t.Errorf("field %d: expected int column %q, got %q", i, name, field.string(0))
+		}
+		if typ.scalar(0, 4, 0) != 64 || typ.scalar(1, 1, 0) != 1 {
+			t.Errorf("field %d: expected signed 64 bit integer", i)
+		}
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the record batch body holds the values 7, 8 and the labels 0, 1 each behind an empty validity buffer
+	rest := stream[8+size:]
+	batchSize := int(binary.LittleEndian.Uint32(rest[4:]))
+	body := rest[8+batchSize : len(rest)-8]
+	want := binary.LittleEndian.AppendUint64(nil, 7)
+	want = binary.LittleEndian.AppendUint64(want, 8)
+	want = binary.LittleEndian.AppendUint64(want, 0)
+	want = binary.LittleEndian.AppendUint64(want, 1)
+	if !bytes.Equal(body, want) {
+		t.Errorf("unexpected body % x", body)
+	}
+}
+
+func TestArrowIPC_RoundTrip(t *testing.T) {
+	t.Run("dataframe", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := goldenDataFrame().WriteArrowIPC(&buf); err != nil {
+			t.Fatal(err)
+		}
+		df, err := ReadDataFrameArrowIPC[int](&buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !slices.Equal(df.Index(), []int{10, 20, 30, 40}) {
+			t.Errorf("unexpected index %v", df.Index())
+		}
+		if !slices.Equal(GetColumn[int32](df, "id").Values(), []int32{1, -2, 3, -4}) {
+			t.Error("unexpected id column")
+		}
+		if !slices.Equal(GetColumn[uint8](df, "count").Values(), []uint8{0, 1, 254, 255}) {
+			t.Error("unexpected count column")
+		}
+		if !slices.Equal(GetColumn[float32](df, "ratio").Values(), []float32{0.5, 0.25, 0, -1}) {
+			t.Error("unexpected ratio column")
+		}
+		if !slices.Equal(GetColumn[string](df, "name").Values(), []string{"alice", "", "bob", "ünïcode"}) {
+			t.Error("unexpected name column")
+		}
+		if !slices.Equal(GetColumn[bool](df, "ok").Values(), []bool{true, false, false, true}) {
+			t.Error("unexpected ok column")
+		}
+		times := GetColumn[time.Time](df, "at").Values()
+		for i, want := range GetColumn[time.Time](goldenDataFrame(), "at").Values() {
+			if !times[i].Equal(want) {
+				t.Errorf("expected time %v, got %v", want, times[i])
+			}
+		}
+		// the missing category is written as null and read back as ""
+		if !slices.Equal(GetColumn[string](df, "grade").Values(), []string{"b", "", "a", "b"}) {
+			t.Error("unexpected grade column")
+		}
+	})
+
+	t.Run("converts numeric values", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := WriteArrowIPC(&buf, NewIndexSeries("n", []int{1, 2, 3})); err != nil {
+			t.Fatal(err)
+		}
+		s, err := ReadArrowIPC[float64, uint16](&buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(s.Values(), []float64{1, 2, 3}) || !slices.Equal(s.Index(), []uint16{0, 1, 2}) {
+			t.Errorf("unexpected series %v", s)
+		}
+	})
+
+	t.Run("keeps NaN", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := WriteArrowIPC(&buf, NewIndexSeries("n", []float64{math.NaN(), 1})); err != nil {
+			t.Fatal(err)
+		}
+		s, err := ReadArrowIPC[float64, int](&buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !math.IsNaN(s.At(0)) || s.At(1) != 1 {
+			t.Errorf("unexpected values %v", s.Values())
+		}
+	})
+
+	t.Run("uses positions without index column", func(t *testing.T) {
+		var buf bytes.Buffer
+		columns := []arrowColumn{{name: "v", values: []float64{1, 2, 3}, valid: []bool{true, false, true}}}
+		if err := writeArrowStream(&buf, columns, 3); err != nil {
+			t.Fatal(err)
+		}
+		stream := buf.Bytes()
+
+		s, err := ReadArrowIPC[float64, int](bytes.NewReader(stream))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(s.Index(), []int{0, 1, 2}) || s.At(0) != 1 || !math.IsNaN(s.At(1)) {
+			t.Errorf("unexpected series %v", s)
+		}
+
+		if _, err := ReadArrowIPC[float64, string](bytes.NewReader(stream)); err == nil {
+			t.Error("expected error for string labels without index column")
+		}
+	})
+}
+
+func TestArrowIPC_RecordBatches(t *testing.T) {
+	write := func(values []float64, valid []bool, index []int) []byte {
+		var buf bytes.Buffer
+		columns := []arrowColumn{{name: "v", values: values, valid: valid}, {name: ArrowIndexColumn, values: index}}
+		if err := writeArrowStream(&buf, columns, len(values)); err != nil {
+			t.Fatal(err)
+		}
+		return buf.Bytes()
+	}
+	first := write([]float64{1, 2}, nil, []int{0, 1})
+	second := write([]float64{3, 4}, []bool{false, true}, []int{2, 3})
+
+	// the first stream without its end of stream marker followed by the record batch of the second
+	schemaSize := 8 + int(binary.LittleEndian.Uint32(second[4:]))
+	stream := slices.Concat(first[:len(first)-8], second[schemaSize:])
+
+	s, err := ReadArrowIPC[float64, int](bytes.NewReader(stream))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(s.Index(), []int{0, 1, 2, 3}) {
+		t.Errorf("unexpected index %v", s.Index())
+	}
+	if s.At(0) != 1 || s.At(1) != 2 || !math.IsNaN(s.At(2)) || s.At(3) != 4 {
+		t.Errorf("unexpected values %v", s.Values())
+	}
+}
+
+func TestArrowIPC_Errors(t *testing.T) {
+	t.Run("unsupported type", func(t *testing.T) {
+		type point struct{ x, y int }
+		s := NewIndexSeries("p", []point{{1, 2}})
+		if err := WriteArrowIPC(&bytes.Buffer{}, s); err == nil {
+			t.Error("expected error for struct values")
+		}
+	})
+
+	t.Run("wrong value type", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := WriteArrowIPC(&buf, goldenSeries()); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := ReadArrowIPC[string, string](&buf); err == nil {
+			t.Error("expected error reading floats as strings")
+		}
+	})
+
+	t.Run("truncated and corrupted streams", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := goldenDataFrame().WriteArrowIPC(&buf); err != nil {
+			t.Fatal(err)
+		}
+		stream := buf.Bytes()
+
+		// every prefix misses at least the record batch or the end of stream marker
+		for n := 0; n < len(stream)-8; n++ {
+			if _, err := ReadDataFrameArrowIPC[int](bytes.NewReader(stream[:n])); err == nil {
+				t.Fatalf("expected error for stream truncated to %d bytes", n)
+			}
+		}
+
+		// lengths which overflow when multiplied by the value size must not reach the allocation
+		for _, n := range []int{math.MaxInt, math.MaxInt/8 + 1, -1} {
+			if _, err := appendFixed[int64](nil, make([]byte, 64), n); err == nil {
+				t.Errorf("expected error for %d values", n)
+			}
+		}
+
+		// flipping bytes must never panic
+		for i := range stream {
+			corrupted := slices.Clone(stream)
+			corrupted[i] ^= 0xA5
+			_, _ = ReadDataFrameArrowIPC[int](bytes.NewReader(corrupted))
+		}
+	})
+
+	t.Run("negative lengths", func(t *testing.T) {
+		var buf bytes.Buffer
+		columns := []arrowColumn{{name: "s", values: []string{"ab", "cd", "ef", "gh", "ij", "kl", "mn"}}}
+		if err := writeArrowStream(&buf, columns, 7); err != nil {
+			t.Fatal(err)
+		}
+		stream := buf.Bytes()
+
+		// the record batch length and the field node length are the only int64 sevens of the stream
+		var lengths []int
+		for i := 0; i+8 <= len(stream); i++ {
+			if binary.LittleEndian.Uint64(stream[i:]) == 7 {
+				lengths = append(lengths, i)
+			}
+		}
+		if len(lengths) != 2 {
+			t.Fatalf("expected 2 lengths in the stream, found %d", len(lengths))
+		}
+
+		patch := func(at ...int) []byte {
+			patched := slices.Clone(stream)
+			for _, i := range at {
+				binary.LittleEndian.PutUint64(patched[i:], math.MaxUint64)
+			}
+			return patched
+		}
+		for _, patched := range [][]byte{patch(lengths...), patch(lengths[0]), patch(lengths[1])} {
+			if _, err := ReadArrowIPC[string, int](bytes.NewReader(patched)); err == nil {
+				t.Error("expected error for a negative length")
+			}
+		}
+	})
+}
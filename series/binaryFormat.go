@@ -0,0 +1,463 @@
+package series
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"io"
+	"math"
+	"slices"
+	"time"
+)
+
+// The native binary format stores a Series as
+//
+//	magic      "PNGO"
+//	version    uint16
+//	flags      uint16
+//	headerSize uint32, the size of the header fields below
+//	  name       uvarint length and bytes
+//	  valueType  uvarint length and bytes, the Go type name like float64 or time.Time
+//	  indexType  uvarint length and bytes
+//	  length     uint64, the number of values
+//	buffers    uint16, the number of buffers
+//	  size       uint64 and data of the values buffer
+//	  size       uint64 and data of the index buffer
+//	checksum   uint32 CRC-32C of everything before it, only if the checksum flag is set
+//
+// all integers are little endian. Later versions may append header fields and buffers,
+// readers skip what they do not know. Flags in the low byte change how the data must be read,
+// a reader fails on low flags it does not know and ignores unknown high flags.
+//
+// Fixed width values are stored back to back, int and uint as 64 bit and bool as one byte.
+// Strings are stored as length+1 uint64 offsets followed by the bytes of all strings.
+// Times are stored as int64 seconds and int32 nanoseconds since the unix epoch and read back in UTC.
+
+// binaryMagic starts every Series in the native binary format
+var binaryMagic = []byte("PNGO")
+
+// binaryVersion is the version of the native binary format written
+const binaryVersion = 1
+
+// binaryChecksum is the flag set if a CRC-32C checksum follows the buffers
+const binaryChecksum = 1 << 8
+
+// binaryRequiredFlags are the flags a reader must understand
+const binaryRequiredFlags = 0xFF
+
+// castagnoli is the table of the CRC-32C checksum
+var castagnoli = crc32.MakeTable(crc32.Castagnoli)
+
+// BinaryOptions configures how a Series is written in the native binary format
+type BinaryOptions struct {
+	// Checksum appends a CRC-32C checksum which is verified when reading
+	Checksum bool
+}
+
+// binaryHeader is the header of a Series in the native binary format
+type binaryHeader struct {
+	version   uint16
+	flags     uint16
+	name      string
+	valueType string
+	indexType string
+	length    uint64
+	// extra holds header fields of later versions
+	extra []byte
+}
+
+// WriteTo writes the Series in the native binary format with a checksum
+// it implements io.WriterTo
+func (s *Series[T, R]) WriteTo(w io.Writer) (int64, error) {
+	return WriteSeries(w, s, BinaryOptions{Checksum: true})
+}
+
+// WriteSeries writes the Series in the native binary format
+// supported value and label types are the Numeric types, string, bool and time.Time
+func WriteSeries[T comparable, R comparable](w io.Writer, s *Series[T, R], opts BinaryOptions) (int64, error) {
+	valueType, values, err := encodeBinaryValues(s.values)
+	if err != nil {
+		return 0, err
+	}
+	indexType, index, err := encodeBinaryValues(s.index)
+	if err != nil {
+		return 0, err
+	}
+
+	header := binaryHeader{
+		version:   binaryVersion,
+		name:      s.name,
+		valueType: valueType,
+		indexType: indexType,
+		length:    uint64(len(s.values)),
+	}
+	if opts.Checksum {
+		header.flags |= binaryChecksum
+	}
+	return writeBinary(w, header, [][]byte{values, index})
+}
+
+// ReadSeries reads a Series in the native binary format
+// values and labels are converted to T and R, numeric types can be read into any Numeric type
+// and are converted like a Go conversion
+// it reads exactly one Series, so several Series written one after another can be read in turn
+func ReadSeries[T comparable, R comparable](r io.Reader) (*Series[T, R], error) {
+	header, buffers, err := readBinary(r)
+	if err != nil {
+		return nil, err
+	}
+	if header.length == 0 {
+		return nil, errors.New("cannot read Series with no data")
+	}
+
+	rawValues, err := decodeBinaryValues(header.valueType, buffers[0], header.length)
+	if err != nil {
+		return nil, fmt.Errorf("values: %w", err)
+	}
+	rawIndex, err := decodeBinaryValues(header.indexType, buffers[1], header.length)
+	if err != nil {
+		return nil, fmt.Errorf("index: %w", err)
+	}
+
+	values, ok := convertArrowValues[T](rawValues)
+	if !ok {
+		return nil, fmt.Errorf("cannot read values of type %s into %T", header.valueType, values)
+	}
+	index, ok := convertArrowValues[R](rawIndex)
+	if !ok {
+		return nil, fmt.Errorf("cannot read labels of type %s into %T", header.indexType, index)
+	}
+	return NewSeries(header.name, values, index), nil
+}
+
+// MarshalBinary returns the Series in the native binary format with a checksum
+// it implements encoding.BinaryMarshaler, which also makes the Series usable with encoding/gob
+func (s *Series[T, R]) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	if _, err := s.WriteTo(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary replaces the Series by the one in data, which must be in the native binary format
+// it implements encoding.BinaryUnmarshaler
+func (s *Series[T, R]) UnmarshalBinary(data []byte) error {
+	read, err := ReadSeries[T, R](bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	*s = *read
+	return nil
+}
+
+// MarshalBinary returns the NumericSeries in the native binary format with a checksum
+func (ns *NumericSeries[T, R]) MarshalBinary() ([]byte, error) {
+	return ns.Series.MarshalBinary()
+}
+
+// UnmarshalBinary replaces the NumericSeries by the one in data, which must be in the native binary format
+// unlike the method of the embedded Series it also works on a zero NumericSeries like the one gob decodes into
+func (ns *NumericSeries[T, R]) UnmarshalBinary(data []byte) error {
+	read, err := ReadSeries[T, R](bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	ns.Series = read
+	return nil
+}
+
+// encodeBinaryValues returns the type name and the buffer of the values
+func encodeBinaryValues(values any) (string, []byte, error) {
+	var name string
+	var fixed any
+	switch v := values.(type) {
+	case []int:
+		name, fixed = "int", castSlice[int64](v)
+	case []uint:
+		name, fixed = "uint", castSlice[uint64](v)
+	case []int8, []int16, []int32, []int64, []uint8, []uint16, []uint32, []uint64, []float32, []float64, []bool:
+		// the element type name without the leading []
+		name, fixed = fmt.Sprintf("%T", v)[2:], v
+	case []string:
+		data := make([]byte, 0, 8*(len(v)+1))
+		offset := uint64(0)
+		for _, s := range v {
+			data = binary.LittleEndian.AppendUint64(data, offset)
+			offset += uint64(len(s))
+		}
+		data = binary.LittleEndian.AppendUint64(data, offset)
+		for _, s := range v {
+			data = append(data, s...)
+		}
+		return "string", data, nil
+	case []time.Time:
+		data := make([]byte, 0, 12*len(v))
+		for _, t := range v {
+			data = binary.LittleEndian.AppendUint64(data, uint64(t.Unix()))
+			data = binary.LittleEndian.AppendUint32(data, uint32(t.Nanosecond()))
+		}
+		return "time.Time", data, nil
+	default:
+		return "", nil, fmt.Errorf("cannot write values of type %T in the binary format", values)
+	}
+
+	data, err := binary.Append(nil, binary.LittleEndian, fixed)
+	return name, data, err
+}
+
+// decodeBinaryValues decodes n values of the named type from the buffer
+func decodeBinaryValues(typeName string, data []byte, n uint64) (any, error) {
+	// every value takes at least one byte, checking it first avoids allocating for a corrupt length
+	if n > uint64(len(data)) {
+		return nil, errors.New("buffer is too short")
+	}
+
+	switch typeName {
+	case "int":
+		values, err := decodeBinaryFixed[int64](data, n)
+		if err != nil {
+			return nil, err
+		}
+		return castSlice[int](values), nil
+	case "uint":
+		values, err := decodeBinaryFixed[uint64](data, n)
+		if err != nil {
+			return nil, err
+		}
+		return castSlice[uint](values), nil
+	case "int8":
+		return decodeBinaryFixed[int8](data, n)
+	case "int16":
+		return decodeBinaryFixed[int16](data, n)
+	case "int32":
+		return decodeBinaryFixed[int32](data, n)
+	case "int64":
+		return decodeBinaryFixed[int64](data, n)
+	case "uint8":
+		return decodeBinaryFixed[uint8](data, n)
+	case "uint16":
+		return decodeBinaryFixed[uint16](data, n)
+	case "uint32":
+		return decodeBinaryFixed[uint32](data, n)
+	case "uint64":
+		return decodeBinaryFixed[uint64](data, n)
+	case "float32":
+		return decodeBinaryFixed[float32](data, n)
+	case "float64":
+		return decodeBinaryFixed[float64](data, n)
+	case "bool":
+		return decodeBinaryFixed[bool](data, n)
+	case "string":
+		if uint64(len(data))/8 < n+1 {
+			return nil, errors.New("buffer is too short")
+		}
+		offsets, err := decodeBinaryFixed[uint64](data[:8*(n+1)], n+1)
+		if err != nil {
+			return nil, err
+		}
+		strs := data[8*(n+1):]
+		values := make([]string, n)
+		for i := range values {
+			start, end := offsets[i], offsets[i+1]
+			if start > end || end > uint64(len(strs)) {
+				return nil, errors.New("string offsets are out of bounds")
+			}
+			values[i] = string(strs[start:end])
+		}
+		return values, nil
+	case "time.Time":
+		if uint64(len(data)) != 12*n {
+			return nil, errors.New("buffer size does not match the length")
+		}
+		values := make([]time.Time, n)
+		for i := range values {
+			sec := int64(binary.LittleEndian.Uint64(data[12*i:]))
+			nsec := int64(binary.LittleEndian.Uint32(data[12*i+8:]))
+			values[i] = time.Unix(sec, nsec).UTC()
+		}
+		return values, nil
+	}
+	return nil, fmt.Errorf("unsupported value type %q", typeName)
+}
+
+// decodeBinaryFixed decodes n little endian values which must fill the whole buffer
+func decodeBinaryFixed[S Numeric | bool](data []byte, n uint64) ([]S, error) {
+	var zero S
+	if uint64(len(data)) != n*uint64(binary.Size(zero)) {
+		return nil, errors.New("buffer size does not match the length")
+	}
+	values := make([]S, n)
+	if _, err := binary.Decode(data, binary.LittleEndian, values); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// writeBinary writes the header and buffers and returns the number of bytes written
+func writeBinary(w io.Writer, header binaryHeader, buffers [][]byte) (int64, error) {
+	var fields []byte
+	for _, s := range []string{header.name, header.valueType, header.indexType} {
+		fields = binary.AppendUvarint(fields, uint64(len(s)))
+		fields = append(fields, s...)
+	}
+	fields = binary.LittleEndian.AppendUint64(fields, header.length)
+	fields = append(fields, header.extra...)
+
+	buf := slices.Clone(binaryMagic)
+	buf = binary.LittleEndian.AppendUint16(buf, header.version)
+	buf = binary.LittleEndian.AppendUint16(buf, header.flags)
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(len(fields)))
+	buf = append(buf, fields...)
+	buf = binary.LittleEndian.AppendUint16(buf, uint16(len(buffers)))
+
+	checksum := crc32.New(castagnoli)
+	written := int64(0)
+	write := func(b []byte) error {
+		checksum.Write(b)
+		n, err := w.Write(b)
+		written += int64(n)
+		return err
+	}
+
+	if err := write(buf); err != nil {
+		return written, err
+	}
+	for _, b := range buffers {
+		if err := write(binary.LittleEndian.AppendUint64(nil, uint64(len(b)))); err != nil {
+			return written, err
+		}
+		if err := write(b); err != nil {
+			return written, err
+		}
+	}
+	if header.flags&binaryChecksum != 0 {
+		n, err := w.Write(binary.LittleEndian.AppendUint32(nil, checksum.Sum32()))
+		written += int64(n)
+		return written, err
+	}
+	return written, nil
+}
+
+// binaryReader reads exactly the requested bytes and feeds them into the checksum
+// it reads from data without copying if r is nil and skips the checksum if it is nil
+type binaryReader struct {
+	r        io.Reader
+	data     []byte
+	checksum hash.Hash32
+}
+
+// read reads n bytes without allocating them up front, so a corrupt size fails at the end of the input
+func (br *binaryReader) read(n uint64) ([]byte, error) {
+	var b []byte
+	switch {
+	case br.r == nil:
+		if n > uint64(len(br.data)) {
+			return nil, io.ErrUnexpectedEOF
+		}
+		b, br.data = br.data[:n], br.data[n:]
+	case n > math.MaxInt64:
+		return nil, io.ErrUnexpectedEOF
+	default:
+		var buf bytes.Buffer
+		if _, err := io.CopyN(&buf, br.r, int64(n)); err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return nil, err
+		}
+		b = buf.Bytes()
+	}
+	if br.checksum != nil {
+		br.checksum.Write(b)
+	}
+	return b, nil
+}
+
+// readBinary reads the header and the values and index buffers, unknown header fields and buffers are skipped
+func readBinary(r io.Reader) (binaryHeader, [][]byte, error) {
+	return decodeBinary(&binaryReader{r: r, checksum: crc32.New(castagnoli)})
+}
+
+// decodeBinary decodes a Series in the native binary format from the reader
+// the checksum is only verified if the reader computes it
+func decodeBinary(br *binaryReader) (binaryHeader, [][]byte, error) {
+	var header binaryHeader
+
+	prefix, err := br.read(12)
+	if err != nil {
+		return header, nil, fmt.Errorf("reading binary header: %w", err)
+	}
+	if !bytes.Equal(prefix[:4], binaryMagic) {
+		return header, nil, errors.New("not a series in the binary format")
+	}
+	header.version = binary.LittleEndian.Uint16(prefix[4:])
+	header.flags = binary.LittleEndian.Uint16(prefix[6:])
+	if header.version == 0 {
+		return header, nil, errors.New("invalid binary format version 0")
+	}
+	if unknown := header.flags & binaryRequiredFlags; unknown != 0 {
+		return header, nil, fmt.Errorf("binary format version %d uses unsupported flags %#x", header.version, unknown)
+	}
+
+	fields, err := br.read(uint64(binary.LittleEndian.Uint32(prefix[8:])))
+	if err != nil {
+		return header, nil, fmt.Errorf("reading binary header: %w", err)
+	}
+	var strs [3]string
+	for i := range strs {
+		size, n := binary.Uvarint(fields)
+		if n <= 0 || size > uint64(len(fields)-n) {
+			return header, nil, errors.New("corrupt binary header")
+		}
+		strs[i] = string(fields[n : n+int(size)])
+		fields = fields[n+int(size):]
+	}
+	if len(fields) < 8 {
+		return header, nil, errors.New("corrupt binary header")
+	}
+	header.name, header.valueType, header.indexType = strs[0], strs[1], strs[2]
+	header.length = binary.LittleEndian.Uint64(fields)
+	header.extra = fields[8:]
+
+	count, err := br.read(2)
+	if err != nil {
+		return header, nil, fmt.Errorf("reading binary buffers: %w", err)
+	}
+	n := int(binary.LittleEndian.Uint16(count))
+	if n < 2 {
+		return header, nil, errors.New("binary format needs a values and an index buffer")
+	}
+	buffers := make([][]byte, 0, 2)
+	for i := range n {
+		size, err := br.read(8)
+		if err != nil {
+			return header, nil, fmt.Errorf("reading binary buffers: %w", err)
+		}
+		b, err := br.read(binary.LittleEndian.Uint64(size))
+		if err != nil {
+			return header, nil, fmt.Errorf("reading binary buffers: %w", err)
+		}
+		if i < 2 {
+			buffers = append(buffers, b)
+		}
+	}
+
+	if header.flags&binaryChecksum != 0 {
+		var want uint32
+		if br.checksum != nil {
+			want = br.checksum.Sum32()
+		}
+		sum, err := br.read(4)
+		if err != nil {
+			return header, nil, fmt.Errorf("reading binary checksum: %w", err)
+		}
+		if br.checksum != nil && binary.LittleEndian.Uint32(sum) != want {
+			return header, nil, errors.New("binary checksum mismatch")
+		}
+	}
+	return header, buffers, nil
+}
@@ -0,0 +1,230 @@
+package series
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/gob"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+// the fixtures in testdata make sure data written by earlier and later versions of the format stays readable,
+// -update only rewrites the ones of the current version
+
+func fixtureSeries() *Series[float64, string] {
+	return NewSeries("price", []float64{1.5, math.NaN(), -2, math.Inf(1)}, []string{"a", "", "ünïcode", "d"})
+}
+
+func TestBinaryFormat_RoundTrip(t *testing.T) {
+	t.Run("all types", func(t *testing.T) {
+		times := []time.Time{
+			time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC),
+			time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC),
+			time.Date(9999, 12, 31, 23, 59, 59, 999999999, time.UTC),
+		}
+		checkBinaryRoundTrip(t, NewSeries("i", []int{math.MinInt, 0, math.MaxInt}, times))
+		checkBinaryRoundTrip(t, NewSeries("u", []uint{math.MaxUint, 0, 1}, []int8{-1, 0, 1}))
+		checkBinaryRoundTrip(t, NewSeries("i16", []int16{-3, 0, 3}, []uint16{1, 2, 3}))
+		checkBinaryRoundTrip(t, NewSeries("i32", []int32{-3, 0, 3}, []uint32{1, 2, 3}))
+		checkBinaryRoundTrip(t, NewSeries("i64", []int64{-3, 0, 3}, []uint64{1, 2, 3}))
+		checkBinaryRoundTrip(t, NewSeries("u8", []uint8{0, 1, 255}, []float32{1, 2, 3}))
+		checkBinaryRoundTrip(t, NewSeries("b", []bool{true, false, true}, []string{"x", "y", "z"}))
+		checkBinaryRoundTrip(t, NewSeries("", times, []float64{1, 2, 3}))
+	})
+
+	t.Run("converts numeric types", func(t *testing.T) {
+		var buf bytes.Buffer
+		if _, err := NewIndexSeries("n", []int16{1, 2, 3}).WriteTo(&buf); err != nil {
+			t.Fatal(err)
+		}
+		s, err := ReadSeries[float64, uint8](&buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(s.Values(), []float64{1, 2, 3}) || !slices.Equal(s.Index(), []uint8{0, 1, 2}) {
+			t.Errorf("unexpected series %v", s)
+		}
+	})
+
+	t.Run("reads several series from one stream", func(t *testing.T) {
+		var buf bytes.Buffer
+		for _, opts := range []BinaryOptions{{Checksum: true}, {}, {Checksum: true}} {
+			if _, err := WriteSeries(&buf, NewIndexSeries("n", []int{buf.Len()}), opts); err != nil {
+				t.Fatal(err)
+			}
+		}
+		for range 3 {
+			if _, err := ReadSeries[int, int](&buf); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if buf.Len() != 0 {
+			t.Errorf("expected all bytes read, %d left", buf.Len())
+		}
+	})
+
+	t.Run("reports bytes written", func(t *testing.T) {
+		var buf bytes.Buffer
+		n, err := fixtureSeries().WriteTo(&buf)
+		if err != nil || n != int64(buf.Len()) {
+			t.Errorf("expected %d bytes written, got %d and error %v", buf.Len(), n, err)
+		}
+	})
+}
+
+func checkBinaryRoundTrip[T comparable, R comparable](t *testing.T, s *Series[T, R]) {
+	t.Helper()
+	data, err := s.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var read Series[T, R]
+	if err := read.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if read.Name() != s.Name() || !slices.Equal(read.Values(), s.Values()) || !slices.Equal(read.Index(), s.Index()) {
+		t.Errorf("expected %v, got %v", s, &read)
+	}
+}
+
+func TestBinaryFormat_Gob(t *testing.T) {
+	type cache struct {
+		Prices  *Series[float64, string]
+		Volumes *NumericSeries[int, int]
+	}
+	in := cache{
+		Prices:  fixtureSeries(),
+		Volumes: NewNumericSeries("volume", []int{10, 20}, []int{1, 2}),
+	}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(in); err != nil {
+		t.Fatal(err)
+	}
+	var out cache
+	if err := gob.NewDecoder(&buf).Decode(&out); err != nil {
+		t.Fatal(err)
+	}
+
+	if out.Prices.Name() != "price" || out.Prices.At(0) != 1.5 || !math.IsNaN(out.Prices.At(1)) {
+		t.Errorf("unexpected prices %v", out.Prices)
+	}
+	if out.Volumes.Sum() != 30 || !slices.Equal(out.Volumes.Index(), []int{1, 2}) {
+		t.Errorf("unexpected volumes %v", out.Volumes)
+	}
+
+	var _ encoding.BinaryMarshaler = in.Prices
+	var _ encoding.BinaryUnmarshaler = in.Volumes
+	var _ io.WriterTo = in.Prices
+}
+
+func TestBinaryFormat_Fixtures(t *testing.T) {
+	t.Run("current version", func(t *testing.T) {
+		var buf bytes.Buffer
+		if _, err := fixtureSeries().WriteTo(&buf); err != nil {
+			t.Fatal(err)
+		}
+		checkGolden(t, "series_v1.pngo", buf.Bytes())
+	})
+
+	t.Run("current version without checksum", func(t *testing.T) {
+		var buf bytes.Buffer
+		s := NewSeries("counts", []int{3, 1, 2}, []time.Time{time.Unix(0, 0).UTC(), time.Unix(1, 5).UTC(), time.Unix(-1, 0).UTC()})
+		if _, err := WriteSeries(&buf, s, BinaryOptions{}); err != nil {
+			t.Fatal(err)
+		}
+		checkGolden(t, "series_v1_nochecksum.pngo", buf.Bytes())
+	})
+
+	t.Run("reads version 1", func(t *testing.T) {
+		data, err := os.ReadFile(filepath.Join("testdata", "series_v1.pngo"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		s, err := ReadSeries[float64, string](bytes.NewReader(data))
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := fixtureSeries()
+		if s.Name() != want.Name() || !slices.Equal(s.Index(), want.Index()) || s.At(0) != 1.5 || !math.IsNaN(s.At(1)) {
+			t.Errorf("expected %v, got %v", want, s)
+		}
+	})
+
+	t.Run("reads a later version", func(t *testing.T) {
+		// series_v2.pngo was written by a made up version 2 which sets an unknown optional flag
+		// and appends the header field 01 02 03 and a third buffer holding "statistics"
+		data, err := os.ReadFile(filepath.Join("testdata", "series_v2.pngo"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		s, err := ReadSeries[int32, string](bytes.NewReader(data))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s.Name() != "future" || !slices.Equal(s.Values(), []int32{7, -7}) || !slices.Equal(s.Index(), []string{"x", "y"}) {
+			t.Errorf("unexpected series %v", s)
+		}
+	})
+}
+
+func TestBinaryFormat_Errors(t *testing.T) {
+	var buf bytes.Buffer
+	if _, err := fixtureSeries().WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	data := buf.Bytes()
+
+	t.Run("truncated", func(t *testing.T) {
+		for n := range len(data) {
+			if _, err := ReadSeries[float64, string](bytes.NewReader(data[:n])); err == nil {
+				t.Fatalf("expected error for %d bytes", n)
+			}
+		}
+	})
+
+	t.Run("checksum mismatch", func(t *testing.T) {
+		corrupted := slices.Clone(data)
+		corrupted[len(corrupted)-10] ^= 1
+		if _, err := ReadSeries[float64, string](bytes.NewReader(corrupted)); err == nil {
+			t.Error("expected checksum error")
+		}
+	})
+
+	t.Run("unknown required flag", func(t *testing.T) {
+		var buf bytes.Buffer
+		header := binaryHeader{version: 2, flags: 1, valueType: "int", indexType: "int", length: 1}
+		if _, err := writeBinary(&buf, header, [][]byte{make([]byte, 8), make([]byte, 8)}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := ReadSeries[int, int](&buf); err == nil {
+			t.Error("expected error for unknown required flag")
+		}
+	})
+
+	t.Run("wrong type", func(t *testing.T) {
+		if _, err := ReadSeries[string, string](bytes.NewReader(data)); err == nil {
+			t.Error("expected error reading floats as strings")
+		}
+	})
+
+	t.Run("unsupported type", func(t *testing.T) {
+		type point struct{ x, y int }
+		if _, err := NewIndexSeries("p", []point{{1, 2}}).WriteTo(&bytes.Buffer{}); err == nil {
+			t.Error("expected error for struct values")
+		}
+	})
+
+	t.Run("flipped bytes never panic", func(t *testing.T) {
+		for i := range data {
+			corrupted := slices.Clone(data)
+			corrupted[i] ^= 0xA5
+			_, _ = ReadSeries[float64, string](bytes.NewReader(corrupted))
+		}
+	})
+}
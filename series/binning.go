@@ -0,0 +1,180 @@
+package series
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+)
+
+// Cut puts every value of the Series into one of the bins given by the edges
+// right: true for bins closed on the right (a, b], false for bins closed on the left [a, b)
+// the outermost edges are always included so the lowest (or highest) value lands in a bin
+// labels names the bins and must have one entry per bin, if nil the intervals are used as labels
+// the result is a CategoricalSeries ordered like the bins, values outside of the edges and NaN are missing
+func Cut[T Numeric, R comparable](ns *NumericSeries[T, R], bins []float64, right bool, labels []string) *CategoricalSeries[R] {
+	if len(bins) < 2 {
+		panic("need at least two bin edges")
+	}
+	for i := 1; i < len(bins); i++ {
+		if bins[i] <= bins[i-1] {
+			panic("bin edges must increase monotonically")
+		}
+	}
+
+	if labels == nil {
+		labels = intervalLabels(bins, right)
+	}
+	if len(labels) != len(bins)-1 {
+		panic("labels length must match number of bins")
+	}
+
+	categoryLookup(labels)
+
+	codes := make([]int32, ns.Len())
+	for i, v := range ns.values {
+		codes[i] = int32(binOf(bins, float64(v), right))
+	}
+
+	return &CategoricalSeries[R]{
+		name:       ns.name,
+		codes:      codes,
+		categories: slices.Clone(labels),
+		ordered:    true,
+		index:      ns.index,
+	}
+}
+
+// CutN puts every value of the Series into one of nBins bins of equal width between Min and Max
+// see Cut for the meaning of right and labels
+func CutN[T Numeric, R comparable](ns *NumericSeries[T, R], nBins int, right bool, labels []string) *CategoricalSeries[R] {
+	return Cut(ns, equalWidthEdges(ns, nBins), right, labels)
+}
+
+// QCut puts every value of the Series into one of q bins holding about the same number of values
+// the edges are the quantiles of the values, see Cut for the meaning of labels
+// panics if some quantiles are equal because the bins would not be unique
+func QCut[T Numeric, R comparable](ns *NumericSeries[T, R], q int, labels []string) *CategoricalSeries[R] {
+	if q <= 0 {
+		panic("number of quantiles must be positive")
+	}
+
+	sorted := make([]float64, 0, ns.Len())
+	for _, v := range ns.values {
+		if !isNaN(v) {
+			sorted = append(sorted, float64(v))
+		}
+	}
+	if len(sorted) == 0 {
+		panic("cannot compute quantiles of series with only NaN values")
+	}
+	slices.Sort(sorted)
+
+	edges := make([]float64, q+1)
+	for i := range edges {
+		edges[i] = quantileSorted(sorted, float64(i)/float64(q))
+	}
+	for i := 1; i < len(edges); i++ {
+		if edges[i] == edges[i-1] {
+			panic(fmt.Sprintf("bin edges must be unique, %v occurs more than once", edges[i]))
+		}
+	}
+
+	return Cut(ns, edges, true, labels)
+}
+
+// Histogram counts the values in bins of equal width between Min and Max
+// returns the counts and the bins+1 edges, every bin is [a, b) except the last one which is [a, b]
+// NaN values are not counted
+func (ns *NumericSeries[T, R]) Histogram(bins int) ([]int, []float64) {
+	edges := equalWidthEdges(ns, bins)
+
+	counts := make([]int, bins)
+	for _, v := range ns.values {
+		if bin := binOf(edges, float64(v), false); bin >= 0 {
+			counts[bin]++
+		}
+	}
+	return counts, edges
+}
+
+// equalWidthEdges returns the edges of nBins bins of equal width between Min and Max
+// a Series where all values are equal gets bins around that value
+func equalWidthEdges[T Numeric, R comparable](ns *NumericSeries[T, R], nBins int) []float64 {
+	if nBins <= 0 {
+		panic("number of bins must be positive")
+	}
+
+	lowest, highest := float64(ns.Min()), float64(ns.Max())
+	if math.IsNaN(lowest) || math.IsNaN(highest) {
+		lowest, highest = nanMinMax(ns.values)
+	}
+	if lowest == highest {
+		lowest, highest = lowest-0.5, highest+0.5
+	}
+
+	width := (highest - lowest) / float64(nBins)
+	edges := make([]float64, nBins+1)
+	for i := range edges {
+		edges[i] = lowest + float64(i)*width
+	}
+	// avoid losing the largest value to rounding
+	edges[nBins] = highest
+	return edges
+}
+
+// nanMinMax returns the smallest and largest value which is not NaN
+func nanMinMax[T Numeric](values []T) (float64, float64) {
+	lowest, highest := math.Inf(1), math.Inf(-1)
+	for _, v := range values {
+		if isNaN(v) {
+			continue
+		}
+		lowest = min(lowest, float64(v))
+		highest = max(highest, float64(v))
+	}
+	if lowest > highest {
+		panic("cannot bin series with only NaN values")
+	}
+	return lowest, highest
+}
+
+// binOf returns the bin of v for the given edges or -1 if it is outside of them
+func binOf(edges []float64, v float64, right bool) int {
+	last := len(edges) - 1
+	if math.IsNaN(v) || v < edges[0] || v > edges[last] {
+		return -1
+	}
+
+	var bin int
+	if right {
+		// first edge >= v closes the bin on the right
+		bin = sort.SearchFloat64s(edges, v) - 1
+	} else {
+		// last edge <= v opens the bin on the left
+		bin = sort.Search(len(edges), func(i int) bool { return edges[i] > v }) - 1
+	}
+	return min(max(bin, 0), last-1)
+}
+
+// intervalLabels returns labels like (0, 10] for the bins between the edges
+func intervalLabels(edges []float64, right bool) []string {
+	labels := make([]string, len(edges)-1)
+	for i := range labels {
+		if right {
+			labels[i] = fmt.Sprintf("(%g, %g]", edges[i], edges[i+1])
+		} else {
+			labels[i] = fmt.Sprintf("[%g, %g)", edges[i], edges[i+1])
+		}
+	}
+	return labels
+}
+
+// quantileSorted returns the q-th quantile of the sorted values using linear interpolation
+func quantileSorted(sorted []float64, q float64) float64 {
+	pos := q * float64(len(sorted)-1)
+	lower := int(math.Floor(pos))
+	upper := int(math.Ceil(pos))
+	frac := pos - float64(lower)
+	return sorted[lower] + (sorted[upper]-sorted[lower])*frac
+}
@@ -0,0 +1,145 @@
+package series
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestCut(t *testing.T) {
+	t.Run("uses intervals closed on the right", func(t *testing.T) {
+		ns := NewNumericSeries("age", []int{0, 5, 10, 11, 20}, []string{"a", "b", "c", "d", "e"})
+
+		result := Cut(ns, []float64{0, 10, 20}, true, nil)
+		expected := []string{"(0, 10]", "(0, 10]", "(0, 10]", "(10, 20]", "(10, 20]"}
+
+		if !slices.Equal(result.Values(), expected) {
+			t.Errorf("expected %v, got %v", expected, result.Values())
+		}
+		if result.Get("d") != "(10, 20]" {
+			t.Error("labels should be preserved")
+		}
+		if !result.Ordered() || !slices.Equal(result.Categories(), []string{"(0, 10]", "(10, 20]"}) {
+			t.Errorf("expected ordered bins as categories, got %v", result.Categories())
+		}
+	})
+
+	t.Run("uses intervals closed on the left", func(t *testing.T) {
+		ns := NewIndexNumericSeries("age", []float64{0, 9.9, 10, 20})
+
+		result := Cut(ns, []float64{0, 10, 20}, false, nil)
+		expected := []string{"[0, 10)", "[0, 10)", "[10, 20)", "[10, 20)"}
+
+		if !slices.Equal(result.Values(), expected) {
+			t.Errorf("expected %v, got %v", expected, result.Values())
+		}
+	})
+
+	t.Run("uses custom labels", func(t *testing.T) {
+		ns := NewIndexNumericSeries("age", []int{3, 15, 70})
+
+		result := Cut(ns, []float64{0, 12, 65, 120}, true, []string{"child", "adult", "senior"})
+		expected := []string{"child", "adult", "senior"}
+
+		if !slices.Equal(result.Values(), expected) {
+			t.Errorf("expected %v, got %v", expected, result.Values())
+		}
+	})
+
+	t.Run("gives values outside the bins an empty label", func(t *testing.T) {
+		ns := NewIndexNumericSeries("x", []float64{-1, 5, 100, math.NaN()})
+
+		result := Cut(ns, []float64{0, 10}, true, nil)
+		expected := []string{"", "(0, 10]", "", ""}
+
+		if !slices.Equal(result.Values(), expected) {
+			t.Errorf("expected %v, got %v", expected, result.Values())
+		}
+	})
+
+	t.Run("panics with unsorted edges", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("expected panic for unsorted edges")
+			}
+		}()
+		Cut(NewIndexNumericSeries("x", []int{1}), []float64{0, 10, 5}, true, nil)
+	})
+
+	t.Run("panics with wrong number of labels", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("expected panic for wrong number of labels")
+			}
+		}()
+		Cut(NewIndexNumericSeries("x", []int{1}), []float64{0, 10}, true, []string{"a", "b"})
+	})
+}
+
+func TestCutN(t *testing.T) {
+	ns := NewIndexNumericSeries("x", []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10})
+
+	result := CutN(ns, 2, true, []string{"low", "high"})
+	expected := []string{"low", "low", "low", "low", "low", "low", "high", "high", "high", "high", "high"}
+
+	if !slices.Equal(result.Values(), expected) {
+		t.Errorf("expected %v, got %v", expected, result.Values())
+	}
+}
+
+func TestQCut(t *testing.T) {
+	t.Run("splits into equally sized bins", func(t *testing.T) {
+		ns := NewIndexNumericSeries("x", []int{8, 1, 6, 3, 2, 7, 4, 5})
+
+		result := QCut(ns, 4, []string{"q1", "q2", "q3", "q4"})
+		expected := []string{"q4", "q1", "q3", "q2", "q1", "q4", "q2", "q3"}
+
+		if !slices.Equal(result.Values(), expected) {
+			t.Errorf("expected %v, got %v", expected, result.Values())
+		}
+	})
+
+	t.Run("panics with duplicate edges", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("expected panic for duplicate edges")
+			}
+		}()
+		QCut(NewIndexNumericSeries("x", []int{1, 1, 1, 1, 2}), 4, nil)
+	})
+}
+
+func TestHistogram(t *testing.T) {
+	t.Run("counts values per bin", func(t *testing.T) {
+		ns := NewIndexNumericSeries("x", []float64{1, 2, 2, 3, 3, 3, 4, 5})
+
+		counts, edges := ns.Histogram(4)
+		expectedCounts := []int{1, 2, 3, 2}
+		expectedEdges := []float64{1, 2, 3, 4, 5}
+
+		if !slices.Equal(counts, expectedCounts) {
+			t.Errorf("expected counts %v, got %v", expectedCounts, counts)
+		}
+		if !slices.Equal(edges, expectedEdges) {
+			t.Errorf("expected edges %v, got %v", expectedEdges, edges)
+		}
+	})
+
+	t.Run("ignores NaN values", func(t *testing.T) {
+		ns := NewIndexNumericSeries("x", []float64{math.NaN(), 0, 10})
+
+		counts, _ := ns.Histogram(2)
+		if counts[0] != 1 || counts[1] != 1 {
+			t.Errorf("unexpected counts %v", counts)
+		}
+	})
+
+	t.Run("handles constant series", func(t *testing.T) {
+		ns := NewIndexNumericSeries("x", []int{7, 7, 7})
+
+		counts, _ := ns.Histogram(1)
+		if counts[0] != 3 {
+			t.Errorf("expected count 3, got %d", counts[0])
+		}
+	})
+}
@@ -0,0 +1,417 @@
+package series
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// missingCode is the code of a value which is not one of the categories
+const missingCode = -1
+
+// A CategoricalSeries holds strings from a small set of categories
+// every value is stored as the integer code of its category instead of the full string
+// a value which is not one of the categories is missing and returned as ""
+type CategoricalSeries[R comparable] struct {
+	name       string
+	codes      []int32
+	categories []string
+	ordered    bool
+	index      []R
+}
+
+// NewCategoricalSeries creates a new CategoricalSeries
+// categories lists the allowed values, if nil the distinct values in order of appearance are used
+// ordered categories can be compared, their order is the order of categories
+func NewCategoricalSeries[R comparable](name string, values []string, index []R, categories []string, ordered bool) *CategoricalSeries[R] {
+	if len(values) == 0 {
+		panic("cannot create Series with no data")
+	}
+
+	if index == nil {
+		panic("needs an index if you dont have one use IndexedSeries")
+	}
+
+	if len(index) != len(values) {
+		panic("index length must match values length")
+	}
+
+	if categories == nil {
+		for _, v := range values {
+			if v != "" && !slices.Contains(categories, v) {
+				categories = append(categories, v)
+			}
+		}
+	}
+
+	lookup := categoryLookup(categories)
+	codes := make([]int32, len(values))
+	for i, v := range values {
+		code, ok := lookup[v]
+		if !ok {
+			code = missingCode
+		}
+		codes[i] = code
+	}
+
+	return &CategoricalSeries[R]{
+		name:       name,
+		codes:      codes,
+		categories: slices.Clone(categories),
+		ordered:    ordered,
+		index:      index,
+	}
+}
+
+// ToCategorical converts a string Series into a CategoricalSeries
+// see NewCategoricalSeries for the meaning of categories and ordered
+func ToCategorical[R comparable](s *Series[string, R], categories []string, ordered bool) *CategoricalSeries[R] {
+	return NewCategoricalSeries(s.name, s.values, s.index, categories, ordered)
+}
+
+// ToSeries converts the CategoricalSeries back into a string Series, missing values become ""
+func (cs *CategoricalSeries[R]) ToSeries() *Series[string, R] {
+	return NewSeries(cs.name, cs.Values(), cs.index)
+}
+
+// categoryLookup maps every category to its code and panics on duplicates
+func categoryLookup(categories []string) map[string]int32 {
+	lookup := make(map[string]int32, len(categories))
+	for i, c := range categories {
+		if _, ok := lookup[c]; ok {
+			panic(fmt.Sprintf("duplicate category %q", c))
+		}
+		lookup[c] = int32(i)
+	}
+	return lookup
+}
+
+// Len return the length of the CategoricalSeries
+func (cs *CategoricalSeries[R]) Len() int {
+	return len(cs.codes)
+}
+
+// Name return the name of the CategoricalSeries
+func (cs *CategoricalSeries[R]) Name() string {
+	return cs.name
+}
+
+// SetName sets the Name of the CategoricalSeries
+func (cs *CategoricalSeries[R]) SetName(name string) {
+	cs.name = name
+}
+
+// Ordered reports whether the categories have an order
+func (cs *CategoricalSeries[R]) Ordered() bool {
+	return cs.ordered
+}
+
+// Categories returns a copy of the categories
+func (cs *CategoricalSeries[R]) Categories() []string {
+	return slices.Clone(cs.categories)
+}
+
+// Codes returns a copy of the codes, -1 marks a missing value
+func (cs *CategoricalSeries[R]) Codes() []int32 {
+	return slices.Clone(cs.codes)
+}
+
+// Index returns the index of the CategoricalSeries as a copy of the index slice
+func (cs *CategoricalSeries[R]) Index() []R {
+	return slices.Clone(cs.index)
+}
+
+// Values returns the values as strings, missing values are ""
+func (cs *CategoricalSeries[R]) Values() []string {
+	values := make([]string, len(cs.codes))
+	for i := range cs.codes {
+		values[i] = cs.At(i)
+	}
+	return values
+}
+
+// At returns the value at the given index of the slice, "" if it is missing
+func (cs *CategoricalSeries[R]) At(i int) string {
+	if i < 0 || i >= cs.Len() {
+		panic(fmt.Sprintf("index %d out of bounds", i))
+	}
+	if cs.codes[i] == missingCode {
+		return ""
+	}
+	return cs.categories[cs.codes[i]]
+}
+
+// Get returns the value for the given label
+func (cs *CategoricalSeries[R]) Get(label R) string {
+	for i := range cs.index {
+		if cs.index[i] == label {
+			return cs.At(i)
+		}
+	}
+	panic(fmt.Sprintf("no value found for label %v", label))
+}
+
+// IsNA returns a bool Series which is true where the value is missing
+func (cs *CategoricalSeries[R]) IsNA() *Series[bool, R] {
+	mask := make([]bool, len(cs.codes))
+	for i, code := range cs.codes {
+		mask[i] = code == missingCode
+	}
+	return NewSeries(cs.name, mask, cs.index)
+}
+
+// String returns a string representation of the CategoricalSeries
+func (cs *CategoricalSeries[R]) String() string {
+	var sb strings.Builder
+	sb.WriteString(cs.ToSeries().String())
+
+	sep := ", "
+	if cs.ordered {
+		sep = " < "
+	}
+	sb.WriteString(fmt.Sprintf("Categories (%d): [%s]\n", len(cs.categories), strings.Join(cs.categories, sep)))
+
+	return sb.String()
+}
+
+// withCodes returns a CategoricalSeries with the same name, labels and order but new codes and categories
+func (cs *CategoricalSeries[R]) withCodes(codes []int32, categories []string) *CategoricalSeries[R] {
+	return &CategoricalSeries[R]{
+		name:       cs.name,
+		codes:      codes,
+		categories: categories,
+		ordered:    cs.ordered,
+		index:      cs.index,
+	}
+}
+
+// AddCategories returns a new CategoricalSeries with the given categories appended
+// the codes stay the same, so this does not touch the values
+func (cs *CategoricalSeries[R]) AddCategories(categories ...string) *CategoricalSeries[R] {
+	combined := append(slices.Clone(cs.categories), categories...)
+	categoryLookup(combined)
+	return cs.withCodes(slices.Clone(cs.codes), combined)
+}
+
+// RemoveUnused returns a new CategoricalSeries without the categories no value uses
+// the order of the remaining categories is kept
+func (cs *CategoricalSeries[R]) RemoveUnused() *CategoricalSeries[R] {
+	used := make([]bool, len(cs.categories))
+	for _, code := range cs.codes {
+		if code != missingCode {
+			used[code] = true
+		}
+	}
+
+	remap := make([]int32, len(cs.categories))
+	var categories []string
+	for code, c := range cs.categories {
+		remap[code] = missingCode
+		if used[code] {
+			remap[code] = int32(len(categories))
+			categories = append(categories, c)
+		}
+	}
+
+	codes := make([]int32, len(cs.codes))
+	for i, code := range cs.codes {
+		codes[i] = missingCode
+		if code != missingCode {
+			codes[i] = remap[code]
+		}
+	}
+	return cs.withCodes(codes, categories)
+}
+
+// Rename returns a new CategoricalSeries with the categories renamed by the given mapping
+// categories missing from the mapping keep their name
+func (cs *CategoricalSeries[R]) Rename(mapping map[string]string) *CategoricalSeries[R] {
+	categories := make([]string, len(cs.categories))
+	for i, c := range cs.categories {
+		if renamed, ok := mapping[c]; ok {
+			c = renamed
+		}
+		categories[i] = c
+	}
+	categoryLookup(categories)
+	return cs.withCodes(slices.Clone(cs.codes), categories)
+}
+
+// ValueCounts returns how often each category occurs, in the order of the categories
+// it counts the codes and never compares strings
+func (cs *CategoricalSeries[R]) ValueCounts() *Series[int, string] {
+	counts := make([]int, len(cs.categories))
+	for _, code := range cs.codes {
+		if code != missingCode {
+			counts[code]++
+		}
+	}
+	return NewSeries(cs.name, counts, cs.Categories())
+}
+
+// Groups returns the positions of the values of every category
+// categories without values get a nil slice
+func (cs *CategoricalSeries[R]) Groups() map[string][]int {
+	positions := make([][]int, len(cs.categories))
+	for i, code := range cs.codes {
+		if code != missingCode {
+			positions[code] = append(positions[code], i)
+		}
+	}
+
+	groups := make(map[string][]int, len(cs.categories))
+	for code, c := range cs.categories {
+		groups[c] = positions[code]
+	}
+	return groups
+}
+
+// compareMask returns a bool Series which is true where keep returns true for the difference
+// between the code of the value and the code of the given category
+// only works for ordered categories, missing values are always false
+func (cs *CategoricalSeries[R]) compareMask(category string, keep func(diff int32) bool) *Series[bool, R] {
+	if !cs.ordered {
+		panic("comparison needs ordered categories")
+	}
+	target, ok := categoryLookup(cs.categories)[category]
+	if !ok {
+		panic(fmt.Sprintf("unknown category %q", category))
+	}
+
+	mask := make([]bool, len(cs.codes))
+	for i, code := range cs.codes {
+		mask[i] = code != missingCode && keep(code-target)
+	}
+	return NewSeries(cs.name, mask, cs.index)
+}
+
+// Less returns a bool Series which is true where the value comes before the given category
+func (cs *CategoricalSeries[R]) Less(category string) *Series[bool, R] {
+	return cs.compareMask(category, func(diff int32) bool { return diff < 0 })
+}
+
+// LessEqual returns a bool Series which is true where the value does not come after the given category
+func (cs *CategoricalSeries[R]) LessEqual(category string) *Series[bool, R] {
+	return cs.compareMask(category, func(diff int32) bool { return diff <= 0 })
+}
+
+// Greater returns a bool Series which is true where the value comes after the given category
+func (cs *CategoricalSeries[R]) Greater(category string) *Series[bool, R] {
+	return cs.compareMask(category, func(diff int32) bool { return diff > 0 })
+}
+
+// GreaterEqual returns a bool Series which is true where the value does not come before the given category
+func (cs *CategoricalSeries[R]) GreaterEqual(category string) *Series[bool, R] {
+	return cs.compareMask(category, func(diff int32) bool { return diff >= 0 })
+}
+
+// Min returns the smallest value of an ordered CategoricalSeries ignoring missing values
+func (cs *CategoricalSeries[R]) Min() string {
+	return cs.extreme(func(a, b int32) bool { return a < b })
+}
+
+// Max returns the largest value of an ordered CategoricalSeries ignoring missing values
+func (cs *CategoricalSeries[R]) Max() string {
+	return cs.extreme(func(a, b int32) bool { return a > b })
+}
+
+// extreme returns the category of the code for which better is true against all others
+func (cs *CategoricalSeries[R]) extreme(better func(a, b int32) bool) string {
+	if !cs.ordered {
+		panic("min and max need ordered categories")
+	}
+
+	best := int32(missingCode)
+	for _, code := range cs.codes {
+		if code != missingCode && (best == missingCode || better(code, best)) {
+			best = code
+		}
+	}
+	if best == missingCode {
+		panic("cannot get min or max of series with only missing values")
+	}
+	return cs.categories[best]
+}
+
+// valueAt returns the value at the given position as any
+func (cs *CategoricalSeries[R]) valueAt(i int) any {
+	return cs.At(i)
+}
+
+// take returns a new CategoricalSeries holding the values and labels at the given positions
+func (cs *CategoricalSeries[R]) take(positions []int) Column[R] {
+	if len(positions) == 0 {
+		panic("cannot create Series with no data")
+	}
+
+	codes := make([]int32, len(positions))
+	index := make([]R, len(positions))
+	for i, p := range positions {
+		codes[i] = cs.codes[p]
+		index[i] = cs.index[p]
+	}
+
+	taken := cs.withCodes(codes, cs.categories)
+	taken.index = index
+	return taken
+}
+
+// gather returns the values at the given positions labeled by index
+// positions from Len on refer to other, which must be a CategoricalSeries, and -1 gives a missing value
+// categories of other which are not categories of the CategoricalSeries are appended
+func (cs *CategoricalSeries[R]) gather(positions []int, other Column[R], index []R) Column[R] {
+	codes, categories := cs.gatherCodes(positions, other)
+	gathered := cs.withCodes(codes, categories)
+	gathered.index = index
+	return gathered
+}
+
+// gatherPositional is gather with the positions in the result as labels
+func (cs *CategoricalSeries[R]) gatherPositional(positions []int, other Column[R]) Column[int] {
+	codes, categories := cs.gatherCodes(positions, other)
+	return &CategoricalSeries[int]{
+		name:       cs.name,
+		codes:      codes,
+		categories: categories,
+		ordered:    cs.ordered,
+		index:      rangePositions(0, len(codes)),
+	}
+}
+
+func (cs *CategoricalSeries[R]) gatherCodes(positions []int, other Column[R]) ([]int32, []string) {
+	categories := cs.categories
+	var otherCodes []int32
+	if o, ok := other.(*CategoricalSeries[R]); ok {
+		categories = slices.Clone(cs.categories)
+		lookup := categoryLookup(categories)
+		recode := make([]int32, len(o.categories))
+		for i, c := range o.categories {
+			code, ok := lookup[c]
+			if !ok {
+				code = int32(len(categories))
+				categories = append(categories, c)
+			}
+			recode[i] = code
+		}
+		otherCodes = make([]int32, len(o.codes))
+		for i, code := range o.codes {
+			otherCodes[i] = missingCode
+			if code != missingCode {
+				otherCodes[i] = recode[code]
+			}
+		}
+	}
+
+	codes := make([]int32, len(positions))
+	for i, p := range positions {
+		switch {
+		case p < 0:
+			codes[i] = missingCode
+		case p < len(cs.codes):
+			codes[i] = cs.codes[p]
+		default:
+			codes[i] = otherCodes[p-len(cs.codes)]
+		}
+	}
+	return codes, categories
+}
@@ -0,0 +1,178 @@
+package series
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestNewCategoricalSeries(t *testing.T) {
+	t.Run("infers categories in order of appearance", func(t *testing.T) {
+		cs := NewCategoricalSeries("country", []string{"DE", "FR", "DE", "IT"}, []int{0, 1, 2, 3}, nil, false)
+
+		if !slices.Equal(cs.Categories(), []string{"DE", "FR", "IT"}) {
+			t.Errorf("unexpected categories %v", cs.Categories())
+		}
+		if !slices.Equal(cs.Codes(), []int32{0, 1, 0, 2}) {
+			t.Errorf("unexpected codes %v", cs.Codes())
+		}
+		if cs.At(3) != "IT" {
+			t.Errorf("expected 'IT', got %s", cs.At(3))
+		}
+	})
+
+	t.Run("marks values outside the categories as missing", func(t *testing.T) {
+		cs := NewCategoricalSeries("size", []string{"S", "XXL", "M"}, []string{"a", "b", "c"}, []string{"S", "M", "L"}, true)
+
+		if cs.Get("b") != "" {
+			t.Errorf("expected missing value, got %s", cs.Get("b"))
+		}
+		if !slices.Equal(cs.IsNA().Values(), []bool{false, true, false}) {
+			t.Errorf("unexpected missing mask %v", cs.IsNA().Values())
+		}
+	})
+
+	t.Run("panics with duplicate categories", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("expected panic for duplicate categories")
+			}
+		}()
+		NewCategoricalSeries("x", []string{"a"}, []int{0}, []string{"a", "a"}, false)
+	})
+
+	t.Run("panics with empty values", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("expected panic for empty values")
+			}
+		}()
+		NewCategoricalSeries("x", []string{}, []int{}, nil, false)
+	})
+}
+
+func TestCategoricalSeries_Conversion(t *testing.T) {
+	s := NewSeries("status", []string{"ok", "fail", "ok"}, []string{"x", "y", "z"})
+
+	cs := ToCategorical(s, nil, false)
+	back := cs.ToSeries()
+
+	if back.Name() != "status" {
+		t.Errorf("expected name 'status', got %s", back.Name())
+	}
+	if !slices.Equal(back.Values(), s.Values()) || !slices.Equal(back.Index(), s.Index()) {
+		t.Error("round trip should keep values and labels")
+	}
+}
+
+func TestCategoricalSeries_Categories(t *testing.T) {
+	cs := NewCategoricalSeries("x", []string{"b", "b", "c"}, []int{0, 1, 2}, []string{"a", "b", "c"}, false)
+
+	t.Run("adds categories without changing values", func(t *testing.T) {
+		added := cs.AddCategories("d")
+
+		if !slices.Equal(added.Categories(), []string{"a", "b", "c", "d"}) {
+			t.Errorf("unexpected categories %v", added.Categories())
+		}
+		if !slices.Equal(added.Values(), cs.Values()) {
+			t.Error("values should not change")
+		}
+		if len(cs.Categories()) != 3 {
+			t.Error("original should not be modified")
+		}
+	})
+
+	t.Run("removes unused categories", func(t *testing.T) {
+		removed := cs.RemoveUnused()
+
+		if !slices.Equal(removed.Categories(), []string{"b", "c"}) {
+			t.Errorf("unexpected categories %v", removed.Categories())
+		}
+		if !slices.Equal(removed.Codes(), []int32{0, 0, 1}) {
+			t.Errorf("unexpected codes %v", removed.Codes())
+		}
+		if !slices.Equal(removed.Values(), cs.Values()) {
+			t.Error("values should not change")
+		}
+	})
+
+	t.Run("renames categories", func(t *testing.T) {
+		renamed := cs.Rename(map[string]string{"b": "beta"})
+
+		if !slices.Equal(renamed.Values(), []string{"beta", "beta", "c"}) {
+			t.Errorf("unexpected values %v", renamed.Values())
+		}
+	})
+
+	t.Run("panics when renaming into a duplicate", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("expected panic for duplicate category")
+			}
+		}()
+		cs.Rename(map[string]string{"a": "c"})
+	})
+}
+
+func TestCategoricalSeries_ValueCounts(t *testing.T) {
+	cs := NewCategoricalSeries("x", []string{"b", "a", "b", "z"}, []int{0, 1, 2, 3}, []string{"a", "b", "c"}, false)
+
+	counts := cs.ValueCounts()
+	if !slices.Equal(counts.Index(), []string{"a", "b", "c"}) {
+		t.Errorf("unexpected labels %v", counts.Index())
+	}
+	if !slices.Equal(counts.Values(), []int{1, 2, 0}) {
+		t.Errorf("unexpected counts %v", counts.Values())
+	}
+
+	groups := cs.Groups()
+	if !slices.Equal(groups["b"], []int{0, 2}) || groups["c"] != nil {
+		t.Errorf("unexpected groups %v", groups)
+	}
+}
+
+func TestCategoricalSeries_Ordered(t *testing.T) {
+	sizes := []string{"M", "S", "L", "M"}
+	cs := NewCategoricalSeries("size", sizes, []int{0, 1, 2, 3}, []string{"S", "M", "L"}, true)
+
+	t.Run("compares with a category", func(t *testing.T) {
+		if !slices.Equal(cs.Greater("S").Values(), []bool{true, false, true, true}) {
+			t.Errorf("unexpected mask %v", cs.Greater("S").Values())
+		}
+		if !slices.Equal(cs.Less("M").Values(), []bool{false, true, false, false}) {
+			t.Errorf("unexpected mask %v", cs.Less("M").Values())
+		}
+		if !slices.Equal(cs.LessEqual("M").Values(), []bool{true, true, false, true}) {
+			t.Errorf("unexpected mask %v", cs.LessEqual("M").Values())
+		}
+		if !slices.Equal(cs.GreaterEqual("L").Values(), []bool{false, false, true, false}) {
+			t.Errorf("unexpected mask %v", cs.GreaterEqual("L").Values())
+		}
+	})
+
+	t.Run("finds min and max by category order", func(t *testing.T) {
+		if cs.Min() != "S" || cs.Max() != "L" {
+			t.Errorf("expected min S and max L, got %s and %s", cs.Min(), cs.Max())
+		}
+	})
+
+	t.Run("panics comparing unordered categories", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("expected panic for unordered categories")
+			}
+		}()
+		unordered := NewCategoricalSeries("size", sizes, []int{0, 1, 2, 3}, nil, false)
+		unordered.Less("M")
+	})
+}
+
+func TestCategoricalSeries_DataFrame(t *testing.T) {
+	cs := NewCategoricalSeries("size", []string{"S", "M", "L"}, []int{0, 1, 2}, nil, false)
+	df := NewDataFrame[int](cs, NewIndexSeries("price", []int{1, 2, 3}))
+
+	tail := df.Tail(2)
+	column := tail.Column("size").(*CategoricalSeries[int])
+	if !slices.Equal(column.Values(), []string{"M", "L"}) {
+		t.Errorf("unexpected values %v", column.Values())
+	}
+}
@@ -0,0 +1,532 @@
+package series
+
+import (
+	"bufio"
+	"cmp"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"math"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// ChunkReader reads a Series in chunks, so it never has to fit into memory at once
+type ChunkReader[T comparable, R comparable] interface {
+	// Next returns the next chunk or io.EOF after the last one
+	Next() (*Series[T, R], error)
+}
+
+// ChunkReaderCtx is a ChunkReader which can stop reading a chunk when a context is cancelled
+type ChunkReaderCtx[T comparable, R comparable] interface {
+	ChunkReader[T, R]
+	// NextCtx is Next but stops and returns ctx.Err() when ctx is cancelled
+	NextCtx(ctx context.Context) (*Series[T, R], error)
+}
+
+// Chunks returns an iterator over the chunks of the reader which stops after the first error
+func Chunks[T comparable, R comparable](cr ChunkReader[T, R]) iter.Seq2[*Series[T, R], error] {
+	return func(yield func(*Series[T, R], error) bool) {
+		for {
+			chunk, err := cr.Next()
+			if err == io.EOF {
+				return
+			}
+			if !yield(chunk, err) || err != nil {
+				return
+			}
+		}
+	}
+}
+
+// Mergeable is a partial aggregate which can be combined with the one of the following data
+type Mergeable[P any] interface {
+	Merge(other P)
+}
+
+// Reduce computes a partial aggregate of every chunk with f and merges them in the order of the chunks
+// only one chunk is held in memory at a time
+func Reduce[T comparable, R comparable, P Mergeable[P]](cr ChunkReader[T, R], f func(chunk *Series[T, R]) P) (P, error) {
+	return ReduceCtx(context.Background(), cr, f)
+}
+
+// ReduceCtx is Reduce but stops and returns ctx.Err() when ctx is cancelled
+// the context is checked before every chunk is aggregated, a ChunkReaderCtx also stops reading a chunk
+func ReduceCtx[T comparable, R comparable, P Mergeable[P]](ctx context.Context, cr ChunkReader[T, R], f func(chunk *Series[T, R]) P) (P, error) {
+	next := cr.Next
+	if crc, ok := cr.(ChunkReaderCtx[T, R]); ok {
+		next = func() (*Series[T, R], error) {
+			return crc.NextCtx(ctx)
+		}
+	}
+
+	var result P
+	first := true
+	for {
+		chunk, err := next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return result, err
+		}
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		if first {
+			result, first = f(chunk), false
+		} else {
+			result.Merge(f(chunk))
+		}
+	}
+	if first {
+		return result, errors.New("cannot reduce reader without chunks")
+	}
+	return result, nil
+}
+
+// CSVChunkOptions configures a CSVChunkReader
+type CSVChunkOptions struct {
+	// Column is the header of the column holding the values
+	Column string
+	// IndexColumn is the header of the column holding the labels
+	// if it is empty the labels are the row numbers starting at 0 and R must be int
+	IndexColumn string
+	// ChunkSize is the number of rows per chunk, 0 means 32768
+	ChunkSize int
+	// Comma is the field delimiter, 0 means ','
+	Comma rune
+}
+
+// CSVChunkReader reads a column of a CSV file with a header row in chunks
+// empty fields are read as NaN into floats, every other value must parse into T and R
+type CSVChunkReader[T comparable, R comparable] struct {
+	r         *csv.Reader
+	name      string
+	column    int
+	index     int // -1 for row numbers
+	chunkSize int
+	row       int
+}
+
+// NewCSVChunkReader reads the header row and returns a reader of the chunks of the configured column
+func NewCSVChunkReader[T comparable, R comparable](r io.Reader, opts CSVChunkOptions) (*CSVChunkReader[T, R], error) {
+	cr := &CSVChunkReader[T, R]{
+		r:         csv.NewReader(r),
+		name:      opts.Column,
+		index:     -1,
+		chunkSize: opts.ChunkSize,
+	}
+	cr.r.ReuseRecord = true
+	if opts.Comma != 0 {
+		cr.r.Comma = opts.Comma
+	}
+	if cr.chunkSize == 0 {
+		cr.chunkSize = defaultChunkSize
+	}
+	if cr.chunkSize < 0 {
+		return nil, errors.New("chunk size must be positive")
+	}
+
+	header, err := cr.r.Read()
+	if err != nil {
+		return nil, fmt.Errorf("reading csv header: %w", err)
+	}
+	if cr.column = slices.Index(header, opts.Column); cr.column < 0 {
+		return nil, fmt.Errorf("csv has no column %q", opts.Column)
+	}
+	if opts.IndexColumn == "" {
+		var zero R
+		if _, ok := any(zero).(int); !ok {
+			return nil, fmt.Errorf("cannot use row numbers as labels of type %T", zero)
+		}
+	} else if cr.index = slices.Index(header, opts.IndexColumn); cr.index < 0 {
+		return nil, fmt.Errorf("csv has no column %q", opts.IndexColumn)
+	}
+	return cr, nil
+}
+
+// Next returns the next chunk of up to ChunkSize rows or io.EOF after the last one
+func (cr *CSVChunkReader[T, R]) Next() (*Series[T, R], error) {
+	return cr.NextCtx(context.Background())
+}
+
+// NextCtx is Next but stops and returns ctx.Err() when ctx is cancelled
+// the context is checked every few thousand rows, the rows read until then are lost
+func (cr *CSVChunkReader[T, R]) NextCtx(ctx context.Context) (*Series[T, R], error) {
+	var values []T
+	var index []R
+	for len(values) < cr.chunkSize {
+		if len(values)%checkEvery == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
+		record, err := cr.r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := parseCSVField[T](record[cr.column])
+		if err != nil {
+			line, _ := cr.r.FieldPos(cr.column)
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		var label R
+		if cr.index < 0 {
+			label = any(cr.row).(R)
+		} else if label, err = parseCSVField[R](record[cr.index]); err != nil {
+			line, _ := cr.r.FieldPos(cr.index)
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		values = append(values, value)
+		index = append(index, label)
+		cr.row++
+	}
+
+	if len(values) == 0 {
+		return nil, io.EOF
+	}
+	return NewSeries(cr.name, values, index), nil
+}
+
+// parseCSVField parses a field into a string, bool, number or RFC 3339 time
+func parseCSVField[T comparable](field string) (T, error) {
+	var v T
+	var err error
+	switch p := any(&v).(type) {
+	case *string:
+		*p = field
+	case *bool:
+		*p, err = strconv.ParseBool(field)
+	case *int:
+		*p, err = parseCSVInt[int](field)
+	case *int8:
+		*p, err = parseCSVInt[int8](field)
+	case *int16:
+		*p, err = parseCSVInt[int16](field)
+	case *int32:
+		*p, err = parseCSVInt[int32](field)
+	case *int64:
+		*p, err = parseCSVInt[int64](field)
+	case *uint:
+		*p, err = parseCSVUint[uint](field)
+	case *uint8:
+		*p, err = parseCSVUint[uint8](field)
+	case *uint16:
+		*p, err = parseCSVUint[uint16](field)
+	case *uint32:
+		*p, err = parseCSVUint[uint32](field)
+	case *uint64:
+		*p, err = parseCSVUint[uint64](field)
+	case *float32:
+		var f float64
+		f, err = parseCSVFloat(field, 32)
+		*p = float32(f)
+	case *float64:
+		*p, err = parseCSVFloat(field, 64)
+	case *time.Time:
+		*p, err = time.Parse(time.RFC3339Nano, field)
+	default:
+		err = fmt.Errorf("cannot parse csv into %T", v)
+	}
+	return v, err
+}
+
+func parseCSVInt[S ~int | ~int8 | ~int16 | ~int32 | ~int64](field string) (S, error) {
+	var zero S
+	v, err := strconv.ParseInt(field, 10, intBitSize(zero))
+	return S(v), err
+}
+
+func parseCSVUint[S ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64](field string) (S, error) {
+	var zero S
+	v, err := strconv.ParseUint(field, 10, intBitSize(zero))
+	return S(v), err
+}
+
+// parseCSVFloat parses a float, an empty field is NaN
+func parseCSVFloat(field string, bitSize int) (float64, error) {
+	if field == "" {
+		return math.NaN(), nil
+	}
+	return strconv.ParseFloat(field, bitSize)
+}
+
+// intBitSize returns the size of an integer type in bits
+func intBitSize[S Numeric](v S) int {
+	switch any(v).(type) {
+	case int, uint:
+		return strconv.IntSize
+	case int8, uint8:
+		return 8
+	case int16, uint16:
+		return 16
+	case int32, uint32:
+		return 32
+	}
+	return 64
+}
+
+// BinaryChunkReader reads Series written one after another in the native binary format, every Series is one chunk
+type BinaryChunkReader[T comparable, R comparable] struct {
+	src *ctxReader
+	r   *bufio.Reader
+}
+
+// NewBinaryChunkReader returns a reader of the Series in r
+func NewBinaryChunkReader[T comparable, R comparable](r io.Reader) *BinaryChunkReader[T, R] {
+	src := &ctxReader{ctx: context.Background(), r: r}
+	return &BinaryChunkReader[T, R]{src: src, r: bufio.NewReader(src)}
+}
+
+// Next returns the next Series or io.EOF at the end of the input
+func (br *BinaryChunkReader[T, R]) Next() (*Series[T, R], error) {
+	return br.NextCtx(context.Background())
+}
+
+// NextCtx is Next but stops and returns ctx.Err() when ctx is cancelled
+// the context is checked before every read from the input, a cancelled read leaves the reader unusable
+func (br *BinaryChunkReader[T, R]) NextCtx(ctx context.Context) (*Series[T, R], error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	br.src.ctx = ctx
+	defer func() { br.src.ctx = context.Background() }()
+
+	if _, err := br.r.Peek(1); err == io.EOF {
+		return nil, io.EOF
+	}
+	s, err := ReadSeries[T, R](br.r)
+	if ctxErr := ctx.Err(); err != nil && ctxErr != nil {
+		return nil, ctxErr
+	}
+	return s, err
+}
+
+// ctxReader is a reader which fails with ctx.Err() once ctx is cancelled
+type ctxReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (cr *ctxReader) Read(p []byte) (int, error) {
+	if err := cr.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return cr.r.Read(p)
+}
+
+// Summary is a mergeable partial aggregate of numeric values
+// merged summaries give the results of the NumericSeries methods on all values at once
+type Summary[T Numeric] struct {
+	count int
+	sum   T
+	mean  float64
+	m2    float64 // sum of squared differences from the mean
+
+	firstNaN bool // the first value is NaN, which makes it the min and max
+	found    bool // min and max hold a value which is not NaN
+	min      T
+	max      T
+}
+
+// Summarize computes the Summary of a chunk
+func Summarize[T Numeric, R comparable](chunk *Series[T, R]) *Summary[T] {
+	ns := &NumericSeries[T, R]{Series: chunk}
+	s := &Summary[T]{
+		count:    ns.Len(),
+		sum:      ns.Sum(),
+		mean:     ns.Mean(),
+		firstNaN: isNaN(ns.values[0]),
+	}
+	for _, v := range ns.values {
+		diff := float64(v) - s.mean
+		s.m2 += diff * diff
+		if isNaN(v) {
+			continue
+		}
+		if !s.found || v < s.min {
+			s.min = v
+		}
+		if !s.found || v > s.max {
+			s.max = v
+		}
+		s.found = true
+	}
+	return s
+}
+
+// Merge adds the values of a Summary of the data following this one
+func (s *Summary[T]) Merge(other *Summary[T]) {
+	if other.count == 0 {
+		return
+	}
+	if s.count == 0 {
+		*s = *other
+		return
+	}
+
+	n := float64(s.count + other.count)
+	delta := other.mean - s.mean
+	s.m2 += other.m2 + delta*delta*float64(s.count)*float64(other.count)/n
+	s.mean += delta * float64(other.count) / n
+	s.count += other.count
+	s.sum += other.sum
+
+	if other.found {
+		if !s.found || other.min < s.min {
+			s.min = other.min
+		}
+		if !s.found || other.max > s.max {
+			s.max = other.max
+		}
+		s.found = true
+	}
+}
+
+// Count returns the number of values
+func (s *Summary[T]) Count() int {
+	return s.count
+}
+
+// Sum returns the sum of the values
+func (s *Summary[T]) Sum() T {
+	return s.sum
+}
+
+// Mean returns the mean of the values
+func (s *Summary[T]) Mean() float64 {
+	return float64(s.sum) / float64(s.count)
+}
+
+// StdDev returns the standard deviation of the values
+// dof is degrees of freedom, typically 0 for population(complete set) and 1 for sample(uncomplete set)
+func (s *Summary[T]) StdDev(dof int) float64 {
+	if dof < 0 {
+		panic("degrees of freedom must be non-negative")
+	}
+	return math.Sqrt(s.m2 / float64(s.count-dof))
+}
+
+// Min returns the smallest value
+// like NumericSeries.Min NaN values are ignored unless the first value is NaN
+func (s *Summary[T]) Min() T {
+	return s.extreme("min", s.min)
+}
+
+// Max returns the largest value
+// like NumericSeries.Max NaN values are ignored unless the first value is NaN
+func (s *Summary[T]) Max() T {
+	return s.extreme("max", s.max)
+}
+
+// extreme returns the min or max taking a leading NaN into account
+func (s *Summary[T]) extreme(name string, value T) T {
+	if s.count == 0 {
+		panic(fmt.Sprintf("cannot get %s of empty summary", name))
+	}
+	if s.firstNaN || !s.found {
+		return T(math.NaN())
+	}
+	return value
+}
+
+// Counts is a mergeable count of how often each value occurs, NaN values are not counted
+type Counts[T comparable] struct {
+	counts map[T]int
+	order  []T // values in the order they were first seen
+}
+
+// CountValues counts the values of a chunk
+func CountValues[T comparable, R comparable](chunk *Series[T, R]) *Counts[T] {
+	c := &Counts[T]{counts: make(map[T]int)}
+	for _, v := range chunk.values {
+		c.add(v, 1)
+	}
+	return c
+}
+
+// add adds n occurrences of the value
+func (c *Counts[T]) add(v T, n int) {
+	// only NaN is not equal to itself
+	if v != v {
+		return
+	}
+	if _, ok := c.counts[v]; !ok {
+		c.order = append(c.order, v)
+	}
+	c.counts[v] += n
+}
+
+// Merge adds the counts of the data following this one
+func (c *Counts[T]) Merge(other *Counts[T]) {
+	for _, v := range other.order {
+		c.add(v, other.counts[v])
+	}
+}
+
+// Series returns the counts labeled by their value, the most frequent first
+// values occurring equally often are in the order they were first seen
+func (c *Counts[T]) Series(name string) *Series[int, T] {
+	values := slices.Clone(c.order)
+	slices.SortStableFunc(values, func(a, b T) int {
+		return cmp.Compare(c.counts[b], c.counts[a])
+	})
+	counts := make([]int, len(values))
+	for i, v := range values {
+		counts[i] = c.counts[v]
+	}
+	return NewSeries(name, counts, values)
+}
+
+// GroupSums is a mergeable sum of the values of every label, NaN labels are not grouped
+type GroupSums[R comparable, T Numeric] struct {
+	sums  map[R]T
+	order []R // labels in the order they were first seen
+}
+
+// SumByLabel sums the values of a chunk with the same label
+// to group by another column read it as the labels
+func SumByLabel[T Numeric, R comparable](chunk *Series[T, R]) *GroupSums[R, T] {
+	g := &GroupSums[R, T]{sums: make(map[R]T)}
+	for i, v := range chunk.values {
+		g.add(chunk.index[i], v)
+	}
+	return g
+}
+
+// add adds the value to the sum of the label
+func (g *GroupSums[R, T]) add(label R, v T) {
+	// only NaN is not equal to itself
+	if label != label {
+		return
+	}
+	if _, ok := g.sums[label]; !ok {
+		g.order = append(g.order, label)
+	}
+	g.sums[label] += v
+}
+
+// Merge adds the sums of the data following this one
+func (g *GroupSums[R, T]) Merge(other *GroupSums[R, T]) {
+	for _, label := range other.order {
+		g.add(label, other.sums[label])
+	}
+}
+
+// Series returns the sums labeled by their group in the order the groups were first seen
+func (g *GroupSums[R, T]) Series(name string) *NumericSeries[T, R] {
+	sums := make([]T, len(g.order))
+	for i, label := range g.order {
+		sums[i] = g.sums[label]
+	}
+	return NewNumericSeries(name, sums, slices.Clone(g.order))
+}
@@ -0,0 +1,276 @@
+package series
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"math"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+const chunkedCSV = `city,price,day
+paris,1.5,2024-01-01T00:00:00Z
+rome,2.5,2024-01-02T00:00:00Z
+paris,,2024-01-03T00:00:00Z
+oslo,4,2024-01-04T00:00:00Z
+rome,-1,2024-01-05T00:00:00Z
+`
+
+func TestCSVChunkReader(t *testing.T) {
+	t.Run("reads chunks with row numbers", func(t *testing.T) {
+		cr, err := NewCSVChunkReader[float64, int](strings.NewReader(chunkedCSV), CSVChunkOptions{Column: "price", ChunkSize: 2})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var lengths []int
+		var index []int
+		for chunk, err := range Chunks(cr) {
+			if err != nil {
+				t.Fatal(err)
+			}
+			lengths = append(lengths, chunk.Len())
+			index = append(index, chunk.Index()...)
+		}
+		if !slices.Equal(lengths, []int{2, 2, 1}) || !slices.Equal(index, []int{0, 1, 2, 3, 4}) {
+			t.Errorf("unexpected chunks %v with labels %v", lengths, index)
+		}
+	})
+
+	t.Run("reads labels from a column", func(t *testing.T) {
+		cr, err := NewCSVChunkReader[string, time.Time](strings.NewReader(chunkedCSV), CSVChunkOptions{Column: "city", IndexColumn: "day"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		chunk, err := cr.Next()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if chunk.Name() != "city" || chunk.Len() != 5 || chunk.At(2) != "paris" || !chunk.Index()[1].Equal(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)) {
+			t.Errorf("unexpected chunk %v", chunk)
+		}
+		if _, err := cr.Next(); err != io.EOF {
+			t.Errorf("expected io.EOF, got %v", err)
+		}
+	})
+
+	t.Run("uses the delimiter", func(t *testing.T) {
+		cr, err := NewCSVChunkReader[int8, string](strings.NewReader("k;v\na;-3\nb;7\n"), CSVChunkOptions{Column: "v", IndexColumn: "k", Comma: ';'})
+		if err != nil {
+			t.Fatal(err)
+		}
+		chunk, err := cr.Next()
+		if err != nil || !slices.Equal(chunk.Values(), []int8{-3, 7}) {
+			t.Errorf("unexpected chunk %v and error %v", chunk, err)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		if _, err := NewCSVChunkReader[float64, int](strings.NewReader(chunkedCSV), CSVChunkOptions{Column: "missing"}); err == nil {
+			t.Error("expected error for a missing column")
+		}
+		if _, err := NewCSVChunkReader[float64, string](strings.NewReader(chunkedCSV), CSVChunkOptions{Column: "price"}); err == nil {
+			t.Error("expected error for row numbers as string labels")
+		}
+
+		cr, err := NewCSVChunkReader[int8, int](strings.NewReader("v\n1\n300\n"), CSVChunkOptions{Column: "v"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := cr.Next(); err == nil || !strings.Contains(err.Error(), "line 3") {
+			t.Errorf("expected out of range error on line 3, got %v", err)
+		}
+	})
+}
+
+func TestBinaryChunkReader(t *testing.T) {
+	var buf bytes.Buffer
+	for _, values := range [][]int{{1, 2}, {3}, {4, 5, 6}} {
+		if _, err := NewIndexSeries("n", values).WriteTo(&buf); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	summary, err := Reduce(NewBinaryChunkReader[int, int](&buf), Summarize[int, int])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary.Count() != 6 || summary.Sum() != 21 || summary.Min() != 1 || summary.Max() != 6 {
+		t.Errorf("unexpected summary %+v", summary)
+	}
+
+	if _, err := NewBinaryChunkReader[int, int](bytes.NewReader([]byte("PNGO"))).Next(); err == nil || err == io.EOF {
+		t.Errorf("expected error for truncated input, got %v", err)
+	}
+	if _, err := Reduce(NewBinaryChunkReader[int, int](&bytes.Buffer{}), Summarize[int, int]); err == nil {
+		t.Error("expected error reducing no chunks")
+	}
+}
+
+func TestSummary(t *testing.T) {
+	values := []float64{3, -1.5, 8, 2, 2, 10.25, -4, 7}
+	all := NewIndexNumericSeries("x", values)
+
+	for _, size := range []int{1, 3, 8} {
+		var merged Summary[float64]
+		for start := 0; start < len(values); start += size {
+			end := min(start+size, len(values))
+			merged.Merge(Summarize(NewIndexSeries("x", values[start:end])))
+		}
+
+		if merged.Count() != 8 || merged.Sum() != all.Sum() || merged.Mean() != all.Mean() {
+			t.Errorf("size %d: unexpected count %d, sum %v or mean %v", size, merged.Count(), merged.Sum(), merged.Mean())
+		}
+		if math.Abs(merged.StdDev(1)-all.StdDev(1)) > 1e-12 || math.Abs(merged.StdDev(0)-all.StdDev(0)) > 1e-12 {
+			t.Errorf("size %d: expected std dev %v, got %v", size, all.StdDev(1), merged.StdDev(1))
+		}
+		if merged.Min() != all.Min() || merged.Max() != all.Max() {
+			t.Errorf("size %d: unexpected min %v or max %v", size, merged.Min(), merged.Max())
+		}
+	}
+
+	t.Run("NaN like NumericSeries", func(t *testing.T) {
+		s := Summarize(NewIndexSeries("x", []float64{1, 5}))
+		s.Merge(Summarize(NewIndexSeries("x", []float64{math.NaN(), -2})))
+		if s.Min() != -2 || s.Max() != 5 || !math.IsNaN(s.Sum()) {
+			t.Errorf("expected later NaN to be ignored by min and max, got %v %v", s.Min(), s.Max())
+		}
+
+		s = Summarize(NewIndexSeries("x", []float64{math.NaN()}))
+		s.Merge(Summarize(NewIndexSeries("x", []float64{3})))
+		if !math.IsNaN(s.Min()) || !math.IsNaN(s.Max()) {
+			t.Error("expected a leading NaN to be the min and max")
+		}
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("expected panic for the min of an empty summary")
+			}
+		}()
+		var s Summary[int]
+		s.Min()
+	})
+}
+
+func TestCounts(t *testing.T) {
+	cr, err := NewCSVChunkReader[string, int](strings.NewReader(chunkedCSV), CSVChunkOptions{Column: "city", ChunkSize: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	counts, err := Reduce(cr, CountValues[string, int])
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := counts.Series("city")
+	if !slices.Equal(s.Index(), []string{"paris", "rome", "oslo"}) || !slices.Equal(s.Values(), []int{2, 2, 1}) {
+		t.Errorf("unexpected counts %v", s)
+	}
+
+	nan := CountValues(NewIndexSeries("x", []float64{math.NaN(), 1, math.NaN()}))
+	if s := nan.Series("x"); s.Len() != 1 || s.At(0) != 1 {
+		t.Errorf("expected NaN not to be counted, got %v", s)
+	}
+}
+
+func TestGroupSums(t *testing.T) {
+	cr, err := NewCSVChunkReader[float64, string](strings.NewReader(chunkedCSV), CSVChunkOptions{Column: "price", IndexColumn: "city", ChunkSize: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sums, err := Reduce(cr, SumByLabel[float64, string])
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := sums.Series("price")
+	if !slices.Equal(s.Index(), []string{"paris", "rome", "oslo"}) || s.Get("rome") != 1.5 || s.Get("oslo") != 4 {
+		t.Errorf("unexpected sums %v", s)
+	}
+	if !math.IsNaN(s.Get("paris")) {
+		t.Errorf("expected the empty price to make the sum of paris NaN, got %v", s.Get("paris"))
+	}
+}
+
+func TestReduceCtx(t *testing.T) {
+	var buf bytes.Buffer
+	for _, values := range [][]int{{1, 2}, {3}} {
+		if _, err := NewIndexSeries("n", values).WriteTo(&buf); err != nil {
+			t.Fatal(err)
+		}
+	}
+	data := buf.Bytes()
+
+	summary, err := ReduceCtx(context.Background(), NewBinaryChunkReader[int, int](bytes.NewReader(data)), Summarize[int, int])
+	if err != nil || summary.Sum() != 6 {
+		t.Errorf("unexpected result %+v %v", summary, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	chunks := 0
+	_, err = ReduceCtx(ctx, NewBinaryChunkReader[int, int](bytes.NewReader(data)), func(chunk *Series[int, int]) *Summary[int] {
+		chunks++
+		cancel()
+		return Summarize(chunk)
+	})
+	if err != context.Canceled || chunks != 1 {
+		t.Errorf("expected to stop after the first chunk, got %v after %d chunks", err, chunks)
+	}
+}
+
+// cancelOnRead cancels a context on the first read from it
+type cancelOnRead struct {
+	r      io.Reader
+	cancel context.CancelFunc
+}
+
+func (c *cancelOnRead) Read(p []byte) (int, error) {
+	c.cancel()
+	return c.r.Read(p)
+}
+
+func TestNextCtx(t *testing.T) {
+	t.Run("csv chunk reader", func(t *testing.T) {
+		csvData := "v\n" + strings.Repeat("1\n", 3*checkEvery)
+		cr, err := NewCSVChunkReader[int, int](strings.NewReader(csvData), CSVChunkOptions{Column: "v", ChunkSize: 4 * checkEvery})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if chunk, err := cr.NextCtx(context.Background()); err != nil || chunk.Len() != 3*checkEvery {
+			t.Fatalf("unexpected chunk %v", err)
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cr, err = NewCSVChunkReader[int, int](&cancelOnRead{strings.NewReader(csvData), cancel}, CSVChunkOptions{Column: "v", ChunkSize: 4 * checkEvery})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := cr.NextCtx(ctx); err != context.Canceled {
+			t.Errorf("expected context.Canceled while reading rows, got %v", err)
+		}
+	})
+
+	t.Run("binary chunk reader", func(t *testing.T) {
+		var buf bytes.Buffer
+		if _, err := NewIndexSeries("n", make([]int, 100_000)).WriteTo(&buf); err != nil {
+			t.Fatal(err)
+		}
+		data := buf.Bytes()
+
+		br := NewBinaryChunkReader[int, int](bytes.NewReader(data))
+		if chunk, err := br.NextCtx(context.Background()); err != nil || chunk.Len() != 100_000 {
+			t.Fatalf("unexpected chunk %v", err)
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		br = NewBinaryChunkReader[int, int](&cancelOnRead{bytes.NewReader(data), cancel})
+		if _, err := br.NextCtx(ctx); err != context.Canceled {
+			t.Errorf("expected context.Canceled while reading the series, got %v", err)
+		}
+		if _, err := NewBinaryChunkReader[int, int](bytes.NewReader(data)).NextCtx(ctx); err != context.Canceled {
+			t.Errorf("expected context.Canceled before reading, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,158 @@
+package series
+
+import (
+	"fmt"
+	"slices"
+)
+
+// ConcatOptions controls how Concat combines Series
+type ConcatOptions struct {
+	// Name is the name of the result, the name of the first Series is used if empty
+	Name string
+	// VerifyIntegrity panics if the result would contain a label more than once
+	VerifyIntegrity bool
+}
+
+// KeyedLabel is the label of a Series created by ConcatKeys
+// Key tells which source Series the value came from and Label is its original label
+type KeyedLabel[K comparable, R comparable] struct {
+	Key   K
+	Label R
+}
+
+// String returns a string representation of the KeyedLabel
+func (k KeyedLabel[K, R]) String() string {
+	return fmt.Sprintf("(%v, %v)", k.Key, k.Label)
+}
+
+// Concat concatenates the given Series into a new Series
+// unlike Append the inputs are not modified and the result is allocated only once
+func Concat[T comparable, R comparable](opts ConcatOptions, series ...*Series[T, R]) *Series[T, R] {
+	total := concatLen(series)
+
+	values := make([]T, 0, total)
+	index := make([]R, 0, total)
+	for _, s := range series {
+		values = append(values, s.values...)
+		index = append(index, s.index...)
+	}
+
+	if opts.VerifyIntegrity {
+		verifyUnique(index)
+	}
+
+	return NewSeries(concatName(opts.Name, series), values, index)
+}
+
+// ConcatIgnoreIndex concatenates the given Series into a new Series with the index 0..n-1
+// like calling ResetIndex on the result of Concat but without the intermediate Series
+func ConcatIgnoreIndex[T comparable, R comparable](name string, series ...*Series[T, R]) *Series[T, int] {
+	total := concatLen(series)
+
+	values := make([]T, 0, total)
+	for _, s := range series {
+		values = append(values, s.values...)
+	}
+
+	index := make([]int, total)
+	for i := range index {
+		index[i] = i
+	}
+
+	return NewSeries(concatName(name, series), values, index)
+}
+
+// ConcatKeys concatenates the given Series and labels each value with the key of its source
+// keys must have one entry per Series, VerifyIntegrity checks the combined (key, label) pairs
+func ConcatKeys[T comparable, R comparable, K comparable](keys []K, opts ConcatOptions, series ...*Series[T, R]) *Series[T, KeyedLabel[K, R]] {
+	if len(keys) != len(series) {
+		panic("keys length must match number of series")
+	}
+	total := concatLen(series)
+
+	values := make([]T, 0, total)
+	index := make([]KeyedLabel[K, R], 0, total)
+	for i, s := range series {
+		values = append(values, s.values...)
+		for _, label := range s.index {
+			index = append(index, KeyedLabel[K, R]{Key: keys[i], Label: label})
+		}
+	}
+
+	if opts.VerifyIntegrity {
+		verifyUnique(index)
+	}
+
+	return NewSeries(concatName(opts.Name, series), values, index)
+}
+
+// ConcatColumns concatenates the given Series horizontally into a DataFrame
+// the labels are aligned: the index is the union of all labels in order of first appearance
+// and a Series missing a label gets fill at that row
+// panics if a Series has a label more than once since its values could not be aligned
+func ConcatColumns[T comparable, R comparable](fill T, series ...*Series[T, R]) *DataFrame[R] {
+	if len(series) == 0 {
+		panic("cannot concat no series")
+	}
+
+	positions := make(map[R]int)
+	var index []R
+	for _, s := range series {
+		verifyUnique(s.index)
+		for _, label := range s.index {
+			if _, ok := positions[label]; !ok {
+				positions[label] = len(index)
+				index = append(index, label)
+			}
+		}
+	}
+
+	// clip so an Append on one column can't write into the index of another
+	index = slices.Clip(index)
+
+	columns := make([]Column[R], len(series))
+	for i, s := range series {
+		values := make([]T, len(index))
+		for j := range values {
+			values[j] = fill
+		}
+		for j, label := range s.index {
+			values[positions[label]] = s.values[j]
+		}
+		columns[i] = NewSeries(s.name, values, index)
+	}
+
+	return NewDataFrame(columns...)
+}
+
+// concatLen returns the combined length of the given Series
+func concatLen[T comparable, R comparable](series []*Series[T, R]) int {
+	if len(series) == 0 {
+		panic("cannot concat no series")
+	}
+
+	total := 0
+	for _, s := range series {
+		total += s.Len()
+	}
+	return total
+}
+
+// concatName returns name or the name of the first Series if name is empty
+func concatName[T comparable, R comparable](name string, series []*Series[T, R]) string {
+	if name == "" {
+		return series[0].name
+	}
+	return name
+}
+
+// verifyUnique panics if a label occurs more than once
+func verifyUnique[R comparable](index []R) {
+	seen := make(map[R]struct{}, len(index))
+	for _, label := range index {
+		if _, ok := seen[label]; ok {
+			panic(fmt.Sprintf("duplicate label %v", label))
+		}
+		seen[label] = struct{}{}
+	}
+}
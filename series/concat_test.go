@@ -0,0 +1,184 @@
+package series
+
+import (
+	"testing"
+)
+
+func TestConcat(t *testing.T) {
+	t.Run("concatenates many series", func(t *testing.T) {
+		s1 := NewSeries("a", []int{1, 2}, []string{"x", "y"})
+		s2 := NewSeries("b", []int{3}, []string{"z"})
+		s3 := NewSeries("c", []int{4, 5}, []string{"v", "w"})
+
+		result := Concat(ConcatOptions{}, s1, s2, s3)
+
+		expectedValues := []int{1, 2, 3, 4, 5}
+		expectedIndex := []string{"x", "y", "z", "v", "w"}
+		for i := range expectedValues {
+			label, value := result.AtIndex(i)
+			if value != expectedValues[i] || label != expectedIndex[i] {
+				t.Errorf("expected %s: %d at position %d, got %s: %d", expectedIndex[i], expectedValues[i], i, label, value)
+			}
+		}
+		if result.Name() != "a" {
+			t.Errorf("expected name 'a', got %s", result.Name())
+		}
+	})
+
+	t.Run("does not modify the inputs", func(t *testing.T) {
+		s1 := NewSeries("a", []int{1, 2}, []string{"x", "y"})
+		s2 := NewSeries("b", []int{3}, []string{"z"})
+
+		Concat(ConcatOptions{}, s1, s2)
+		if s1.Len() != 2 || s2.Len() != 1 {
+			t.Error("inputs should not be modified")
+		}
+	})
+
+	t.Run("uses custom name when provided", func(t *testing.T) {
+		s1 := NewIndexSeries("a", []int{1})
+		s2 := NewIndexSeries("b", []int{2})
+
+		result := Concat(ConcatOptions{Name: "both"}, s1, s2)
+		if result.Name() != "both" {
+			t.Errorf("expected name 'both', got %s", result.Name())
+		}
+	})
+
+	t.Run("allows duplicate labels without verify", func(t *testing.T) {
+		s1 := NewIndexSeries("a", []int{1, 2})
+		s2 := NewIndexSeries("b", []int{3, 4})
+
+		result := Concat(ConcatOptions{}, s1, s2)
+		if result.Len() != 4 {
+			t.Errorf("expected length 4, got %d", result.Len())
+		}
+	})
+
+	t.Run("panics on duplicate labels with verify", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("expected panic for duplicate labels")
+			}
+		}()
+		s1 := NewIndexSeries("a", []int{1, 2})
+		s2 := NewIndexSeries("b", []int{3, 4})
+
+		Concat(ConcatOptions{VerifyIntegrity: true}, s1, s2)
+	})
+
+	t.Run("panics with no series", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("expected panic for no series")
+			}
+		}()
+		Concat[int, int](ConcatOptions{})
+	})
+}
+
+func TestConcatIgnoreIndex(t *testing.T) {
+	t.Run("renumbers the index", func(t *testing.T) {
+		s1 := NewSeries("a", []int{1, 2}, []string{"x", "y"})
+		s2 := NewSeries("b", []int{3}, []string{"x"})
+
+		result := ConcatIgnoreIndex("", s1, s2)
+
+		idx := result.Index()
+		for i := range idx {
+			if idx[i] != i {
+				t.Errorf("expected index %d at position %d, got %d", i, i, idx[i])
+			}
+		}
+		if result.At(2) != 3 {
+			t.Errorf("expected value 3 at position 2, got %d", result.At(2))
+		}
+	})
+}
+
+func TestConcatKeys(t *testing.T) {
+	t.Run("labels values with their source key", func(t *testing.T) {
+		s1 := NewIndexSeries("a", []int{1, 2})
+		s2 := NewIndexSeries("b", []int{3})
+
+		result := ConcatKeys([]string{"first", "second"}, ConcatOptions{}, s1, s2)
+
+		label, value := result.AtIndex(2)
+		if label.Key != "second" || label.Label != 0 || value != 3 {
+			t.Errorf("expected (second, 0): 3, got %v: %d", label, value)
+		}
+		if result.Get(KeyedLabel[string, int]{Key: "first", Label: 1}) != 2 {
+			t.Error("expected value 2 for (first, 1)")
+		}
+	})
+
+	t.Run("verify integrity passes for distinct keys", func(t *testing.T) {
+		s1 := NewIndexSeries("a", []int{1, 2})
+		s2 := NewIndexSeries("b", []int{3, 4})
+
+		result := ConcatKeys([]int{1, 2}, ConcatOptions{VerifyIntegrity: true}, s1, s2)
+		if result.Len() != 4 {
+			t.Errorf("expected length 4, got %d", result.Len())
+		}
+	})
+
+	t.Run("panics with mismatched keys", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("expected panic for mismatched keys")
+			}
+		}()
+		s1 := NewIndexSeries("a", []int{1, 2})
+
+		ConcatKeys([]int{1, 2}, ConcatOptions{}, s1)
+	})
+}
+
+func TestConcatColumns(t *testing.T) {
+	t.Run("aligns series on their labels", func(t *testing.T) {
+		s1 := NewSeries("x", []int{1, 2}, []string{"a", "b"})
+		s2 := NewSeries("y", []int{30, 10}, []string{"c", "a"})
+
+		df := ConcatColumns(-1, s1, s2)
+
+		expectedIndex := []string{"a", "b", "c"}
+		idx := df.Index()
+		for i := range expectedIndex {
+			if idx[i] != expectedIndex[i] {
+				t.Errorf("expected label %s at position %d, got %s", expectedIndex[i], i, idx[i])
+			}
+		}
+
+		x := GetColumn[int](df, "x")
+		y := GetColumn[int](df, "y")
+		if x.Get("c") != -1 {
+			t.Errorf("expected fill value -1 for missing label, got %d", x.Get("c"))
+		}
+		if y.Get("a") != 10 || y.Get("b") != -1 || y.Get("c") != 30 {
+			t.Error("column y not aligned correctly")
+		}
+	})
+
+	t.Run("panics with duplicate names", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("expected panic for duplicate column names")
+			}
+		}()
+		s1 := NewIndexSeries("x", []int{1})
+		s2 := NewIndexSeries("x", []int{2})
+
+		ConcatColumns(0, s1, s2)
+	})
+
+	t.Run("panics with duplicate labels in one series", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("expected panic for duplicate labels")
+			}
+		}()
+		s := NewSeries("a", []int{1, 2}, []string{"k", "k"})
+
+		ConcatColumns(0, s)
+	})
+}
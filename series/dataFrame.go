@@ -0,0 +1,269 @@
+package series
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Column is a Series of any value type seen from a DataFrame
+// every *Series[T, R] is a Column[R], the unexported methods keep it from being implemented elsewhere
+type Column[R comparable] interface {
+	Len() int
+	Name() string
+	Index() []R
+
+	valueAt(i int) any
+	take(positions []int) Column[R]
+	gather(positions []int, other Column[R], index []R) Column[R]
+	gatherPositional(positions []int, other Column[R]) Column[int]
+}
+
+// valueAt returns the value at the given position as any
+func (s *Series[T, R]) valueAt(i int) any {
+	return s.values[i]
+}
+
+// take returns a new Series holding the values and labels at the given positions
+func (s *Series[T, R]) take(positions []int) Column[R] {
+	return s.takePositions(positions)
+}
+
+// takePositions returns a new Series holding the values and labels at the given positions
+func (s *Series[T, R]) takePositions(positions []int) *Series[T, R] {
+	values := make([]T, len(positions))
+	index := make([]R, len(positions))
+	for i, p := range positions {
+		values[i] = s.values[p]
+		index[i] = s.index[p]
+	}
+	return NewSeries(s.name, values, index)
+}
+
+// gather returns the values at the given positions labeled by index
+// positions from Len on refer to other, which must be a column of the same type, and -1 gives a missing value
+func (s *Series[T, R]) gather(positions []int, other Column[R], index []R) Column[R] {
+	return NewSeries(s.name, s.gatherValues(positions, other), index)
+}
+
+// gatherPositional is gather with the positions in the result as labels
+func (s *Series[T, R]) gatherPositional(positions []int, other Column[R]) Column[int] {
+	return NewIndexSeries(s.name, s.gatherValues(positions, other))
+}
+
+func (s *Series[T, R]) gatherValues(positions []int, other Column[R]) []T {
+	var otherValues []T
+	if o, ok := other.(*Series[T, R]); ok {
+		otherValues = o.values
+	}
+
+	missing := missingValue[T]()
+	values := make([]T, len(positions))
+	for i, p := range positions {
+		switch {
+		case p < 0:
+			values[i] = missing
+		case p < len(s.values):
+			values[i] = s.values[p]
+		default:
+			values[i] = otherValues[p-len(s.values)]
+		}
+	}
+	return values
+}
+
+// missingValue returns the value of a missing row: NaN for floats and the zero value otherwise
+func missingValue[T comparable]() T {
+	var v T
+	switch p := any(&v).(type) {
+	case *float32:
+		*p = float32(math.NaN())
+	case *float64:
+		*p = math.NaN()
+	}
+	return v
+}
+
+// A DataFrame is a table of named Series which all share the same index
+type DataFrame[R comparable] struct {
+	index   []R
+	columns []Column[R]
+}
+
+// NewDataFrame creates a new DataFrame from the given columns
+// all columns must have the same labels in the same order and unique names
+func NewDataFrame[R comparable](columns ...Column[R]) *DataFrame[R] {
+	if len(columns) == 0 {
+		panic("cannot create DataFrame with no columns")
+	}
+
+	index := columns[0].Index()
+	seen := make(map[string]bool, len(columns))
+	for _, c := range columns {
+		if seen[c.Name()] {
+			panic(fmt.Sprintf("duplicate column name %q", c.Name()))
+		}
+		seen[c.Name()] = true
+
+		if c.Len() != len(index) {
+			panic("all columns must have the same length")
+		}
+		labels := c.Index()
+		for i := range labels {
+			if labels[i] != index[i] {
+				panic(fmt.Sprintf("column %q is not aligned with the DataFrame index", c.Name()))
+			}
+		}
+	}
+
+	return &DataFrame[R]{
+		index:   index,
+		columns: columns,
+	}
+}
+
+// Len returns the number of rows of the DataFrame
+func (df *DataFrame[R]) Len() int {
+	return len(df.index)
+}
+
+// Index returns the index of the DataFrame as a copy of the index slice
+func (df *DataFrame[R]) Index() []R {
+	copied := make([]R, len(df.index))
+	copy(copied, df.index)
+	return copied
+}
+
+// Columns returns the names of the columns in order
+func (df *DataFrame[R]) Columns() []string {
+	names := make([]string, len(df.columns))
+	for i, c := range df.columns {
+		names[i] = c.Name()
+	}
+	return names
+}
+
+// HasColumn checks if the DataFrame has a column with the given name
+func (df *DataFrame[R]) HasColumn(name string) bool {
+	return df.columnPos(name) >= 0
+}
+
+// Column returns the column with the given name
+func (df *DataFrame[R]) Column(name string) Column[R] {
+	pos := df.columnPos(name)
+	if pos < 0 {
+		panic(fmt.Sprintf("no column named %q", name))
+	}
+	return df.columns[pos]
+}
+
+// columnPos returns the position of the column with the given name or -1
+func (df *DataFrame[R]) columnPos(name string) int {
+	for i, c := range df.columns {
+		if c.Name() == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// GetColumn returns the column with the given name as a typed Series
+// panics if the column does not hold values of type T
+func GetColumn[T comparable, R comparable](df *DataFrame[R], name string) *Series[T, R] {
+	s, ok := df.Column(name).(*Series[T, R])
+	if !ok {
+		panic(fmt.Sprintf("column %q does not hold values of type %T", name, *new(T)))
+	}
+	return s
+}
+
+// AddColumn returns a new DataFrame with the given column appended
+func (df *DataFrame[R]) AddColumn(c Column[R]) *DataFrame[R] {
+	columns := make([]Column[R], 0, len(df.columns)+1)
+	columns = append(columns, df.columns...)
+	columns = append(columns, c)
+	return NewDataFrame(columns...)
+}
+
+// Select returns a new DataFrame with only the given columns in the given order
+func (df *DataFrame[R]) Select(names ...string) *DataFrame[R] {
+	columns := make([]Column[R], len(names))
+	for i, name := range names {
+		columns[i] = df.Column(name)
+	}
+	return NewDataFrame(columns...)
+}
+
+// Take returns a new DataFrame holding the rows at the given positions
+func (df *DataFrame[R]) Take(positions []int) *DataFrame[R] {
+	for _, p := range positions {
+		if p < 0 || p >= df.Len() {
+			panic(fmt.Sprintf("index %d out of bounds", p))
+		}
+	}
+
+	columns := make([]Column[R], len(df.columns))
+	for i, c := range df.columns {
+		columns[i] = c.take(positions)
+	}
+	return NewDataFrame(columns...)
+}
+
+// Filter returns a new DataFrame with only the rows where the mask is true
+// the mask is applied by position and must have the same length as the DataFrame
+func (df *DataFrame[R]) Filter(mask *Series[bool, R]) *DataFrame[R] {
+	if mask.Len() != df.Len() {
+		panic("mask must have the same length as the DataFrame")
+	}
+
+	var positions []int
+	for i, keep := range mask.values {
+		if keep {
+			positions = append(positions, i)
+		}
+	}
+	return df.Take(positions)
+}
+
+// Head returns the first n rows of the DataFrame
+func (df *DataFrame[R]) Head(n int) *DataFrame[R] {
+	return df.Take(rangePositions(0, min(n, df.Len())))
+}
+
+// Tail returns the last n rows of the DataFrame
+func (df *DataFrame[R]) Tail(n int) *DataFrame[R] {
+	return df.Take(rangePositions(df.Len()-min(n, df.Len()), df.Len()))
+}
+
+// String returns a string representation of the DataFrame
+func (df *DataFrame[R]) String() string {
+	var sb strings.Builder
+
+	sb.WriteString(strings.Join(df.Columns(), "\t") + "\n")
+
+	// don't print more than 10 rows
+	maxLen := min(df.Len(), 10)
+
+	for i := range maxLen {
+		sb.WriteString(fmt.Sprintf("%v:", df.index[i]))
+		for _, c := range df.columns {
+			sb.WriteString(fmt.Sprintf("\t%v", c.valueAt(i)))
+		}
+		sb.WriteString("\n")
+	}
+
+	if df.Len() > 10 {
+		sb.WriteString(fmt.Sprintf("... (%d more)\n", df.Len()-10))
+	}
+
+	return sb.String()
+}
+
+// rangePositions returns the positions from start up to but not including end
+func rangePositions(start, end int) []int {
+	positions := make([]int, end-start)
+	for i := range positions {
+		positions[i] = start + i
+	}
+	return positions
+}
@@ -0,0 +1,139 @@
+package series
+
+import (
+	"testing"
+)
+
+func TestNewDataFrame(t *testing.T) {
+	t.Run("creates dataframe from columns of different types", func(t *testing.T) {
+		ages := NewSeries("age", []int{25, 30}, []string{"alice", "bob"})
+		cities := NewSeries("city", []string{"Berlin", "Paris"}, []string{"alice", "bob"})
+
+		df := NewDataFrame[string](ages, cities)
+
+		if df.Len() != 2 {
+			t.Errorf("expected length 2, got %d", df.Len())
+		}
+		columns := df.Columns()
+		if len(columns) != 2 || columns[0] != "age" || columns[1] != "city" {
+			t.Errorf("unexpected columns %v", columns)
+		}
+	})
+
+	t.Run("panics with no columns", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("expected panic for no columns")
+			}
+		}()
+		NewDataFrame[int]()
+	})
+
+	t.Run("panics with unaligned columns", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("expected panic for unaligned columns")
+			}
+		}()
+		a := NewSeries("a", []int{1, 2}, []string{"x", "y"})
+		b := NewSeries("b", []int{1, 2}, []string{"y", "x"})
+		NewDataFrame[string](a, b)
+	})
+
+	t.Run("panics with different lengths", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("expected panic for different lengths")
+			}
+		}()
+		a := NewIndexSeries("a", []int{1, 2})
+		b := NewIndexSeries("b", []int{1})
+		NewDataFrame[int](a, b)
+	})
+}
+
+func TestGetColumn(t *testing.T) {
+	t.Run("returns typed column", func(t *testing.T) {
+		df := NewDataFrame[int](NewIndexSeries("a", []int{1, 2}), NewIndexSeries("b", []string{"x", "y"}))
+
+		b := GetColumn[string](df, "b")
+		if b.At(1) != "y" {
+			t.Errorf("expected 'y', got %s", b.At(1))
+		}
+	})
+
+	t.Run("panics with wrong type", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("expected panic for wrong type")
+			}
+		}()
+		df := NewDataFrame[int](NewIndexSeries("a", []int{1, 2}))
+		GetColumn[string](df, "a")
+	})
+
+	t.Run("panics with unknown column", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("expected panic for unknown column")
+			}
+		}()
+		df := NewDataFrame[int](NewIndexSeries("a", []int{1, 2}))
+		df.Column("missing")
+	})
+}
+
+func TestDataFrame_SelectAndAdd(t *testing.T) {
+	df := NewDataFrame[int](NewIndexSeries("a", []int{1, 2}), NewIndexSeries("b", []int{3, 4}))
+	df = df.AddColumn(NewIndexSeries("c", []float64{0.5, 1.5}))
+
+	if !df.HasColumn("c") {
+		t.Error("expected column c to be added")
+	}
+
+	selected := df.Select("c", "a")
+	columns := selected.Columns()
+	if len(columns) != 2 || columns[0] != "c" || columns[1] != "a" {
+		t.Errorf("unexpected columns %v", columns)
+	}
+}
+
+func TestDataFrame_HeadTail(t *testing.T) {
+	df := NewDataFrame[string](
+		NewSeries("a", []int{1, 2, 3}, []string{"x", "y", "z"}),
+		NewSeries("b", []bool{true, false, true}, []string{"x", "y", "z"}),
+	)
+
+	head := df.Head(2)
+	if head.Len() != 2 || GetColumn[int](head, "a").At(1) != 2 {
+		t.Error("head rows are incorrect")
+	}
+
+	tail := df.Tail(2)
+	if tail.Len() != 2 || tail.Index()[0] != "y" {
+		t.Error("tail rows are incorrect")
+	}
+
+	if df.String() == "" {
+		t.Error("expected non-empty string")
+	}
+}
+
+func TestDataFrame_Filter(t *testing.T) {
+	df := NewDataFrame[string](
+		NewSeries("a", []int{1, 2, 3}, []string{"x", "y", "z"}),
+		NewSeries("b", []bool{true, false, true}, []string{"x", "y", "z"}),
+	)
+
+	filtered := df.Filter(GetColumn[bool](df, "b"))
+	if filtered.Len() != 2 || filtered.Index()[1] != "z" || GetColumn[int](filtered, "a").At(1) != 3 {
+		t.Errorf("unexpected rows\n%v", filtered)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for a mask of another length")
+		}
+	}()
+	df.Filter(NewSeries("m", []bool{true}, []string{"x"}))
+}
@@ -0,0 +1,139 @@
+package series
+
+import (
+	"time"
+)
+
+// DatetimeAccessor offers vectorized date and time operations on a time.Time Series
+// every operation returns a new Series with the name and labels of the original
+type DatetimeAccessor[R comparable] struct {
+	s *Series[time.Time, R]
+}
+
+// Dt returns the DatetimeAccessor of the given Series
+func Dt[R comparable](s *Series[time.Time, R]) *DatetimeAccessor[R] {
+	return &DatetimeAccessor[R]{s: s}
+}
+
+// CalendarUnit is a unit of the calendar used by Truncate
+type CalendarUnit int
+
+const (
+	// CalendarYear truncates to midnight of January 1st
+	CalendarYear CalendarUnit = iota
+	// CalendarMonth truncates to midnight of the first day of the month
+	CalendarMonth
+	// CalendarDay truncates to midnight
+	CalendarDay
+	// CalendarHour truncates to the start of the hour
+	CalendarHour
+	// CalendarMinute truncates to the start of the minute
+	CalendarMinute
+	// CalendarSecond truncates to the start of the second
+	CalendarSecond
+)
+
+// Year returns the year of every value
+func (da *DatetimeAccessor[R]) Year() *Series[int, R] {
+	return mapValues(da.s, time.Time.Year)
+}
+
+// Month returns the month of every value
+func (da *DatetimeAccessor[R]) Month() *Series[time.Month, R] {
+	return mapValues(da.s, time.Time.Month)
+}
+
+// Day returns the day of the month of every value
+func (da *DatetimeAccessor[R]) Day() *Series[int, R] {
+	return mapValues(da.s, time.Time.Day)
+}
+
+// Weekday returns the day of the week of every value
+func (da *DatetimeAccessor[R]) Weekday() *Series[time.Weekday, R] {
+	return mapValues(da.s, time.Time.Weekday)
+}
+
+// Hour returns the hour of every value
+func (da *DatetimeAccessor[R]) Hour() *Series[int, R] {
+	return mapValues(da.s, time.Time.Hour)
+}
+
+// Minute returns the minute of every value
+func (da *DatetimeAccessor[R]) Minute() *Series[int, R] {
+	return mapValues(da.s, time.Time.Minute)
+}
+
+// Second returns the second of every value
+func (da *DatetimeAccessor[R]) Second() *Series[int, R] {
+	return mapValues(da.s, time.Time.Second)
+}
+
+// Floor rounds every value down to a multiple of freq since the zero time
+func (da *DatetimeAccessor[R]) Floor(freq time.Duration) *Series[time.Time, R] {
+	return mapValues(da.s, func(t time.Time) time.Time { return t.Truncate(freq) })
+}
+
+// Ceil rounds every value up to a multiple of freq since the zero time
+func (da *DatetimeAccessor[R]) Ceil(freq time.Duration) *Series[time.Time, R] {
+	return mapValues(da.s, func(t time.Time) time.Time {
+		floor := t.Truncate(freq)
+		if floor.Equal(t) {
+			return floor
+		}
+		return floor.Add(freq)
+	})
+}
+
+// Round rounds every value to the nearest multiple of freq since the zero time, halfway values round up
+func (da *DatetimeAccessor[R]) Round(freq time.Duration) *Series[time.Time, R] {
+	return mapValues(da.s, func(t time.Time) time.Time { return t.Round(freq) })
+}
+
+// Truncate sets everything below the given calendar unit to its start in the location of the value
+// unlike Floor it knows about months of different length and daylight saving time
+func (da *DatetimeAccessor[R]) Truncate(unit CalendarUnit) *Series[time.Time, R] {
+	return mapValues(da.s, func(t time.Time) time.Time {
+		year, month, day := t.Date()
+		hour, minute, second := t.Clock()
+		switch unit {
+		case CalendarYear:
+			month, day, hour, minute, second = time.January, 1, 0, 0, 0
+		case CalendarMonth:
+			day, hour, minute, second = 1, 0, 0, 0
+		case CalendarDay:
+			hour, minute, second = 0, 0, 0
+		case CalendarHour:
+			minute, second = 0, 0
+		case CalendarMinute:
+			second = 0
+		case CalendarSecond:
+		default:
+			panic("unknown calendar unit")
+		}
+		return time.Date(year, month, day, hour, minute, second, 0, t.Location())
+	})
+}
+
+// Format returns every value formatted with the given layout, see time.Time.Format
+func (da *DatetimeAccessor[R]) Format(layout string) *Series[string, R] {
+	return mapValues(da.s, func(t time.Time) string { return t.Format(layout) })
+}
+
+// InLocation returns every value as the same instant in the given location
+func (da *DatetimeAccessor[R]) InLocation(loc *time.Location) *Series[time.Time, R] {
+	return mapValues(da.s, func(t time.Time) time.Time { return t.In(loc) })
+}
+
+// Sub returns the duration between the values of both Series element-wise
+// both Series must have the same length
+func (da *DatetimeAccessor[R]) Sub(other *Series[time.Time, R]) *Series[time.Duration, R] {
+	if other.Len() != da.s.Len() {
+		panic("series must be of the same length to subtract")
+	}
+
+	values := make([]time.Duration, da.s.Len())
+	for i, t := range da.s.values {
+		values[i] = t.Sub(other.values[i])
+	}
+	return NewSeries(da.s.name, values, da.s.index)
+}
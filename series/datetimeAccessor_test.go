@@ -0,0 +1,122 @@
+package series
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func dtSeries() *Series[time.Time, string] {
+	return NewSeries("ts", []time.Time{
+		time.Date(2024, time.February, 29, 13, 45, 30, 0, time.UTC),
+		time.Date(2023, time.December, 31, 23, 59, 59, 0, time.UTC),
+	}, []string{"a", "b"})
+}
+
+func TestDt_Fields(t *testing.T) {
+	s := dtSeries()
+
+	if !slices.Equal(Dt(s).Year().Values(), []int{2024, 2023}) {
+		t.Errorf("unexpected years %v", Dt(s).Year().Values())
+	}
+	if !slices.Equal(Dt(s).Month().Values(), []time.Month{time.February, time.December}) {
+		t.Errorf("unexpected months %v", Dt(s).Month().Values())
+	}
+	if !slices.Equal(Dt(s).Day().Values(), []int{29, 31}) {
+		t.Errorf("unexpected days %v", Dt(s).Day().Values())
+	}
+	if !slices.Equal(Dt(s).Weekday().Values(), []time.Weekday{time.Thursday, time.Sunday}) {
+		t.Errorf("unexpected weekdays %v", Dt(s).Weekday().Values())
+	}
+	if !slices.Equal(Dt(s).Hour().Values(), []int{13, 23}) {
+		t.Errorf("unexpected hours %v", Dt(s).Hour().Values())
+	}
+	if !slices.Equal(Dt(s).Minute().Values(), []int{45, 59}) || !slices.Equal(Dt(s).Second().Values(), []int{30, 59}) {
+		t.Error("unexpected minutes or seconds")
+	}
+
+	years := Dt(s).Year()
+	if years.Name() != "ts" || years.Index()[1] != "b" {
+		t.Error("name and labels should be preserved")
+	}
+}
+
+func TestDt_Rounding(t *testing.T) {
+	s := dtSeries()
+
+	floor := Dt(s).Floor(time.Hour)
+	if !floor.At(0).Equal(time.Date(2024, time.February, 29, 13, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected floor %v", floor.At(0))
+	}
+
+	ceil := Dt(s).Ceil(time.Hour)
+	if !ceil.At(1).Equal(time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected ceil %v", ceil.At(1))
+	}
+
+	exact := NewIndexSeries("ts", []time.Time{time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)})
+	if !Dt(exact).Ceil(time.Hour).At(0).Equal(exact.At(0)) {
+		t.Error("ceil should not change values which are already on the frequency")
+	}
+
+	round := Dt(s).Round(time.Hour)
+	if !round.At(0).Equal(time.Date(2024, time.February, 29, 14, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected round %v", round.At(0))
+	}
+}
+
+func TestDt_Truncate(t *testing.T) {
+	s := dtSeries()
+
+	month := Dt(s).Truncate(CalendarMonth)
+	if !month.At(0).Equal(time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected month start %v", month.At(0))
+	}
+
+	year := Dt(s).Truncate(CalendarYear)
+	if !year.At(1).Equal(time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected year start %v", year.At(1))
+	}
+
+	minute := Dt(s).Truncate(CalendarMinute)
+	if minute.At(0).Second() != 0 || minute.At(0).Minute() != 45 {
+		t.Errorf("unexpected minute start %v", minute.At(0))
+	}
+}
+
+func TestDt_FormatAndLocation(t *testing.T) {
+	s := dtSeries()
+
+	formatted := Dt(s).Format("2006-01-02")
+	if !slices.Equal(formatted.Values(), []string{"2024-02-29", "2023-12-31"}) {
+		t.Errorf("unexpected formatted values %v", formatted.Values())
+	}
+
+	loc := time.FixedZone("UTC+2", 2*60*60)
+	moved := Dt(s).InLocation(loc)
+	if moved.At(0).Hour() != 15 || !moved.At(0).Equal(s.At(0)) {
+		t.Errorf("unexpected value in location %v", moved.At(0))
+	}
+}
+
+func TestDt_Sub(t *testing.T) {
+	s := dtSeries()
+	other := NewSeries("start", []time.Time{
+		time.Date(2024, time.February, 29, 12, 45, 30, 0, time.UTC),
+		time.Date(2023, time.December, 31, 23, 59, 0, 0, time.UTC),
+	}, []string{"a", "b"})
+
+	result := Dt(s).Sub(other)
+	if !slices.Equal(result.Values(), []time.Duration{time.Hour, 59 * time.Second}) {
+		t.Errorf("unexpected durations %v", result.Values())
+	}
+
+	t.Run("panics with mismatched length", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("expected panic for mismatched length")
+			}
+		}()
+		Dt(s).Sub(other.Head(1))
+	})
+}
@@ -0,0 +1,282 @@
+package series
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// This file holds the small part of FlatBuffers needed to read and write Arrow IPC metadata.
+// Tables are written front to back: a table is followed by the strings, vectors and tables it
+// references, so every unsigned offset points forward like the format requires.
+
+// fbValue is something which can be written into a FlatBuffer and referenced by offset
+type fbValue interface {
+	writeTo(w *fbWriter) int
+}
+
+// fbField is a field of a table, either a little endian scalar of size bytes or an offset to ref
+type fbField struct {
+	size   int
+	scalar uint64
+	ref    fbValue
+}
+
+// fbScalar returns a scalar field of the given size in bytes
+func fbScalar(size int, v uint64) *fbField {
+	return &fbField{size: size, scalar: v}
+}
+
+// fbRef returns a field holding the offset to v
+func fbRef(v fbValue) *fbField {
+	return &fbField{size: 4, ref: v}
+}
+
+// fbTable is a table, fields are indexed by their id and nil fields are left out
+type fbTable []*fbField
+
+// fbString is a string
+type fbString string
+
+// fbStructs is a vector of structs of elemSize bytes each
+type fbStructs struct {
+	elemSize int
+	data     []byte
+}
+
+// fbTables is a vector of tables
+type fbTables []fbTable
+
+// fbWriter writes FlatBuffers front to back
+type fbWriter struct {
+	buf []byte
+}
+
+// fbBuild returns the FlatBuffer with root as the root table
+func fbBuild(root fbTable) []byte {
+	w := &fbWriter{buf: make([]byte, 4)}
+	pos := root.writeTo(w)
+	binary.LittleEndian.PutUint32(w.buf, uint32(pos))
+	w.align(8)
+	return w.buf
+}
+
+// align pads the buffer with zeros until its length is a multiple of n
+func (w *fbWriter) align(n int) {
+	for len(w.buf)%n != 0 {
+		w.buf = append(w.buf, 0)
+	}
+}
+
+// patch writes the offset from the position at to the position target
+func (w *fbWriter) patch(at, target int) {
+	binary.LittleEndian.PutUint32(w.buf[at:], uint32(target-at))
+}
+
+func (t fbTable) writeTo(w *fbWriter) int {
+	// lay out the fields behind the 4 byte offset to the vtable, each aligned to its size
+	offsets := make([]int, len(t))
+	size := 4
+	for i, f := range t {
+		if f == nil {
+			continue
+		}
+		size = (size + f.size - 1) / f.size * f.size
+		offsets[i] = size
+		size += f.size
+	}
+
+	w.align(2)
+	vtable := len(w.buf)
+	w.buf = binary.LittleEndian.AppendUint16(w.buf, uint16(4+2*len(t)))
+	w.buf = binary.LittleEndian.AppendUint16(w.buf, uint16(size))
+	for _, off := range offsets {
+		w.buf = binary.LittleEndian.AppendUint16(w.buf, uint16(off))
+	}
+
+	w.align(8)
+	table := len(w.buf)
+	w.buf = append(w.buf, make([]byte, size)...)
+	binary.LittleEndian.PutUint32(w.buf[table:], uint32(table-vtable))
+	for i, f := range t {
+		if f == nil || f.ref != nil {
+			continue
+		}
+		field := w.buf[table+offsets[i]:]
+		switch f.size {
+		case 1:
+			field[0] = byte(f.scalar)
+		case 2:
+			binary.LittleEndian.PutUint16(field, uint16(f.scalar))
+		case 4:
+			binary.LittleEndian.PutUint32(field, uint32(f.scalar))
+		case 8:
+			binary.LittleEndian.PutUint64(field, f.scalar)
+		}
+	}
+
+	for i, f := range t {
+		if f != nil && f.ref != nil {
+			w.patch(table+offsets[i], f.ref.writeTo(w))
+		}
+	}
+	return table
+}
+
+func (s fbString) writeTo(w *fbWriter) int {
+	w.align(4)
+	pos := len(w.buf)
+	w.buf = binary.LittleEndian.AppendUint32(w.buf, uint32(len(s)))
+	w.buf = append(w.buf, s...)
+	w.buf = append(w.buf, 0)
+	return pos
+}
+
+func (v fbStructs) writeTo(w *fbWriter) int {
+	// the elements behind the length must be aligned to 8
+	w.align(4)
+	if len(w.buf)%8 == 0 {
+		w.buf = append(w.buf, 0, 0, 0, 0)
+	}
+	pos := len(w.buf)
+	w.buf = binary.LittleEndian.AppendUint32(w.buf, uint32(len(v.data)/v.elemSize))
+	w.buf = append(w.buf, v.data...)
+	return pos
+}
+
+func (v fbTables) writeTo(w *fbWriter) int {
+	w.align(4)
+	pos := len(w.buf)
+	w.buf = binary.LittleEndian.AppendUint32(w.buf, uint32(len(v)))
+	w.buf = append(w.buf, make([]byte, 4*len(v))...)
+	for i, t := range v {
+		w.patch(pos+4+4*i, t.writeTo(w))
+	}
+	return pos
+}
+
+// errFlatBuffer is returned for FlatBuffers pointing outside of their buffer
+var errFlatBuffer = errors.New("corrupt flatbuffer")
+
+// fbReader reads a table of a FlatBuffer
+// all reads are bounds checked, a bad offset sets err and returns zero values
+type fbReader struct {
+	buf []byte
+	pos int
+	err *error
+}
+
+// fbRoot returns the root table of the FlatBuffer
+func fbRoot(buf []byte, err *error) fbReader {
+	r := fbReader{buf: buf, err: err}
+	r.pos = int(r.u32(0))
+	return r
+}
+
+// bytes returns n bytes at the position at or nil if they are out of bounds
+func (r fbReader) bytes(at, n int) []byte {
+	if at < 0 || n < 0 || at+n > len(r.buf) {
+		if *r.err == nil {
+			*r.err = errFlatBuffer
+		}
+		return nil
+	}
+	return r.buf[at : at+n]
+}
+
+func (r fbReader) u16(at int) uint16 {
+	if b := r.bytes(at, 2); b != nil {
+		return binary.LittleEndian.Uint16(b)
+	}
+	return 0
+}
+
+func (r fbReader) u32(at int) uint32 {
+	if b := r.bytes(at, 4); b != nil {
+		return binary.LittleEndian.Uint32(b)
+	}
+	return 0
+}
+
+func (r fbReader) u64(at int) uint64 {
+	if b := r.bytes(at, 8); b != nil {
+		return binary.LittleEndian.Uint64(b)
+	}
+	return 0
+}
+
+// field returns the position of the field with the given id or 0 if it is not set
+func (r fbReader) field(id int) int {
+	vtable := r.pos - int(int32(r.u32(r.pos)))
+	if 4+2*id >= int(r.u16(vtable)) {
+		return 0
+	}
+	off := int(r.u16(vtable + 4 + 2*id))
+	if off == 0 {
+		return 0
+	}
+	return r.pos + off
+}
+
+// scalar returns the unsigned scalar field of size bytes with the given id or def if it is not set
+func (r fbReader) scalar(id int, size int, def uint64) uint64 {
+	at := r.field(id)
+	if at == 0 {
+		return def
+	}
+	switch size {
+	case 1:
+		if b := r.bytes(at, 1); b != nil {
+			return uint64(b[0])
+		}
+		return 0
+	case 2:
+		return uint64(r.u16(at))
+	case 4:
+		return uint64(r.u32(at))
+	default:
+		return r.u64(at)
+	}
+}
+
+// ref returns the position the offset field with the given id points to or 0 if it is not set
+func (r fbReader) ref(id int) int {
+	at := r.field(id)
+	if at == 0 {
+		return 0
+	}
+	return at + int(r.u32(at))
+}
+
+// table returns the table the field with the given id points to
+func (r fbReader) table(id int) (fbReader, bool) {
+	pos := r.ref(id)
+	return fbReader{buf: r.buf, pos: pos, err: r.err}, pos != 0
+}
+
+// string returns the string field with the given id or "" if it is not set
+func (r fbReader) string(id int) string {
+	pos := r.ref(id)
+	if pos == 0 {
+		return ""
+	}
+	return string(r.bytes(pos+4, int(r.u32(pos))))
+}
+
+// vector returns the position of the first element and the length of the vector field with the given id
+// elemSize is the size of an element in bytes, a vector reaching outside of the buffer is empty
+func (r fbReader) vector(id int, elemSize int) (int, int) {
+	pos := r.ref(id)
+	if pos == 0 {
+		return 0, 0
+	}
+	n := int(r.u32(pos))
+	if r.bytes(pos+4, n*elemSize) == nil {
+		return 0, 0
+	}
+	return pos + 4, n
+}
+
+// tableAt returns the table referenced by the offset at the given position, e.g. in a vector of tables
+func (r fbReader) tableAt(at int) fbReader {
+	return fbReader{buf: r.buf, pos: at + int(r.u32(at)), err: r.err}
+}
@@ -0,0 +1,63 @@
+package series
+
+import (
+	"encoding/binary"
+	"testing"
+)
+
+func TestFlatBuffers(t *testing.T) {
+	child := fbTable{fbScalar(2, 7), nil, fbRef(fbString("inner"))}
+	structs := binary.LittleEndian.AppendUint64(nil, 11)
+	structs = binary.LittleEndian.AppendUint64(structs, 12)
+	root := fbTable{
+		fbScalar(1, 1),
+		fbScalar(8, 1<<40),
+		nil,
+		fbRef(fbString("hello")),
+		fbRef(child),
+		fbRef(fbStructs{elemSize: 8, data: structs}),
+		fbRef(fbTables{child, {fbScalar(4, 99)}}),
+		fbScalar(4, 42),
+	}
+	buf := fbBuild(root)
+	if len(buf)%8 != 0 {
+		t.Errorf("buffer must be padded to 8 bytes, got %d", len(buf))
+	}
+
+	var err error
+	r := fbRoot(buf, &err)
+	if r.scalar(0, 1, 0) != 1 || r.scalar(1, 8, 0) != 1<<40 || r.scalar(7, 4, 0) != 42 {
+		t.Error("unexpected scalars")
+	}
+	if r.scalar(2, 4, 5) != 5 || r.scalar(20, 4, 6) != 6 {
+		t.Error("missing fields must return the default")
+	}
+	if r.string(3) != "hello" {
+		t.Errorf("expected hello, got %q", r.string(3))
+	}
+
+	c, ok := r.table(4)
+	if !ok || c.scalar(0, 2, 0) != 7 || c.string(2) != "inner" {
+		t.Error("unexpected child table")
+	}
+
+	start, n := r.vector(5, 8)
+	if n != 2 || start%8 != 0 || r.u64(start) != 11 || r.u64(start+8) != 12 {
+		t.Error("unexpected struct vector")
+	}
+
+	start, n = r.vector(6, 4)
+	if n != 2 || r.tableAt(start).string(2) != "inner" || r.tableAt(start+4).scalar(0, 4, 0) != 99 {
+		t.Error("unexpected table vector")
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// an offset pointing outside of the buffer sets the error instead of panicking
+	binary.LittleEndian.PutUint32(buf, uint32(len(buf)+100))
+	r = fbRoot(buf, &err)
+	if r.string(3) != "" || err == nil {
+		t.Error("expected error for out of bounds offset")
+	}
+}
@@ -0,0 +1,571 @@
+package series
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"time"
+)
+
+// JoinHow decides which rows a Merge or Join keeps
+type JoinHow int
+
+const (
+	// InnerJoin keeps only the rows whose keys are found on both sides
+	InnerJoin JoinHow = iota
+	// LeftJoin keeps every row of the left side
+	LeftJoin
+	// RightJoin keeps every row of the right side
+	RightJoin
+	// OuterJoin keeps every row of both sides
+	OuterJoin
+	// CrossJoin combines every row of the left side with every row of the right side
+	CrossJoin
+)
+
+// JoinAlgorithm decides how the matching rows of a Merge or Join are found
+type JoinAlgorithm int
+
+const (
+	// HashJoin looks the keys up in a hash table built from one side
+	HashJoin JoinAlgorithm = iota
+	// SortMergeJoin sorts both sides by their keys and walks them in step
+	// the keys must be numbers, strings, bools or times
+	SortMergeJoin
+)
+
+// JoinValidate checks that the keys of a Merge or Join have the expected relationship
+type JoinValidate int
+
+const (
+	// ValidateNone does not check the keys
+	ValidateNone JoinValidate = iota
+	// OneToOne requires the keys to be unique on both sides
+	OneToOne
+	// OneToMany requires the keys to be unique on the left side
+	OneToMany
+	// ManyToOne requires the keys to be unique on the right side
+	ManyToOne
+)
+
+// MergeOptions are the options of Merge and Join
+type MergeOptions struct {
+	// Algorithm is the algorithm used to find the matching rows, the result does not depend on it
+	Algorithm JoinAlgorithm
+	// Suffixes are appended to the names of the left and right columns found on both sides
+	// "_x" and "_y" are used if both are empty
+	Suffixes [2]string
+	// Indicator adds a categorical column "_merge" which tells if a row was found on the left side, the right side or both
+	Indicator bool
+	// Validate checks the relationship of the keys before joining
+	Validate JoinValidate
+}
+
+// IndicatorColumn is the name of the column added by MergeOptions.Indicator
+const IndicatorColumn = "_merge"
+
+// joinKeys are the keys of both sides of a join
+// ids are equal if and only if the keys are equal, missing keys have the id -1 and match nothing
+type joinKeys struct {
+	left, right     []int
+	leftAt, rightAt func(i int) []any
+}
+
+// joinPairs are the positions of the rows combined into each row of a join, -1 if there is no such row
+type joinPairs struct {
+	left, right []int
+}
+
+// Merge joins the rows of left and right whose values in the on columns are equal
+// the on columns must be found on both sides with the same type, rows with NaN or missing keys match nothing
+// rows are ordered like the left side, followed by the rows only found on the right side, or like the right side for RightJoin
+// the result has the key columns in the place of the left ones, filled from the right side for rows only found there,
+// followed by the other right columns, it is labeled by the position of the rows
+// CrossJoin takes no on columns
+func Merge[R comparable](left, right *DataFrame[R], on []string, how JoinHow, opts MergeOptions) (*DataFrame[int], error) {
+	if how == CrossJoin {
+		if len(on) > 0 {
+			return nil, errors.New("a cross join takes no key columns")
+		}
+		return assembleMerge(left, right, nil, crossPairs(left.Len(), right.Len()), opts)
+	}
+	if len(on) == 0 {
+		return nil, errors.New("no key columns to merge on")
+	}
+
+	leftKeys := make([]Column[R], len(on))
+	rightKeys := make([]Column[R], len(on))
+	for i, name := range on {
+		if slices.Contains(on[:i], name) {
+			return nil, fmt.Errorf("duplicate key column %q", name)
+		}
+		l, r := left.columnPos(name), right.columnPos(name)
+		if l < 0 || r < 0 {
+			return nil, fmt.Errorf("key column %q is not found on both sides", name)
+		}
+		leftKeys[i], rightKeys[i] = left.columns[l], right.columns[r]
+		if reflect.TypeOf(leftKeys[i]) != reflect.TypeOf(rightKeys[i]) {
+			return nil, fmt.Errorf("key column %q has different types on both sides", name)
+		}
+	}
+
+	pairs, err := joinRows(columnKeys(leftKeys, rightKeys), how, opts)
+	if err != nil {
+		return nil, err
+	}
+	return assembleMerge(left, right, on, pairs, opts)
+}
+
+// Join joins the rows of left and right which have the same label
+// it works like Merge with the labels as the only key column, the result is labeled by the joined labels
+func Join[R comparable](left, right *DataFrame[R], how JoinHow, opts MergeOptions) (*DataFrame[R], error) {
+	if how == CrossJoin {
+		return nil, errors.New("a cross join cannot be aligned on the index")
+	}
+	pairs, err := joinRows(labelKeys(left.index, right.index), how, opts)
+	if err != nil {
+		return nil, err
+	}
+	if len(pairs.left) == 0 {
+		return nil, errors.New("the join has no rows")
+	}
+
+	index := make([]R, len(pairs.left))
+	for i, l := range pairs.left {
+		if l >= 0 {
+			index[i] = left.index[l]
+		} else {
+			index[i] = right.index[pairs.right[i]]
+		}
+	}
+
+	var columns []Column[R]
+	for _, c := range left.columns {
+		columns = append(columns, c.gather(pairs.left, nil, index))
+	}
+	for _, c := range right.columns {
+		columns = append(columns, c.gather(pairs.right, nil, index))
+	}
+	if err := suffixColumns(columns, len(left.columns), nil, opts.Suffixes); err != nil {
+		return nil, err
+	}
+	if opts.Indicator {
+		if slices.ContainsFunc(columns, func(c Column[R]) bool { return c.Name() == IndicatorColumn }) {
+			return nil, fmt.Errorf("duplicate column name %q in the result", IndicatorColumn)
+		}
+		codes, categories := indicatorCodes(pairs)
+		columns = append(columns, &CategoricalSeries[R]{
+			name:       IndicatorColumn,
+			codes:      codes,
+			categories: categories,
+			index:      index,
+		})
+	}
+	return NewDataFrame(columns...), nil
+}
+
+// assembleMerge builds the result of Merge from the joined rows
+func assembleMerge[R comparable](left, right *DataFrame[R], on []string, pairs joinPairs, opts MergeOptions) (*DataFrame[int], error) {
+	if len(pairs.left) == 0 {
+		return nil, errors.New("the merge has no rows")
+	}
+
+	// key columns take the value of the right row if there is no left row
+	coalesced := make([]int, len(pairs.left))
+	for i, l := range pairs.left {
+		coalesced[i] = l
+		if l < 0 {
+			coalesced[i] = left.Len() + pairs.right[i]
+		}
+	}
+
+	var columns []Column[int]
+	for _, c := range left.columns {
+		if slices.Contains(on, c.Name()) {
+			columns = append(columns, c.gatherPositional(coalesced, right.Column(c.Name())))
+		} else {
+			columns = append(columns, c.gatherPositional(pairs.left, nil))
+		}
+	}
+	for _, c := range right.columns {
+		if !slices.Contains(on, c.Name()) {
+			columns = append(columns, c.gatherPositional(pairs.right, nil))
+		}
+	}
+	if err := suffixColumns(columns, len(left.columns), on, opts.Suffixes); err != nil {
+		return nil, err
+	}
+	if opts.Indicator {
+		if slices.ContainsFunc(columns, func(c Column[int]) bool { return c.Name() == IndicatorColumn }) {
+			return nil, fmt.Errorf("duplicate column name %q in the result", IndicatorColumn)
+		}
+		codes, categories := indicatorCodes(pairs)
+		columns = append(columns, &CategoricalSeries[int]{
+			name:       IndicatorColumn,
+			codes:      codes,
+			categories: categories,
+			index:      rangePositions(0, len(codes)),
+		})
+	}
+	return NewDataFrame(columns...), nil
+}
+
+// suffixColumns appends the suffixes to the names of the columns found on both sides except the keys
+// the first nLeft columns are the left ones, the result must not have duplicate names
+func suffixColumns[R comparable](columns []Column[R], nLeft int, keys []string, suffixes [2]string) error {
+	if suffixes == [2]string{} {
+		suffixes = [2]string{"_x", "_y"}
+	}
+
+	leftNames := make(map[string]bool)
+	for _, c := range columns[:nLeft] {
+		leftNames[c.Name()] = true
+	}
+	overlap := make(map[string]bool)
+	for _, c := range columns[nLeft:] {
+		if leftNames[c.Name()] && !slices.Contains(keys, c.Name()) {
+			overlap[c.Name()] = true
+		}
+	}
+
+	seen := make(map[string]bool, len(columns))
+	for i, c := range columns {
+		name := c.Name()
+		if overlap[name] {
+			side := 0
+			if i >= nLeft {
+				side = 1
+			}
+			name += suffixes[side]
+			c.(interface{ SetName(string) }).SetName(name)
+		}
+		if seen[name] {
+			return fmt.Errorf("duplicate column name %q in the result", name)
+		}
+		seen[name] = true
+	}
+	return nil
+}
+
+// indicatorCodes returns the codes and categories of the indicator column
+func indicatorCodes(pairs joinPairs) ([]int32, []string) {
+	codes := make([]int32, len(pairs.left))
+	for i, l := range pairs.left {
+		switch {
+		case pairs.right[i] < 0:
+			codes[i] = 0
+		case l < 0:
+			codes[i] = 1
+		default:
+			codes[i] = 2
+		}
+	}
+	return codes, []string{"left_only", "right_only", "both"}
+}
+
+// crossPairs returns every combination of a left and a right row in left order
+func crossPairs(nLeft, nRight int) joinPairs {
+	pairs := joinPairs{
+		left:  make([]int, 0, nLeft*nRight),
+		right: make([]int, 0, nLeft*nRight),
+	}
+	for l := range nLeft {
+		for r := range nRight {
+			pairs.left = append(pairs.left, l)
+			pairs.right = append(pairs.right, r)
+		}
+	}
+	return pairs
+}
+
+// columnKeys interns the values of the key columns of both sides
+// every column is interned on its own, then the ids of the columns are combined row by row
+func columnKeys[R comparable](leftKeys, rightKeys []Column[R]) joinKeys {
+	left := make([]int, leftKeys[0].Len())
+	right := make([]int, rightKeys[0].Len())
+	for k := range leftKeys {
+		values := make(map[any]int)
+		combined := make(map[[2]int]int)
+		intern := func(c Column[R], ids []int) {
+			for i := range ids {
+				v, ok := keyAt(c, i)
+				if ids[i] < 0 || !ok {
+					ids[i] = -1
+					continue
+				}
+				id, found := values[v]
+				if !found {
+					id = len(values)
+					values[v] = id
+				}
+				pair := [2]int{ids[i], id}
+				if ids[i], found = combined[pair]; !found {
+					ids[i] = len(combined)
+					combined[pair] = ids[i]
+				}
+			}
+		}
+		intern(leftKeys[k], left)
+		intern(rightKeys[k], right)
+	}
+
+	at := func(keys []Column[R]) func(i int) []any {
+		return func(i int) []any {
+			values := make([]any, len(keys))
+			for k, c := range keys {
+				values[k] = c.valueAt(i)
+			}
+			return values
+		}
+	}
+	return joinKeys{left: left, right: right, leftAt: at(leftKeys), rightAt: at(rightKeys)}
+}
+
+// keyAt returns the key value of a column at the given position, false if it is missing
+func keyAt[R comparable](c Column[R], i int) (any, bool) {
+	if cs, ok := c.(*CategoricalSeries[R]); ok && cs.codes[i] == missingCode {
+		return nil, false
+	}
+	v := c.valueAt(i)
+	return hashKey(v), v == v
+}
+
+// hashKey returns the value interned for a key, times are converted to UTC which also drops the
+// monotonic clock reading, so equal instants get the same id like they compare equal in compareKey
+func hashKey[T comparable](v T) T {
+	if t, ok := any(v).(time.Time); ok {
+		return any(t.UTC()).(T)
+	}
+	return v
+}
+
+// labelKeys interns the labels of both sides
+func labelKeys[R comparable](leftIndex, rightIndex []R) joinKeys {
+	labels := make(map[R]int)
+	intern := func(index []R) []int {
+		ids := make([]int, len(index))
+		for i, label := range index {
+			if isNaN(label) {
+				ids[i] = -1
+				continue
+			}
+			label = hashKey(label)
+			id, found := labels[label]
+			if !found {
+				id = len(labels)
+				labels[label] = id
+			}
+			ids[i] = id
+		}
+		return ids
+	}
+	at := func(index []R) func(i int) []any {
+		return func(i int) []any { return []any{index[i]} }
+	}
+	return joinKeys{
+		left:    intern(leftIndex),
+		right:   intern(rightIndex),
+		leftAt:  at(leftIndex),
+		rightAt: at(rightIndex),
+	}
+}
+
+// joinRows validates the keys and finds the rows of the join
+func joinRows(keys joinKeys, how JoinHow, opts MergeOptions) (joinPairs, error) {
+	if opts.Validate == OneToOne || opts.Validate == OneToMany {
+		if !uniqueKeys(keys.left) {
+			return joinPairs{}, errors.New("the keys of the left side are not unique")
+		}
+	}
+	if opts.Validate == OneToOne || opts.Validate == ManyToOne {
+		if !uniqueKeys(keys.right) {
+			return joinPairs{}, errors.New("the keys of the right side are not unique")
+		}
+	}
+
+	// a right join is a left join with the sides swapped
+	from, to, fromAt, toAt := keys.left, keys.right, keys.leftAt, keys.rightAt
+	if how == RightJoin {
+		from, to, fromAt, toAt = to, from, toAt, fromAt
+	}
+
+	var matches [][]int
+	switch opts.Algorithm {
+	case HashJoin:
+		matches = hashMatches(from, to)
+	case SortMergeJoin:
+		for _, v := range fromAt(0) {
+			if !orderedKey(v) {
+				return joinPairs{}, fmt.Errorf("cannot sort keys of type %T", v)
+			}
+		}
+		matches = sortMergeMatches(from, to, fromAt, toAt)
+	default:
+		return joinPairs{}, fmt.Errorf("unknown join algorithm %d", opts.Algorithm)
+	}
+
+	var pairs joinPairs
+	matched := make([]bool, len(to))
+	for f, m := range matches {
+		if len(m) == 0 && (how == LeftJoin || how == RightJoin || how == OuterJoin) {
+			pairs.left = append(pairs.left, f)
+			pairs.right = append(pairs.right, -1)
+		}
+		for _, t := range m {
+			pairs.left = append(pairs.left, f)
+			pairs.right = append(pairs.right, t)
+			matched[t] = true
+		}
+	}
+	if how == OuterJoin {
+		for t, ok := range matched {
+			if !ok {
+				pairs.left = append(pairs.left, -1)
+				pairs.right = append(pairs.right, t)
+			}
+		}
+	}
+
+	if how == RightJoin {
+		pairs.left, pairs.right = pairs.right, pairs.left
+	}
+	return pairs, nil
+}
+
+// uniqueKeys checks that no key is found twice, missing keys are ignored
+func uniqueKeys(ids []int) bool {
+	seen := make(map[int]bool, len(ids))
+	for _, id := range ids {
+		if id < 0 {
+			continue
+		}
+		if seen[id] {
+			return false
+		}
+		seen[id] = true
+	}
+	return true
+}
+
+// hashMatches returns for every row of from the rows of to with the same key in order
+func hashMatches(from, to []int) [][]int {
+	table := make(map[int][]int)
+	for t, id := range to {
+		if id >= 0 {
+			table[id] = append(table[id], t)
+		}
+	}
+
+	matches := make([][]int, len(from))
+	for f, id := range from {
+		if id >= 0 {
+			matches[f] = table[id]
+		}
+	}
+	return matches
+}
+
+// sortMergeMatches returns for every row of from the rows of to with the same key in order
+// both sides are sorted by their key values, equal keys form a group which is matched as a whole
+func sortMergeMatches(from, to []int, fromAt, toAt func(i int) []any) [][]int {
+	sorted := func(ids []int, at func(i int) []any) []int {
+		positions := sortedPositions(len(ids), func(i, j int) int {
+			return compareKeys(at(i), at(j))
+		})
+		return slices.DeleteFunc(positions, func(p int) bool { return ids[p] < 0 })
+	}
+	fromSorted, toSorted := sorted(from, fromAt), sorted(to, toAt)
+
+	// groupEnd returns the end of the group of equal keys starting at start
+	groupEnd := func(positions []int, start int, at func(i int) []any) int {
+		end := start + 1
+		for end < len(positions) && compareKeys(at(positions[start]), at(positions[end])) == 0 {
+			end++
+		}
+		return end
+	}
+
+	matches := make([][]int, len(from))
+	for i, j := 0, 0; i < len(fromSorted) && j < len(toSorted); {
+		switch c := compareKeys(fromAt(fromSorted[i]), toAt(toSorted[j])); {
+		case c < 0:
+			i++
+		case c > 0:
+			j++
+		default:
+			iEnd, jEnd := groupEnd(fromSorted, i, fromAt), groupEnd(toSorted, j, toAt)
+			for _, f := range fromSorted[i:iEnd] {
+				matches[f] = toSorted[j:jEnd]
+			}
+			i, j = iEnd, jEnd
+		}
+	}
+	return matches
+}
+
+// compareKeys compares two keys column by column
+func compareKeys(a, b []any) int {
+	for k := range a {
+		if c := compareKey(a[k], b[k]); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+// orderedKey checks if compareKey can compare values of the type of v
+func orderedKey(v any) bool {
+	switch v.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, uintptr,
+		float32, float64, string, bool, time.Time:
+		return true
+	}
+	return false
+}
+
+// compareKey compares two key values of the same type, false is less than true
+func compareKey(a, b any) int {
+	switch a := a.(type) {
+	case int:
+		return cmp.Compare(a, b.(int))
+	case int8:
+		return cmp.Compare(a, b.(int8))
+	case int16:
+		return cmp.Compare(a, b.(int16))
+	case int32:
+		return cmp.Compare(a, b.(int32))
+	case int64:
+		return cmp.Compare(a, b.(int64))
+	case uint:
+		return cmp.Compare(a, b.(uint))
+	case uint8:
+		return cmp.Compare(a, b.(uint8))
+	case uint16:
+		return cmp.Compare(a, b.(uint16))
+	case uint32:
+		return cmp.Compare(a, b.(uint32))
+	case uint64:
+		return cmp.Compare(a, b.(uint64))
+	case uintptr:
+		return cmp.Compare(a, b.(uintptr))
+	case float32:
+		return cmp.Compare(a, b.(float32))
+	case float64:
+		return cmp.Compare(a, b.(float64))
+	case string:
+		return cmp.Compare(a, b.(string))
+	case bool:
+		if a == b.(bool) {
+			return 0
+		}
+		if a {
+			return 1
+		}
+		return -1
+	case time.Time:
+		return a.Compare(b.(time.Time))
+	}
+	panic(fmt.Sprintf("cannot compare keys of type %T", a))
+}
@@ -0,0 +1,230 @@
+package series
+
+import (
+	"math"
+	"slices"
+	"testing"
+	"time"
+)
+
+func joinFixtures() (*DataFrame[int], *DataFrame[int]) {
+	left := NewDataFrame[int](
+		NewIndexSeries("id", []int{1, 2, 3, 2}),
+		NewIndexSeries("value", []float64{10, 20, 30, 21}),
+		ToCategorical(NewIndexSeries("team", []string{"red", "blue", "", "red"}), []string{"red", "blue"}, false),
+	)
+	right := NewDataFrame[int](
+		NewIndexSeries("id", []int{2, 4, 1}),
+		NewIndexSeries("value", []float64{200, 400, 100}),
+		NewIndexSeries("name", []string{"two", "four", "one"}),
+	)
+	return left, right
+}
+
+func TestMerge(t *testing.T) {
+	nan := math.NaN()
+	for _, algorithm := range []JoinAlgorithm{HashJoin, SortMergeJoin} {
+		for how, want := range map[JoinHow]struct {
+			ids    []int
+			left   []float64
+			right  []float64
+			merged []string
+		}{
+			InnerJoin: {[]int{1, 2, 2}, []float64{10, 20, 21}, []float64{100, 200, 200}, []string{"both", "both", "both"}},
+			LeftJoin:  {[]int{1, 2, 3, 2}, []float64{10, 20, 30, 21}, []float64{100, 200, nan, 200}, []string{"both", "both", "left_only", "both"}},
+			RightJoin: {[]int{2, 2, 4, 1}, []float64{20, 21, nan, 10}, []float64{200, 200, 400, 100}, []string{"both", "both", "right_only", "both"}},
+			OuterJoin: {[]int{1, 2, 3, 2, 4}, []float64{10, 20, 30, 21, nan}, []float64{100, 200, nan, 200, 400}, []string{"both", "both", "left_only", "both", "right_only"}},
+		} {
+			left, right := joinFixtures()
+			df, err := Merge(left, right, []string{"id"}, how, MergeOptions{Algorithm: algorithm, Indicator: true})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(df.Columns(), []string{"id", "value_x", "team", "value_y", "name", "_merge"}) {
+				t.Errorf("%d/%d: unexpected columns %v", algorithm, how, df.Columns())
+			}
+			if ids := GetColumn[int](df, "id").Values(); !slices.Equal(ids, want.ids) {
+				t.Errorf("%d/%d: expected ids %v, got %v", algorithm, how, want.ids, ids)
+			}
+			if v := GetColumn[float64](df, "value_x").Values(); !equalNaN(v, want.left) {
+				t.Errorf("%d/%d: expected left values %v, got %v", algorithm, how, want.left, v)
+			}
+			if v := GetColumn[float64](df, "value_y").Values(); !equalNaN(v, want.right) {
+				t.Errorf("%d/%d: expected right values %v, got %v", algorithm, how, want.right, v)
+			}
+			if v := df.Column("_merge").(*CategoricalSeries[int]).Values(); !slices.Equal(v, want.merged) {
+				t.Errorf("%d/%d: expected indicator %v, got %v", algorithm, how, want.merged, v)
+			}
+			if !slices.Equal(df.Index(), rangePositions(0, len(want.ids))) {
+				t.Errorf("%d/%d: unexpected index %v", algorithm, how, df.Index())
+			}
+		}
+	}
+
+	t.Run("composite and categorical keys", func(t *testing.T) {
+		left := NewDataFrame[int](
+			NewIndexSeries("a", []string{"x", "x", "y"}),
+			ToCategorical(NewIndexSeries("b", []string{"p", "q", ""}), []string{"p", "q"}, false),
+			NewIndexSeries("v", []int{1, 2, 3}),
+		)
+		right := NewDataFrame[int](
+			NewIndexSeries("a", []string{"x", "y", "x"}),
+			ToCategorical(NewIndexSeries("b", []string{"q", "", "r"}), []string{"r", "q"}, false),
+			NewIndexSeries("w", []int{20, 30, 40}),
+		)
+		for _, algorithm := range []JoinAlgorithm{HashJoin, SortMergeJoin} {
+			df, err := Merge(left, right, []string{"a", "b"}, OuterJoin, MergeOptions{Algorithm: algorithm, Suffixes: [2]string{"_l", "_r"}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if w := GetColumn[int](df, "w").Values(); !slices.Equal(w, []int{0, 20, 0, 30, 40}) {
+				t.Errorf("%d: unexpected w %v", algorithm, w)
+			}
+			b := df.Column("b").(*CategoricalSeries[int])
+			if !slices.Equal(b.Values(), []string{"p", "q", "", "", "r"}) || !slices.Equal(b.Categories(), []string{"p", "q", "r"}) {
+				t.Errorf("%d: unexpected b %v %v", algorithm, b.Values(), b.Categories())
+			}
+		}
+	})
+
+	t.Run("cross join", func(t *testing.T) {
+		left, right := joinFixtures()
+		df, err := Merge(left.Select("id"), right.Select("name"), nil, CrossJoin, MergeOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if df.Len() != 12 || GetColumn[int](df, "id").At(3) != 2 || GetColumn[string](df, "name").At(3) != "two" {
+			t.Errorf("unexpected result\n%v", df)
+		}
+	})
+
+	t.Run("time keys match as instants", func(t *testing.T) {
+		// time.Now has a monotonic clock reading and the local location, UTC drops both
+		now := time.Now()
+		left := NewDataFrame[int](NewIndexSeries("at", []time.Time{now}), NewIndexSeries("v", []int{1}))
+		right := NewDataFrame[int](NewIndexSeries("at", []time.Time{now.UTC()}), NewIndexSeries("w", []int{2}))
+		for _, algorithm := range []JoinAlgorithm{HashJoin, SortMergeJoin} {
+			df, err := Merge(left, right, []string{"at"}, InnerJoin, MergeOptions{Algorithm: algorithm})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if df.Len() != 1 || GetColumn[int](df, "w").At(0) != 2 {
+				t.Errorf("%d: expected one match, got\n%v", algorithm, df)
+			}
+
+			byLabel, err := Join(
+				NewDataFrame(Column[time.Time](NewSeries("v", []int{1}, []time.Time{now}))),
+				NewDataFrame(Column[time.Time](NewSeries("w", []int{2}, []time.Time{now.In(time.FixedZone("UTC+3", 3*60*60))}))),
+				InnerJoin, MergeOptions{Algorithm: algorithm},
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if byLabel.Len() != 1 {
+				t.Errorf("%d: expected one match by label, got\n%v", algorithm, byLabel)
+			}
+		}
+	})
+
+	t.Run("NaN keys never match", func(t *testing.T) {
+		left := NewDataFrame[int](NewIndexSeries("k", []float64{nan, 1}), NewIndexSeries("v", []int{1, 2}))
+		right := NewDataFrame[int](NewIndexSeries("k", []float64{nan, 1}), NewIndexSeries("w", []int{3, 4}))
+		df, err := Merge(left, right, []string{"k"}, InnerJoin, MergeOptions{Algorithm: SortMergeJoin})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if df.Len() != 1 || GetColumn[int](df, "w").At(0) != 4 {
+			t.Errorf("unexpected result\n%v", df)
+		}
+	})
+}
+
+func TestMerge_Errors(t *testing.T) {
+	left, right := joinFixtures()
+	other := NewDataFrame[int](NewIndexSeries("id", []string{"1"}))
+	for name, merge := range map[string]func() error{
+		"missing key": func() error {
+			_, err := Merge(left, right, []string{"team"}, InnerJoin, MergeOptions{})
+			return err
+		},
+		"no keys": func() error {
+			_, err := Merge(left, right, nil, InnerJoin, MergeOptions{})
+			return err
+		},
+		"keys for cross join": func() error {
+			_, err := Merge(left, right, []string{"id"}, CrossJoin, MergeOptions{})
+			return err
+		},
+		"different key types": func() error {
+			_, err := Merge(left, other, []string{"id"}, InnerJoin, MergeOptions{})
+			return err
+		},
+		"one to one": func() error {
+			_, err := Merge(left, right, []string{"id"}, InnerJoin, MergeOptions{Validate: OneToOne})
+			return err
+		},
+		"one to many": func() error {
+			_, err := Merge(left, right, []string{"id"}, InnerJoin, MergeOptions{Validate: OneToMany})
+			return err
+		},
+		"no rows": func() error {
+			_, err := Merge(left.Take([]int{2}), right, []string{"id"}, InnerJoin, MergeOptions{})
+			return err
+		},
+		"duplicate names": func() error {
+			_, err := Merge(left, right.AddColumn(NewIndexSeries("value_x", []int{0, 0, 0})), []string{"id"}, InnerJoin, MergeOptions{})
+			return err
+		},
+	} {
+		if merge() == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+
+	if _, err := Merge(left, right, []string{"id"}, InnerJoin, MergeOptions{Validate: ManyToOne}); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+func TestJoin(t *testing.T) {
+	left := NewDataFrame(
+		Column[string](NewSeries("x", []int{1, 2, 3}, []string{"a", "b", "c"})),
+		Column[string](NewSeries("y", []bool{true, false, true}, []string{"a", "b", "c"})),
+	)
+	right := NewDataFrame(
+		Column[string](NewSeries("x", []int{40, 20}, []string{"d", "b"})),
+	)
+	for _, algorithm := range []JoinAlgorithm{HashJoin, SortMergeJoin} {
+		df, err := Join(left, right, OuterJoin, MergeOptions{Algorithm: algorithm, Validate: OneToOne})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(df.Index(), []string{"a", "b", "c", "d"}) {
+			t.Errorf("%d: unexpected index %v", algorithm, df.Index())
+		}
+		if x := GetColumn[int](df, "x_x").Values(); !slices.Equal(x, []int{1, 2, 3, 0}) {
+			t.Errorf("%d: unexpected x_x %v", algorithm, x)
+		}
+		if x := GetColumn[int](df, "x_y").Values(); !slices.Equal(x, []int{0, 20, 0, 40}) {
+			t.Errorf("%d: unexpected x_y %v", algorithm, x)
+		}
+	}
+
+	df, err := Join(left, right, RightJoin, MergeOptions{Indicator: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(df.Index(), []string{"d", "b"}) || df.Column("_merge").(*CategoricalSeries[string]).At(0) != "right_only" {
+		t.Errorf("unexpected result\n%v", df)
+	}
+
+	if _, err := Join(left, right, CrossJoin, MergeOptions{}); err == nil {
+		t.Error("expected error for a cross join")
+	}
+}
+
+func equalNaN(a, b []float64) bool {
+	return slices.EqualFunc(a, b, func(x, y float64) bool {
+		return x == y || math.IsNaN(x) && math.IsNaN(y)
+	})
+}
@@ -0,0 +1,648 @@
+package series
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Expr is a lazily evaluated expression over the columns of a DataFrame
+// building it only records the operations, they run when the LazyFrame using it is collected
+// numeric expressions are computed in float64, comparisons, And, Or and Not give bools
+type Expr struct {
+	op    exprOp
+	name  string  // column of a Col, name of an Alias
+	value float64 // value of a Lit
+	args  []Expr
+}
+
+type exprOp int
+
+const (
+	opCol exprOp = iota
+	opLit
+	opAdd
+	opSub
+	opMul
+	opDiv
+	opPow
+	opAbs
+	opNeg
+	opEq
+	opNe
+	opLt
+	opLe
+	opGt
+	opGe
+	opAnd
+	opOr
+	opNot
+	opAlias
+	opFilter
+)
+
+// exprSymbols are the operators of binary expressions in String
+var exprSymbols = map[exprOp]string{
+	opAdd: "+", opSub: "-", opMul: "*", opDiv: "/", opPow: "**",
+	opEq: "==", opNe: "!=", opLt: "<", opLe: "<=", opGt: ">", opGe: ">=",
+	opAnd: "and", opOr: "or",
+}
+
+// Col refers to the column with the given name
+func Col(name string) Expr {
+	return Expr{op: opCol, name: name}
+}
+
+// Lit is a constant number
+func Lit(v float64) Expr {
+	return Expr{op: opLit, value: v}
+}
+
+func (e Expr) binary(op exprOp, other Expr) Expr {
+	return Expr{op: op, args: []Expr{e, other}}
+}
+
+// Add adds other element-wise
+func (e Expr) Add(other Expr) Expr { return e.binary(opAdd, other) }
+
+// Sub subtracts other element-wise
+func (e Expr) Sub(other Expr) Expr { return e.binary(opSub, other) }
+
+// Mul multiplies with other element-wise
+func (e Expr) Mul(other Expr) Expr { return e.binary(opMul, other) }
+
+// Div divides by other element-wise
+func (e Expr) Div(other Expr) Expr { return e.binary(opDiv, other) }
+
+// Pow raises to the power of other element-wise
+func (e Expr) Pow(other Expr) Expr { return e.binary(opPow, other) }
+
+// Abs returns the absolute values
+func (e Expr) Abs() Expr { return Expr{op: opAbs, args: []Expr{e}} }
+
+// Neg negates the values
+func (e Expr) Neg() Expr { return Expr{op: opNeg, args: []Expr{e}} }
+
+// Eq is true where the values equal the ones of other
+func (e Expr) Eq(other Expr) Expr { return e.binary(opEq, other) }
+
+// Ne is true where the values differ from the ones of other
+func (e Expr) Ne(other Expr) Expr { return e.binary(opNe, other) }
+
+// Lt is true where the values are less than the ones of other
+func (e Expr) Lt(other Expr) Expr { return e.binary(opLt, other) }
+
+// Le is true where the values are less than or equal to the ones of other
+func (e Expr) Le(other Expr) Expr { return e.binary(opLe, other) }
+
+// Gt is true where the values are greater than the ones of other
+func (e Expr) Gt(other Expr) Expr { return e.binary(opGt, other) }
+
+// Ge is true where the values are greater than or equal to the ones of other
+func (e Expr) Ge(other Expr) Expr { return e.binary(opGe, other) }
+
+// And is true where both bool expressions are true
+func (e Expr) And(other Expr) Expr { return e.binary(opAnd, other) }
+
+// Or is true where either bool expression is true
+func (e Expr) Or(other Expr) Expr { return e.binary(opOr, other) }
+
+// Not inverts a bool expression
+func (e Expr) Not() Expr { return Expr{op: opNot, args: []Expr{e}} }
+
+// Alias names the column the expression produces
+// without it the column is named after the leftmost column the expression uses
+func (e Expr) Alias(name string) Expr {
+	return Expr{op: opAlias, name: name, args: []Expr{e}}
+}
+
+// Filter keeps only the rows where the bool expression predicate is true
+// it must be the outermost operation besides Alias and all expressions of a Select must share it
+func (e Expr) Filter(predicate Expr) Expr {
+	return Expr{op: opFilter, args: []Expr{e, predicate}}
+}
+
+// String returns the expression in a readable form
+func (e Expr) String() string {
+	switch e.op {
+	case opCol:
+		return e.name
+	case opLit:
+		return strconv.FormatFloat(e.value, 'g', -1, 64)
+	case opAbs:
+		return fmt.Sprintf("abs(%v)", e.args[0])
+	case opNeg:
+		return fmt.Sprintf("-%v", e.args[0])
+	case opNot:
+		return fmt.Sprintf("not %v", e.args[0])
+	case opAlias:
+		return fmt.Sprintf("%v as %s", e.args[0], e.name)
+	case opFilter:
+		return fmt.Sprintf("%v where %v", e.args[0], e.args[1])
+	}
+	return fmt.Sprintf("(%v %s %v)", e.args[0], exprSymbols[e.op], e.args[1])
+}
+
+// outputName returns the alias or the leftmost column of the expression
+func (e Expr) outputName() string {
+	if e.op == opCol || e.op == opAlias {
+		return e.name
+	}
+	for _, arg := range e.args {
+		if name := arg.outputName(); name != "" {
+			return name
+		}
+	}
+	return ""
+}
+
+// isArithmetic returns if the operation computes a number from numbers
+func (op exprOp) isArithmetic() bool {
+	return op >= opAdd && op <= opNeg
+}
+
+// exprKind is the type of the values of an expression
+type exprKind int
+
+const (
+	kindOther exprKind = iota
+	kindNumber
+	kindBool
+)
+
+func (k exprKind) String() string {
+	return [...]string{"unsupported", "number", "bool"}[k]
+}
+
+// LazyFrame records operations on a DataFrame and runs them when it is collected
+// the plan is optimized first: filters run before any projection and only on the columns they need,
+// element-wise operations are fused so every output column is computed in a single pass
+// without intermediate Series and operations on constants are computed once
+type LazyFrame[R comparable] struct {
+	df    *DataFrame[R]
+	steps []lazyStep
+}
+
+// lazyStep is a Filter, Select or WithColumns recorded by a LazyFrame
+type lazyStep struct {
+	filter *Expr
+	exprs  []Expr
+	keep   bool // WithColumns keeps the other columns
+}
+
+// Lazy returns a LazyFrame over the DataFrame
+func (df *DataFrame[R]) Lazy() *LazyFrame[R] {
+	return &LazyFrame[R]{df: df}
+}
+
+func (lf *LazyFrame[R]) with(step lazyStep) *LazyFrame[R] {
+	return &LazyFrame[R]{df: lf.df, steps: append(slices.Clip(lf.steps), step)}
+}
+
+// Filter keeps only the rows where the bool expression predicate is true
+func (lf *LazyFrame[R]) Filter(predicate Expr) *LazyFrame[R] {
+	return lf.with(lazyStep{filter: &predicate})
+}
+
+// Select replaces the columns by the given expressions
+func (lf *LazyFrame[R]) Select(exprs ...Expr) *LazyFrame[R] {
+	return lf.with(lazyStep{exprs: exprs})
+}
+
+// WithColumns adds the given expressions as columns, replacing columns of the same name
+func (lf *LazyFrame[R]) WithColumns(exprs ...Expr) *LazyFrame[R] {
+	return lf.with(lazyStep{exprs: exprs, keep: true})
+}
+
+// lazyPlan is an optimized LazyFrame in which every expression only refers to columns of the DataFrame
+type lazyPlan struct {
+	filters []Expr
+	names   []string
+	outputs []Expr
+}
+
+// optimize resolves the columns of every step to the expressions computing them
+// so filters can be moved in front of all projections
+func (lf *LazyFrame[R]) optimize() (lazyPlan, error) {
+	var plan lazyPlan
+	for _, c := range lf.df.columns {
+		plan.names = append(plan.names, c.Name())
+		plan.outputs = append(plan.outputs, Col(c.Name()))
+	}
+	kinds := lf.kinds()
+
+	for _, step := range lf.steps {
+		current := make(map[string]Expr, len(plan.names))
+		for i, name := range plan.names {
+			current[name] = plan.outputs[i]
+		}
+
+		if step.filter != nil {
+			predicate, err := resolve(*step.filter, current, kinds, kindBool)
+			if err != nil {
+				return plan, fmt.Errorf("filter %v: %w", *step.filter, err)
+			}
+			plan.filters = append(plan.filters, predicate)
+			continue
+		}
+
+		var names []string
+		var outputs []Expr
+		if step.keep {
+			names, outputs = slices.Clone(plan.names), slices.Clone(plan.outputs)
+		}
+		var filter *Expr
+		if n := len(slices.DeleteFunc(slices.Clone(step.exprs), isFiltered)); n != 0 && n != len(step.exprs) {
+			return plan, errors.New("either all or none of the expressions of a Select must be filtered")
+		}
+		for i, e := range step.exprs {
+			name := e.outputName()
+			if e.op == opAlias {
+				e = e.args[0]
+			}
+			if e.op == opFilter {
+				if step.keep {
+					return plan, fmt.Errorf("%v: cannot filter in WithColumns", step.exprs[i])
+				}
+				if filter == nil {
+					filter = &e.args[1]
+				} else if filter.String() != e.args[1].String() {
+					return plan, fmt.Errorf("%v: all expressions of a Select must have the same filter", step.exprs[i])
+				}
+				e = e.args[0]
+			}
+
+			resolved, err := resolve(e, current, kinds, kindOther)
+			if err != nil {
+				return plan, fmt.Errorf("%v: %w", step.exprs[i], err)
+			}
+			if name == "" {
+				name = "literal"
+			}
+			if pos := slices.Index(names, name); pos >= 0 && step.keep {
+				outputs[pos] = resolved
+				continue
+			} else if pos >= 0 {
+				return plan, fmt.Errorf("duplicate column name %q", name)
+			}
+			names = append(names, name)
+			outputs = append(outputs, resolved)
+		}
+		if len(names) == 0 {
+			return plan, errors.New("cannot select no columns")
+		}
+
+		if filter != nil {
+			predicate, err := resolve(*filter, current, kinds, kindBool)
+			if err != nil {
+				return plan, fmt.Errorf("filter %v: %w", *filter, err)
+			}
+			plan.filters = append(plan.filters, predicate)
+		}
+		plan.names, plan.outputs = names, outputs
+	}
+	return plan, nil
+}
+
+// isFiltered returns if the expression is filtered
+func isFiltered(e Expr) bool {
+	if e.op == opAlias {
+		e = e.args[0]
+	}
+	return e.op == opFilter
+}
+
+// resolve replaces the columns of the expression by the expressions computing them, checks the kinds
+// of the values and computes operations on constants
+// want is the kind the expression must have, kindOther allows any
+func resolve(e Expr, columns map[string]Expr, kinds map[string]exprKind, want exprKind) (Expr, error) {
+	resolved, err := resolveExpr(e, columns)
+	if err != nil {
+		return resolved, err
+	}
+	kind, err := resolved.kind(kinds)
+	if err != nil {
+		return resolved, err
+	}
+	if want != kindOther && kind != want {
+		return resolved, fmt.Errorf("expected %v but got %v", want, kind)
+	}
+	return resolved, nil
+}
+
+func resolveExpr(e Expr, columns map[string]Expr) (Expr, error) {
+	switch e.op {
+	case opCol:
+		c, ok := columns[e.name]
+		if !ok {
+			return e, fmt.Errorf("no column %q", e.name)
+		}
+		return c, nil
+	case opLit:
+		return e, nil
+	case opAlias, opFilter:
+		return e, errors.New("Alias and Filter must be the outermost operations")
+	}
+
+	args := make([]Expr, len(e.args))
+	constant := true
+	for i, arg := range e.args {
+		var err error
+		if args[i], err = resolveExpr(arg, columns); err != nil {
+			return e, err
+		}
+		constant = constant && args[i].op == opLit
+	}
+	e = Expr{op: e.op, args: args}
+	if constant && e.op.isArithmetic() {
+		return Lit(e.compileNumber(nil)(0)), nil
+	}
+	return e, nil
+}
+
+// kind returns the kind of the values of a resolved expression
+func (e Expr) kind(columns map[string]exprKind) (exprKind, error) {
+	switch e.op {
+	case opCol:
+		return columns[e.name], nil
+	case opLit:
+		return kindNumber, nil
+	}
+
+	want := kindNumber
+	if e.op == opAnd || e.op == opOr || e.op == opNot {
+		want = kindBool
+	}
+	for _, arg := range e.args {
+		kind, err := arg.kind(columns)
+		if err != nil {
+			return kind, err
+		}
+		if kind != want {
+			return kind, fmt.Errorf("%v is %v but must be %v", arg, kind, want)
+		}
+	}
+	if e.op.isArithmetic() {
+		return kindNumber, nil
+	}
+	return kindBool, nil
+}
+
+// columns appends the columns the expression uses which are not in names yet
+func (e Expr) columns(names []string) []string {
+	if e.op == opCol && !slices.Contains(names, e.name) {
+		return append(names, e.name)
+	}
+	for _, arg := range e.args {
+		names = arg.columns(names)
+	}
+	return names
+}
+
+// kinds returns the kinds of the columns of the DataFrame
+func (lf *LazyFrame[R]) kinds() map[string]exprKind {
+	kinds := make(map[string]exprKind, len(lf.df.columns))
+	for _, c := range lf.df.columns {
+		kinds[c.Name()] = columnKind(c)
+	}
+	return kinds
+}
+
+// columnKind returns the kind of the values of a column
+func columnKind[R comparable](c Column[R]) exprKind {
+	s, ok := c.(interface{ rawValues() any })
+	if !ok {
+		return kindOther
+	}
+	if _, ok := s.rawValues().([]bool); ok {
+		return kindBool
+	}
+	if _, ok := numberAt(s.rawValues()); ok {
+		return kindNumber
+	}
+	return kindOther
+}
+
+// lazyColumns looks up the values of the columns a compiled expression reads
+type lazyColumns interface {
+	number(name string) func(i int) float64
+	bool(name string) func(i int) bool
+}
+
+// compileNumber turns a numeric expression into a single function computing the value of a row
+func (e Expr) compileNumber(columns lazyColumns) func(i int) float64 {
+	if e.op == opCol {
+		return columns.number(e.name)
+	}
+	if e.op == opLit {
+		v := e.value
+		return func(int) float64 { return v }
+	}
+
+	a := e.args[0].compileNumber(columns)
+	switch e.op {
+	case opAbs:
+		return func(i int) float64 { return math.Abs(a(i)) }
+	case opNeg:
+		return func(i int) float64 { return -a(i) }
+	}
+
+	b := e.args[1].compileNumber(columns)
+	switch e.op {
+	case opAdd:
+		return func(i int) float64 { return a(i) + b(i) }
+	case opSub:
+		return func(i int) float64 { return a(i) - b(i) }
+	case opMul:
+		return func(i int) float64 { return a(i) * b(i) }
+	case opDiv:
+		return func(i int) float64 { return a(i) / b(i) }
+	case opPow:
+		return func(i int) float64 { return math.Pow(a(i), b(i)) }
+	}
+	panic(fmt.Sprintf("%v is not numeric", e))
+}
+
+// compileBool turns a bool expression into a single function computing the value of a row
+func (e Expr) compileBool(columns lazyColumns) func(i int) bool {
+	switch e.op {
+	case opCol:
+		return columns.bool(e.name)
+	case opNot:
+		a := e.args[0].compileBool(columns)
+		return func(i int) bool { return !a(i) }
+	case opAnd:
+		a, b := e.args[0].compileBool(columns), e.args[1].compileBool(columns)
+		return func(i int) bool { return a(i) && b(i) }
+	case opOr:
+		a, b := e.args[0].compileBool(columns), e.args[1].compileBool(columns)
+		return func(i int) bool { return a(i) || b(i) }
+	}
+
+	a, b := e.args[0].compileNumber(columns), e.args[1].compileNumber(columns)
+	switch e.op {
+	case opEq:
+		return func(i int) bool { return a(i) == b(i) }
+	case opNe:
+		return func(i int) bool { return a(i) != b(i) }
+	case opLt:
+		return func(i int) bool { return a(i) < b(i) }
+	case opLe:
+		return func(i int) bool { return a(i) <= b(i) }
+	case opGt:
+		return func(i int) bool { return a(i) > b(i) }
+	case opGe:
+		return func(i int) bool { return a(i) >= b(i) }
+	}
+	panic(fmt.Sprintf("%v is not a bool", e))
+}
+
+// frameColumns reads the columns of a DataFrame for compiled expressions
+type frameColumns[R comparable] struct {
+	df *DataFrame[R]
+}
+
+func (fc frameColumns[R]) number(name string) func(i int) float64 {
+	at, ok := numberAt(fc.df.Column(name).(interface{ rawValues() any }).rawValues())
+	if !ok {
+		panic(fmt.Sprintf("column %q is not numeric", name))
+	}
+	return at
+}
+
+func (fc frameColumns[R]) bool(name string) func(i int) bool {
+	v := fc.df.Column(name).(interface{ rawValues() any }).rawValues().([]bool)
+	return func(i int) bool { return v[i] }
+}
+
+// numberAt returns a function reading a slice of numbers as float64
+func numberAt(values any) (func(i int) float64, bool) {
+	switch v := values.(type) {
+	case []int:
+		return floatAt(v), true
+	case []int8:
+		return floatAt(v), true
+	case []int16:
+		return floatAt(v), true
+	case []int32:
+		return floatAt(v), true
+	case []int64:
+		return floatAt(v), true
+	case []uint:
+		return floatAt(v), true
+	case []uint8:
+		return floatAt(v), true
+	case []uint16:
+		return floatAt(v), true
+	case []uint32:
+		return floatAt(v), true
+	case []uint64:
+		return floatAt(v), true
+	case []float32:
+		return floatAt(v), true
+	case []float64:
+		return floatAt(v), true
+	}
+	return nil, false
+}
+
+// floatAt returns a function reading the values as float64
+func floatAt[S Numeric](values []S) func(i int) float64 {
+	return func(i int) float64 { return float64(values[i]) }
+}
+
+// Explain returns the optimized plan, read from the bottom up
+func (lf *LazyFrame[R]) Explain() (string, error) {
+	plan, err := lf.optimize()
+	if err != nil {
+		return "", err
+	}
+
+	var scan []string
+	for _, e := range append(slices.Clone(plan.filters), plan.outputs...) {
+		scan = e.columns(scan)
+	}
+	outputs := make([]string, len(plan.outputs))
+	for i, e := range plan.outputs {
+		outputs[i] = e.String()
+		if e.op != opCol || e.name != plan.names[i] {
+			outputs[i] += " as " + plan.names[i]
+		}
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "select [%s]\n", strings.Join(outputs, ", "))
+	indent := "  "
+	for _, f := range plan.filters {
+		fmt.Fprintf(&sb, "%sfilter %v\n", indent, f)
+		indent += "  "
+	}
+	fmt.Fprintf(&sb, "%sscan [%s]\n", indent, strings.Join(scan, ", "))
+	return sb.String(), nil
+}
+
+// Collect runs the optimized plan and returns the resulting DataFrame
+// columns which are selected unchanged keep their type, computed ones are float64 or bool
+func (lf *LazyFrame[R]) Collect() (*DataFrame[R], error) {
+	plan, err := lf.optimize()
+	if err != nil {
+		return nil, err
+	}
+	columns := frameColumns[R]{df: lf.df}
+
+	positions := rangePositions(0, lf.df.Len())
+	if len(plan.filters) > 0 {
+		predicates := make([]func(i int) bool, len(plan.filters))
+		for i, f := range plan.filters {
+			predicates[i] = f.compileBool(columns)
+		}
+		positions = slices.DeleteFunc(positions, func(p int) bool {
+			for _, keep := range predicates {
+				if !keep(p) {
+					return true
+				}
+			}
+			return false
+		})
+	}
+	if len(positions) == 0 {
+		return nil, errors.New("the filters removed all rows")
+	}
+
+	index := make([]R, len(positions))
+	for i, p := range positions {
+		index[i] = lf.df.index[p]
+	}
+	kinds := lf.kinds()
+
+	result := make([]Column[R], len(plan.outputs))
+	for i, e := range plan.outputs {
+		name := plan.names[i]
+		if e.op == opCol {
+			c := lf.df.Column(e.name).take(positions)
+			c.(interface{ SetName(string) }).SetName(name)
+			result[i] = c
+			continue
+		}
+
+		if kind, _ := e.kind(kinds); kind == kindBool {
+			compute := e.compileBool(columns)
+			values := make([]bool, len(positions))
+			for j, p := range positions {
+				values[j] = compute(p)
+			}
+			result[i] = NewSeries(name, values, index)
+			continue
+		}
+		compute := e.compileNumber(columns)
+		values := make([]float64, len(positions))
+		for j, p := range positions {
+			values[j] = compute(p)
+		}
+		result[i] = NewSeries(name, values, index)
+	}
+	return NewDataFrame(result...), nil
+}
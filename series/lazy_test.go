@@ -0,0 +1,154 @@
+package series
+
+import (
+	"math"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestLazyFrame_Collect(t *testing.T) {
+	t.Run("fuses element-wise operations", func(t *testing.T) {
+		index := []string{"a", "b", "c", "d"}
+		df, err := NewDataFrame(
+			Column[string](NewSeries("x", []int{1, -2, 3, 4}, index)),
+			Column[string](NewSeries("y", []float64{0.5, 1, math.NaN(), 2}, index)),
+			Column[string](NewSeries("ok", []bool{true, true, false, true}, index)),
+		).Lazy().Select(
+			Col("x").Add(Col("y")).Mul(Lit(2)).Alias("z"),
+			Col("x").Abs().Pow(Lit(2)).Sub(Lit(1)).Div(Lit(3)),
+			Col("x").Gt(Lit(0)).And(Col("ok")).Alias("positive"),
+		).Collect()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !slices.Equal(df.Columns(), []string{"z", "x", "positive"}) {
+			t.Errorf("unexpected columns %v", df.Columns())
+		}
+		z := GetColumn[float64](df, "z").Values()
+		if z[0] != 3 || z[1] != -2 || !math.IsNaN(z[2]) || z[3] != 12 {
+			t.Errorf("unexpected z %v", z)
+		}
+		if x := GetColumn[float64](df, "x").Values(); !slices.Equal(x, []float64{0, 1, 8.0 / 3, 5}) {
+			t.Errorf("unexpected x %v", x)
+		}
+		if p := GetColumn[bool](df, "positive").Values(); !slices.Equal(p, []bool{true, false, false, true}) {
+			t.Errorf("unexpected positive %v", p)
+		}
+	})
+
+	t.Run("filters rows", func(t *testing.T) {
+		index := []string{"a", "b", "c", "d"}
+		df, err := NewDataFrame(
+			Column[string](NewSeries("x", []int{1, -2, 3, 4}, index)),
+			Column[string](NewSeries("y", []float64{0.5, 1, math.NaN(), 2}, index)),
+			Column[string](NewSeries("name", []string{"p", "q", "r", "s"}, index)),
+		).Lazy().
+			Select(Col("x").Mul(Lit(10)).Filter(Col("y").Lt(Lit(2))).Alias("x10"), Col("name").Filter(Col("y").Lt(Lit(2)))).
+			Filter(Col("x10").Ne(Lit(10)).Or(Col("x10").Abs().Gt(Lit(100)))).
+			Collect()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(df.Index(), []string{"b"}) || GetColumn[float64](df, "x10").At(0) != -20 {
+			t.Errorf("unexpected result\n%v", df)
+		}
+		if GetColumn[string](df, "name").At(0) != "q" {
+			t.Error("expected unchanged columns to keep their type")
+		}
+	})
+
+	t.Run("adds and replaces columns", func(t *testing.T) {
+		index := []string{"a", "b", "c", "d"}
+		df, err := NewDataFrame(
+			Column[string](NewSeries("x", []int{1, -2, 3, 4}, index)),
+			Column[string](NewSeries("y", []float64{0.5, 1, math.NaN(), 2}, index)),
+			Column[string](NewSeries("ok", []bool{true, true, false, true}, index)),
+		).Lazy().
+			WithColumns(Col("x").Neg(), Col("x").Add(Col("y")).Alias("sum")).
+			Filter(Col("ok")).
+			Collect()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(df.Columns(), []string{"x", "y", "ok", "sum"}) {
+			t.Errorf("unexpected columns %v", df.Columns())
+		}
+		if !slices.Equal(GetColumn[float64](df, "x").Values(), []float64{-1, 2, -4}) {
+			t.Errorf("unexpected x %v", GetColumn[float64](df, "x").Values())
+		}
+		if !slices.Equal(GetColumn[float64](df, "sum").Values(), []float64{1.5, -1, 6}) {
+			t.Errorf("unexpected sum %v", GetColumn[float64](df, "sum").Values())
+		}
+	})
+
+	t.Run("does not change the DataFrame", func(t *testing.T) {
+		index := []string{"a", "b", "c", "d"}
+		df := NewDataFrame(
+			Column[string](NewSeries("x", []int{1, -2, 3, 4}, index)),
+			Column[string](NewSeries("y", []float64{0.5, 1, math.NaN(), 2}, index)),
+		)
+		base := df.Lazy().Filter(Col("x").Gt(Lit(0)))
+		if _, err := base.Select(Col("x")).Collect(); err != nil {
+			t.Fatal(err)
+		}
+		all, err := base.Collect()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if all.Len() != 3 || len(all.Columns()) != 2 || df.Len() != 4 {
+			t.Errorf("unexpected result\n%v", all)
+		}
+	})
+}
+
+func TestLazyFrame_Explain(t *testing.T) {
+	index := []string{"a", "b"}
+	plan, err := NewDataFrame(
+		Column[string](NewSeries("x", []int{1, 2}, index)),
+		Column[string](NewSeries("y", []float64{0.5, 1}, index)),
+		Column[string](NewSeries("name", []string{"p", "q"}, index)),
+	).Lazy().
+		Select(Col("x").Add(Col("y")).Mul(Lit(2).Add(Lit(1))).Alias("z"), Col("name")).
+		Filter(Col("z").Gt(Lit(0))).
+		Select(Col("z"), Col("name").Alias("label")).
+		Explain()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := strings.Join([]string{
+		"select [((x + y) * 3) as z, name as label]",
+		"  filter (((x + y) * 3) > 0)",
+		"    scan [x, y, name]",
+		"",
+	}, "\n")
+	if plan != want {
+		t.Errorf("expected plan\n%s\ngot\n%s", want, plan)
+	}
+}
+
+func TestLazyFrame_Errors(t *testing.T) {
+	index := []string{"a", "b"}
+	lf := NewDataFrame(
+		Column[string](NewSeries("x", []int{1, -2}, index)),
+		Column[string](NewSeries("y", []float64{0.5, 1}, index)),
+		Column[string](NewSeries("ok", []bool{true, false}, index)),
+		Column[string](NewSeries("name", []string{"p", "q"}, index)),
+	).Lazy()
+	for name, lf := range map[string]*LazyFrame[string]{
+		"missing column":      lf.Select(Col("missing")),
+		"arithmetic on text":  lf.Select(Col("name").Add(Lit(1))),
+		"number as predicate": lf.Filter(Col("x")),
+		"nested filter":       lf.Select(Col("x").Filter(Col("ok")).Add(Lit(1))),
+		"different filters":   lf.Select(Col("x").Filter(Col("ok")), Col("y").Filter(Col("ok").Not())),
+		"partly filtered":     lf.Select(Col("x"), Col("y").Filter(Col("ok"))),
+		"duplicate names":     lf.Select(Col("x"), Col("x").Abs()),
+		"all rows removed":    lf.Filter(Col("x").Gt(Lit(100))),
+		"nested alias":        lf.Select(Col("x").Alias("a").Add(Lit(1))),
+	} {
+		if _, err := lf.Collect(); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
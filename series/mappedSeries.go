@@ -0,0 +1,386 @@
+package series
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"iter"
+	"math"
+	"os"
+	"time"
+)
+
+// MappedNumericSeries is a NumericSeries in the native binary format which is memory mapped from a file
+// values and labels are decoded from the mapping when they are read instead of being copied into slices,
+// so the file can be larger than the available memory
+// values of any numeric type are converted to T, labels must be stored as R
+// it must be closed to release the mapping, using it after Close panics
+type MappedNumericSeries[T Numeric, R comparable] struct {
+	mapping *mapping
+	name    string
+	// start is the position of the first value in the buffers and length the number of values
+	start  int
+	length int
+
+	checksum bool
+	end      int // end of the Series in the mapping where its checksum starts
+
+	values    int // offset of the values buffer in the mapping
+	valueSize int
+	value     func(b []byte) T
+
+	index int // offset of the index buffer in the mapping
+	label func(index []byte, i int) any
+}
+
+// mapping is a file mapped into memory, it is shared by a MappedNumericSeries and its slices
+type mapping struct {
+	data []byte
+}
+
+// bytes returns the mapped data and panics if it was unmapped
+func (m *mapping) bytes() []byte {
+	if m.data == nil {
+		panic("use of closed MappedNumericSeries")
+	}
+	return m.data
+}
+
+// OpenMappedNumericSeries maps the file at path which holds a Series in the native binary format
+// the checksum is not verified when opening as that would read the whole file, use Verify for that
+func OpenMappedNumericSeries[T Numeric, R comparable](path string) (*MappedNumericSeries[T, R], error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if info.Size() == 0 || info.Size() > math.MaxInt {
+		return nil, fmt.Errorf("cannot map file of %d bytes", info.Size())
+	}
+	data, err := mmapFile(f, int(info.Size()))
+	if err != nil {
+		return nil, fmt.Errorf("mapping %s: %w", path, err)
+	}
+
+	ms, err := newMappedNumericSeries[T, R](data)
+	if err != nil {
+		_ = munmapFile(data)
+		return nil, fmt.Errorf("mapping %s: %w", path, err)
+	}
+	return ms, nil
+}
+
+// newMappedNumericSeries decodes the header and checks the buffers of a Series in the mapped data
+func newMappedNumericSeries[T Numeric, R comparable](data []byte) (*MappedNumericSeries[T, R], error) {
+	br := &binaryReader{data: data}
+	header, buffers, err := decodeBinary(br)
+	if err != nil {
+		return nil, err
+	}
+	if header.length == 0 {
+		return nil, errors.New("cannot read Series with no data")
+	}
+
+	size, value, ok := mappedNumeric[T](header.valueType)
+	if !ok {
+		return nil, fmt.Errorf("cannot map values of type %s", header.valueType)
+	}
+	if header.length > uint64(math.MaxInt/size) || uint64(len(buffers[0])) != header.length*uint64(size) {
+		return nil, errors.New("values: buffer size does not match the length")
+	}
+	n := int(header.length)
+
+	label, err := mappedLabel(header.indexType, buffers[1], n)
+	if err != nil {
+		return nil, fmt.Errorf("index: %w", err)
+	}
+	if _, ok := label(buffers[1], 0).(R); !ok {
+		var zero R
+		return nil, fmt.Errorf("cannot read labels of type %s into %T", header.indexType, zero)
+	}
+
+	// the buffers are sub slices of data, so their capacity tells where they start
+	return &MappedNumericSeries[T, R]{
+		mapping:   &mapping{data: data},
+		name:      header.name,
+		length:    n,
+		checksum:  header.flags&binaryChecksum != 0,
+		end:       len(data) - len(br.data) - 4,
+		values:    cap(data) - cap(buffers[0]),
+		valueSize: size,
+		value:     value,
+		index:     cap(data) - cap(buffers[1]),
+		label:     label,
+	}, nil
+}
+
+// mappedNumeric returns the size of a value of the named numeric type and a decoder converting it to T
+func mappedNumeric[T Numeric](typeName string) (int, func(b []byte) T, bool) {
+	le := binary.LittleEndian
+	switch typeName {
+	case "int", "int64":
+		return 8, func(b []byte) T { return T(int64(le.Uint64(b))) }, true
+	case "uint", "uint64":
+		return 8, func(b []byte) T { return T(le.Uint64(b)) }, true
+	case "int8":
+		return 1, func(b []byte) T { return T(int8(b[0])) }, true
+	case "uint8":
+		return 1, func(b []byte) T { return T(b[0]) }, true
+	case "int16":
+		return 2, func(b []byte) T { return T(int16(le.Uint16(b))) }, true
+	case "uint16":
+		return 2, func(b []byte) T { return T(le.Uint16(b)) }, true
+	case "int32":
+		return 4, func(b []byte) T { return T(int32(le.Uint32(b))) }, true
+	case "uint32":
+		return 4, func(b []byte) T { return T(le.Uint32(b)) }, true
+	case "float32":
+		return 4, func(b []byte) T { return T(math.Float32frombits(le.Uint32(b))) }, true
+	case "float64":
+		return 8, func(b []byte) T { return T(math.Float64frombits(le.Uint64(b))) }, true
+	}
+	return 0, nil, false
+}
+
+// mappedLabel checks the index buffer of n labels of the named type and returns a decoder of the label at i
+// the labels keep their stored type, int and uint are decoded as int and uint
+func mappedLabel(typeName string, buf []byte, n int) (func(index []byte, i int) any, error) {
+	var size int
+	var decode func(b []byte) any
+	switch typeName {
+	case "int":
+		size, decode = mappedFixed[int](typeName)
+	case "uint":
+		size, decode = mappedFixed[uint](typeName)
+	case "int8":
+		size, decode = mappedFixed[int8](typeName)
+	case "int16":
+		size, decode = mappedFixed[int16](typeName)
+	case "int32":
+		size, decode = mappedFixed[int32](typeName)
+	case "int64":
+		size, decode = mappedFixed[int64](typeName)
+	case "uint8":
+		size, decode = mappedFixed[uint8](typeName)
+	case "uint16":
+		size, decode = mappedFixed[uint16](typeName)
+	case "uint32":
+		size, decode = mappedFixed[uint32](typeName)
+	case "uint64":
+		size, decode = mappedFixed[uint64](typeName)
+	case "float32":
+		size, decode = mappedFixed[float32](typeName)
+	case "float64":
+		size, decode = mappedFixed[float64](typeName)
+	case "bool":
+		size, decode = 1, func(b []byte) any { return b[0] != 0 }
+	case "time.Time":
+		size, decode = 12, func(b []byte) any {
+			sec := int64(binary.LittleEndian.Uint64(b))
+			nsec := int64(binary.LittleEndian.Uint32(b[8:]))
+			return time.Unix(sec, nsec).UTC()
+		}
+	case "string":
+		return mappedStrings(buf, n)
+	default:
+		return nil, fmt.Errorf("unsupported value type %q", typeName)
+	}
+
+	if len(buf)%size != 0 || len(buf)/size != n {
+		return nil, errors.New("buffer size does not match the length")
+	}
+	return func(index []byte, i int) any { return decode(index[size*i:]) }, nil
+}
+
+// mappedFixed returns the size and a decoder of the named numeric type which is stored as S
+func mappedFixed[S Numeric](typeName string) (int, func(b []byte) any) {
+	size, decode, _ := mappedNumeric[S](typeName)
+	return size, func(b []byte) any { return decode(b) }
+}
+
+// mappedStrings checks the offsets of n strings once, so decoding a label cannot go out of bounds
+func mappedStrings(buf []byte, n int) (func(index []byte, i int) any, error) {
+	if uint64(len(buf))/8 < uint64(n)+1 {
+		return nil, errors.New("buffer is too short")
+	}
+	offset := func(index []byte, i int) uint64 { return binary.LittleEndian.Uint64(index[8*i:]) }
+	strs := 8 * (n + 1)
+	for i := range n {
+		if offset(buf, i) > offset(buf, i+1) || offset(buf, i+1) > uint64(len(buf)-strs) {
+			return nil, errors.New("string offsets are out of bounds")
+		}
+	}
+	return func(index []byte, i int) any {
+		return string(index[strs+int(offset(index, i)) : strs+int(offset(index, i+1))])
+	}, nil
+}
+
+// Len returns the number of values
+func (ms *MappedNumericSeries[T, R]) Len() int {
+	return ms.length
+}
+
+// Name return the name of the MappedNumericSeries
+func (ms *MappedNumericSeries[T, R]) Name() string {
+	return ms.name
+}
+
+// at returns the value at position i without checking the bounds
+func (ms *MappedNumericSeries[T, R]) at(data []byte, i int) T {
+	return ms.value(data[ms.values+ms.valueSize*(ms.start+i):])
+}
+
+// labelAt returns the label at position i without checking the bounds
+func (ms *MappedNumericSeries[T, R]) labelAt(data []byte, i int) R {
+	return ms.label(data[ms.index:], ms.start+i).(R)
+}
+
+// At returns the value at the given index
+func (ms *MappedNumericSeries[T, R]) At(i int) T {
+	if i < 0 || i >= ms.length {
+		panic(fmt.Sprintf("index %d out of bounds", i))
+	}
+	return ms.at(ms.mapping.bytes(), i)
+}
+
+// AtIndex returns the label and value at the given index
+func (ms *MappedNumericSeries[T, R]) AtIndex(i int) (R, T) {
+	if i < 0 || i >= ms.length {
+		panic(fmt.Sprintf("index %d out of bounds", i))
+	}
+	data := ms.mapping.bytes()
+	return ms.labelAt(data, i), ms.at(data, i)
+}
+
+// Get returns the value of the first label matching the given label
+func (ms *MappedNumericSeries[T, R]) Get(label R) T {
+	data := ms.mapping.bytes()
+	for i := range ms.length {
+		if ms.labelAt(data, i) == label {
+			return ms.at(data, i)
+		}
+	}
+	panic(fmt.Sprintf("no value found for label %v", label))
+}
+
+// Sum returns the sum of the values
+func (ms *MappedNumericSeries[T, R]) Sum() T {
+	data := ms.mapping.bytes()
+	var sum T
+	for i := range ms.length {
+		sum += ms.at(data, i)
+	}
+	return sum
+}
+
+// Mean returns the mean of the values
+func (ms *MappedNumericSeries[T, R]) Mean() float64 {
+	return float64(ms.Sum()) / float64(ms.Len())
+}
+
+// Min returns the smallest value
+func (ms *MappedNumericSeries[T, R]) Min() T {
+	data := ms.mapping.bytes()
+	minValue := ms.at(data, 0)
+	for i := 1; i < ms.length; i++ {
+		if v := ms.at(data, i); v < minValue {
+			minValue = v
+		}
+	}
+	return minValue
+}
+
+// Max returns the largest value
+func (ms *MappedNumericSeries[T, R]) Max() T {
+	data := ms.mapping.bytes()
+	maxValue := ms.at(data, 0)
+	for i := 1; i < ms.length; i++ {
+		if v := ms.at(data, i); v > maxValue {
+			maxValue = v
+		}
+	}
+	return maxValue
+}
+
+// Head copies the first n values into a NumericSeries
+func (ms *MappedNumericSeries[T, R]) Head(n int) *NumericSeries[T, R] {
+	return ms.load(0, min(max(n, 0), ms.length))
+}
+
+// Tail copies the last n values into a NumericSeries
+func (ms *MappedNumericSeries[T, R]) Tail(n int) *NumericSeries[T, R] {
+	return ms.load(ms.length-min(max(n, 0), ms.length), ms.length)
+}
+
+// Slice returns the values from start up to but excluding end without copying them
+// the slice shares the mapping, closing either of them closes both
+func (ms *MappedNumericSeries[T, R]) Slice(start, end int) *MappedNumericSeries[T, R] {
+	if start < 0 || end > ms.length || start >= end {
+		panic(fmt.Sprintf("invalid slice [%d:%d] of length %d", start, end, ms.length))
+	}
+	slice := *ms
+	slice.start += start
+	slice.length = end - start
+	return &slice
+}
+
+// Chunks copies the values into NumericSeries of up to size values one after another
+// only one chunk has to fit into memory at a time
+func (ms *MappedNumericSeries[T, R]) Chunks(size int) iter.Seq[*NumericSeries[T, R]] {
+	if size <= 0 {
+		panic("chunk size must be positive")
+	}
+	return func(yield func(*NumericSeries[T, R]) bool) {
+		for start := 0; start < ms.length; start += size {
+			if !yield(ms.load(start, min(start+size, ms.length))) {
+				return
+			}
+		}
+	}
+}
+
+// Load copies all values into a NumericSeries
+func (ms *MappedNumericSeries[T, R]) Load() *NumericSeries[T, R] {
+	return ms.load(0, ms.length)
+}
+
+// load copies the values from start up to but excluding end into a NumericSeries
+func (ms *MappedNumericSeries[T, R]) load(start, end int) *NumericSeries[T, R] {
+	data := ms.mapping.bytes()
+	values := make([]T, end-start)
+	index := make([]R, end-start)
+	for i := range values {
+		values[i] = ms.at(data, start+i)
+		index[i] = ms.labelAt(data, start+i)
+	}
+	return NewNumericSeries(ms.name, values, index)
+}
+
+// Verify reads the whole file and checks its checksum, it returns nil if the file has no checksum
+func (ms *MappedNumericSeries[T, R]) Verify() error {
+	data := ms.mapping.bytes()
+	if !ms.checksum {
+		return nil
+	}
+	if crc32.Checksum(data[:ms.end], castagnoli) != binary.LittleEndian.Uint32(data[ms.end:]) {
+		return errors.New("binary checksum mismatch")
+	}
+	return nil
+}
+
+// Close releases the mapping of the MappedNumericSeries and all of its slices
+// closing it again does nothing
+func (ms *MappedNumericSeries[T, R]) Close() error {
+	if ms.mapping.data == nil {
+		return nil
+	}
+	data := ms.mapping.data
+	ms.mapping.data = nil
+	return munmapFile(data)
+}
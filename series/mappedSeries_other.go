@@ -0,0 +1,22 @@
+//go:build !unix
+
+package series
+
+import (
+	"io"
+	"os"
+)
+
+// mmapFile reads the first size bytes of the file on systems without mmap support
+func mmapFile(f *os.File, size int) ([]byte, error) {
+	data := make([]byte, size)
+	if _, err := io.ReadFull(f, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// munmapFile releases a mapping of mmapFile
+func munmapFile(data []byte) error {
+	return nil
+}
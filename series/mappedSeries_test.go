@@ -0,0 +1,209 @@
+package series
+
+import (
+	"bytes"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+// writeMapped writes the Series to a temporary file and returns its path
+func writeMapped[T comparable, R comparable](t *testing.T, s *Series[T, R], opts BinaryOptions) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if _, err := WriteSeries(&buf, s, opts); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "series.pngo")
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func openMapped[T Numeric, R comparable](t *testing.T, path string) *MappedNumericSeries[T, R] {
+	t.Helper()
+	ms, err := OpenMappedNumericSeries[T, R](path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ms.Close() })
+	return ms
+}
+
+func TestMappedNumericSeries_Read(t *testing.T) {
+	values := make([]float64, 1000)
+	index := make([]string, 1000)
+	for i := range values {
+		values[i] = float64(i)
+		index[i] = string(rune('a' + i%26))
+	}
+	index[999] = "last"
+	ms := openMapped[float64, string](t, writeMapped(t, NewSeries("x", values, index), BinaryOptions{Checksum: true}))
+
+	if ms.Name() != "x" || ms.Len() != 1000 {
+		t.Errorf("unexpected name %q or length %d", ms.Name(), ms.Len())
+	}
+	if ms.Sum() != 499500 || ms.Mean() != 499.5 || ms.Min() != 0 || ms.Max() != 999 {
+		t.Errorf("unexpected aggregates %v %v %v %v", ms.Sum(), ms.Mean(), ms.Min(), ms.Max())
+	}
+	if ms.At(7) != 7 || ms.Get("last") != 999 {
+		t.Error("unexpected values")
+	}
+	if label, value := ms.AtIndex(27); label != "b" || value != 27 {
+		t.Errorf("expected b and 27, got %v and %v", label, value)
+	}
+
+	head := ms.Head(3)
+	if !slices.Equal(head.Values(), []float64{0, 1, 2}) || !slices.Equal(head.Index(), []string{"a", "b", "c"}) {
+		t.Errorf("unexpected head %v", head)
+	}
+	if tail := ms.Tail(2); !slices.Equal(tail.Index(), []string{"k", "last"}) {
+		t.Errorf("unexpected tail %v", tail)
+	}
+	if ms.Head(5000).Len() != 1000 || ms.Tail(5000).Len() != 1000 {
+		t.Error("expected head and tail to be capped by the length")
+	}
+
+	if err := ms.Verify(); err != nil {
+		t.Error(err)
+	}
+	if !slices.Equal(ms.Load().Values(), values) {
+		t.Error("expected loaded values to match")
+	}
+}
+
+func TestMappedNumericSeries_Slice(t *testing.T) {
+	ms := openMapped[int, int](t, writeMapped(t, NewIndexSeries("n", []int{5, 3, 8, 1, 9, 2}), BinaryOptions{}))
+
+	slice := ms.Slice(1, 5)
+	if slice.Len() != 4 || slice.At(0) != 3 || slice.Sum() != 21 || slice.Min() != 1 || slice.Max() != 9 {
+		t.Errorf("unexpected slice %v", slice.Load())
+	}
+	if !slices.Equal(slice.Load().Index(), []int{1, 2, 3, 4}) {
+		t.Errorf("expected the labels of the slice, got %v", slice.Load().Index())
+	}
+	if nested := slice.Slice(2, 4); !slices.Equal(nested.Load().Values(), []int{1, 9}) {
+		t.Errorf("unexpected nested slice %v", nested.Load())
+	}
+
+	for _, bounds := range [][2]int{{-1, 2}, {2, 2}, {0, 7}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("expected panic for slice %v", bounds)
+				}
+			}()
+			ms.Slice(bounds[0], bounds[1])
+		}()
+	}
+}
+
+func TestMappedNumericSeries_Chunks(t *testing.T) {
+	times := []time.Time{time.Unix(0, 0).UTC(), time.Unix(1, 0).UTC(), time.Unix(2, 0).UTC(), time.Unix(3, 0).UTC(), time.Unix(4, 0).UTC()}
+	ms := openMapped[float64, time.Time](t, writeMapped(t, NewSeries("t", []int32{1, 2, 3, 4, 5}, times), BinaryOptions{}))
+
+	var lengths []int
+	sum := 0.0
+	for chunk := range ms.Chunks(2) {
+		lengths = append(lengths, chunk.Len())
+		sum += chunk.Sum()
+	}
+	if !slices.Equal(lengths, []int{2, 2, 1}) || sum != 15 {
+		t.Errorf("unexpected chunks %v with sum %v", lengths, sum)
+	}
+
+	for chunk := range ms.Chunks(10) {
+		if !slices.Equal(chunk.Index(), times) {
+			t.Errorf("unexpected labels %v", chunk.Index())
+		}
+		break
+	}
+}
+
+func TestMappedNumericSeries_Close(t *testing.T) {
+	ms, err := OpenMappedNumericSeries[float64, string](writeMapped(t, fixtureSeries(), BinaryOptions{Checksum: true}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	slice := ms.Slice(0, 2)
+	if err := ms.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := ms.Close(); err != nil {
+		t.Errorf("expected closing again to do nothing, got %v", err)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic reading a closed slice")
+		}
+	}()
+	slice.At(0)
+}
+
+func TestMappedNumericSeries_Errors(t *testing.T) {
+	t.Run("missing file", func(t *testing.T) {
+		if _, err := OpenMappedNumericSeries[int, int](filepath.Join(t.TempDir(), "missing")); err == nil {
+			t.Error("expected error for a missing file")
+		}
+	})
+
+	t.Run("non numeric values", func(t *testing.T) {
+		path := writeMapped(t, NewIndexSeries("s", []string{"a"}), BinaryOptions{})
+		if _, err := OpenMappedNumericSeries[int, int](path); err == nil {
+			t.Error("expected error for string values")
+		}
+	})
+
+	t.Run("wrong label type", func(t *testing.T) {
+		path := writeMapped(t, NewIndexSeries("n", []int{1}), BinaryOptions{})
+		if _, err := OpenMappedNumericSeries[int, int64](path); err == nil {
+			t.Error("expected error reading int labels as int64")
+		}
+	})
+
+	t.Run("checksum mismatch", func(t *testing.T) {
+		path := writeMapped(t, NewIndexSeries("n", []float64{1, 2, 3}), BinaryOptions{Checksum: true})
+		data, _ := os.ReadFile(path)
+		data[len(data)-10] ^= 1
+		os.WriteFile(path, data, 0o644)
+
+		ms := openMapped[float64, int](t, path)
+		if err := ms.Verify(); err == nil {
+			t.Error("expected checksum error")
+		}
+	})
+
+	t.Run("corrupted files never panic", func(t *testing.T) {
+		var buf bytes.Buffer
+		if _, err := fixtureSeries().WriteTo(&buf); err != nil {
+			t.Fatal(err)
+		}
+		data := buf.Bytes()
+		path := filepath.Join(t.TempDir(), "corrupt.pngo")
+		for i := range data {
+			corrupted := slices.Clone(data)
+			corrupted[i] ^= 0xA5
+			for _, b := range [][]byte{corrupted, data[:i]} {
+				if err := os.WriteFile(path, b, 0o644); err != nil {
+					t.Fatal(err)
+				}
+				if ms, err := OpenMappedNumericSeries[float64, string](path); err == nil {
+					ms.Load()
+					ms.Close()
+				}
+			}
+		}
+	})
+
+	t.Run("values of other numeric types", func(t *testing.T) {
+		ms := openMapped[float64, int](t, writeMapped(t, NewIndexSeries("n", []int8{-1, 2}), BinaryOptions{}))
+		if !slices.Equal(ms.Load().Values(), []float64{-1, 2}) || math.IsNaN(ms.Mean()) {
+			t.Errorf("unexpected values %v", ms.Load())
+		}
+	})
+}
@@ -0,0 +1,18 @@
+//go:build unix
+
+package series
+
+import (
+	"os"
+	"syscall"
+)
+
+// mmapFile maps the first size bytes of the file read only
+func mmapFile(f *os.File, size int) ([]byte, error) {
+	return syscall.Mmap(int(f.Fd()), 0, size, syscall.PROT_READ, syscall.MAP_SHARED)
+}
+
+// munmapFile releases a mapping of mmapFile
+func munmapFile(data []byte) error {
+	return syscall.Munmap(data)
+}
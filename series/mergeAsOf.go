@@ -0,0 +1,283 @@
+package series
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"time"
+)
+
+// AsOfDirection decides which right row MergeAsOf matches to a left row
+type AsOfDirection int
+
+const (
+	// Backward matches the last right row whose key is less than or equal to the left key
+	Backward AsOfDirection = iota
+	// Forward matches the first right row whose key is greater than or equal to the left key
+	Forward
+	// Nearest matches the right row whose key is closest to the left key, Backward wins ties
+	Nearest
+)
+
+// AsOfOptions are the options of MergeAsOf
+type AsOfOptions struct {
+	// By are columns which must be equal on both sides in addition to the nearest key
+	By []string
+	// Direction decides which right row is matched
+	Direction AsOfDirection
+	// HasTolerance limits the distance between the keys of matched rows to Tolerance or TimeTolerance,
+	// there is no limit without it
+	HasTolerance bool
+	// Tolerance is the largest distance between numeric keys of matched rows, 0 only matches equal keys
+	Tolerance float64
+	// TimeTolerance is the largest distance between time keys of matched rows, 0 only matches equal times
+	TimeTolerance time.Duration
+	// Suffixes are appended to the names of the left and right columns found on both sides
+	// "_x" and "_y" are used if both are empty
+	Suffixes [2]string
+}
+
+// MergeAsOf matches every row of left to the right row with the nearest key in the on column
+// the on column must hold numbers or times of the same type on both sides and be sorted in ascending order
+// the result has the rows, labels and columns of left followed by the right columns except on and By,
+// which are missing for the rows without a match
+// it walks both sides once, remembering the last right row of every By group
+func MergeAsOf[R comparable](left, right *DataFrame[R], on string, opts AsOfOptions) (*DataFrame[R], error) {
+	l, r := left.columnPos(on), right.columnPos(on)
+	if l < 0 || r < 0 {
+		return nil, fmt.Errorf("key column %q is not found on both sides", on)
+	}
+	leftOn, rightOn := left.columns[l], right.columns[r]
+	if reflect.TypeOf(leftOn) != reflect.TypeOf(rightOn) {
+		return nil, fmt.Errorf("key column %q has different types on both sides", on)
+	}
+	if opts.Tolerance < 0 || opts.TimeTolerance < 0 || math.IsNaN(opts.Tolerance) {
+		return nil, errors.New("tolerance must not be negative or NaN")
+	}
+	if !opts.HasTolerance && (opts.Tolerance != 0 || opts.TimeTolerance != 0) {
+		return nil, errors.New("tolerance is set without HasTolerance")
+	}
+
+	groups, err := asOfGroups(left, right, on, opts.By)
+	if err != nil {
+		return nil, err
+	}
+
+	leftValues, ok := leftOn.(interface{ rawValues() any })
+	if !ok {
+		return nil, fmt.Errorf("key column %q must hold numbers or times", on)
+	}
+	rightValues := rightOn.(interface{ rawValues() any })
+
+	var matches []int
+	switch l := leftValues.rawValues().(type) {
+	case []time.Time:
+		matches, err = asOfTimes(l, rightValues.rawValues().([]time.Time), groups, opts)
+	case []int:
+		matches, err = asOfNumbers(l, rightValues.rawValues().([]int), groups, opts)
+	case []int8:
+		matches, err = asOfNumbers(l, rightValues.rawValues().([]int8), groups, opts)
+	case []int16:
+		matches, err = asOfNumbers(l, rightValues.rawValues().([]int16), groups, opts)
+	case []int32:
+		matches, err = asOfNumbers(l, rightValues.rawValues().([]int32), groups, opts)
+	case []int64:
+		matches, err = asOfNumbers(l, rightValues.rawValues().([]int64), groups, opts)
+	case []uint:
+		matches, err = asOfNumbers(l, rightValues.rawValues().([]uint), groups, opts)
+	case []uint8:
+		matches, err = asOfNumbers(l, rightValues.rawValues().([]uint8), groups, opts)
+	case []uint16:
+		matches, err = asOfNumbers(l, rightValues.rawValues().([]uint16), groups, opts)
+	case []uint32:
+		matches, err = asOfNumbers(l, rightValues.rawValues().([]uint32), groups, opts)
+	case []uint64:
+		matches, err = asOfNumbers(l, rightValues.rawValues().([]uint64), groups, opts)
+	case []float32:
+		matches, err = asOfNumbers(l, rightValues.rawValues().([]float32), groups, opts)
+	case []float64:
+		matches, err = asOfNumbers(l, rightValues.rawValues().([]float64), groups, opts)
+	default:
+		return nil, fmt.Errorf("key column %q must hold numbers or times", on)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("key column %q: %w", on, err)
+	}
+
+	var columns []Column[R]
+	all := rangePositions(0, left.Len())
+	for _, c := range left.columns {
+		columns = append(columns, c.gather(all, nil, left.index))
+	}
+	for _, c := range right.columns {
+		if c.Name() != on && !slices.Contains(opts.By, c.Name()) {
+			columns = append(columns, c.gather(matches, nil, left.index))
+		}
+	}
+	keys := append([]string{on}, opts.By...)
+	if err := suffixColumns(columns, len(left.columns), keys, opts.Suffixes); err != nil {
+		return nil, err
+	}
+	return NewDataFrame(columns...), nil
+}
+
+// asOfGroups returns the ids of the By groups of both sides, all rows are in one group without By columns
+func asOfGroups[R comparable](left, right *DataFrame[R], on string, by []string) (joinKeys, error) {
+	if len(by) == 0 {
+		return joinKeys{left: make([]int, left.Len()), right: make([]int, right.Len())}, nil
+	}
+
+	leftBy := make([]Column[R], len(by))
+	rightBy := make([]Column[R], len(by))
+	for i, name := range by {
+		if name == on || slices.Contains(by[:i], name) {
+			return joinKeys{}, fmt.Errorf("duplicate key column %q", name)
+		}
+		l, r := left.columnPos(name), right.columnPos(name)
+		if l < 0 || r < 0 {
+			return joinKeys{}, fmt.Errorf("key column %q is not found on both sides", name)
+		}
+		leftBy[i], rightBy[i] = left.columns[l], right.columns[r]
+		if reflect.TypeOf(leftBy[i]) != reflect.TypeOf(rightBy[i]) {
+			return joinKeys{}, fmt.Errorf("key column %q has different types on both sides", name)
+		}
+	}
+	return columnKeys(leftBy, rightBy), nil
+}
+
+// asOfTimes returns the matches of time keys, the distance between them is a time.Duration
+func asOfTimes(left, right []time.Time, groups joinKeys, opts AsOfOptions) ([]int, error) {
+	if opts.Tolerance != 0 {
+		return nil, errors.New("time keys need TimeTolerance instead of Tolerance")
+	}
+	closer := func(l, backward, forward time.Time) bool {
+		return forward.Sub(l) < l.Sub(backward)
+	}
+	var within func(l, r time.Time) bool
+	if opts.HasTolerance {
+		// Sub saturates for gaps of more than 292 years, so the bounds are moved instead
+		within = func(l, r time.Time) bool {
+			return !r.Before(l.Add(-opts.TimeTolerance)) && !r.After(l.Add(opts.TimeTolerance))
+		}
+	}
+	return asOfMatches(left, right, time.Time.Compare, closer, within, groups, opts.Direction)
+}
+
+// asOfNumbers returns the matches of numeric keys, which are compared as K so large integers stay exact
+func asOfNumbers[K Numeric](left, right []K, groups joinKeys, opts AsOfOptions) ([]int, error) {
+	if opts.TimeTolerance != 0 {
+		return nil, errors.New("numeric keys need Tolerance instead of TimeTolerance")
+	}
+	if slices.ContainsFunc(left, isNaN[K]) || slices.ContainsFunc(right, isNaN[K]) {
+		return nil, errors.New("keys have NaN values")
+	}
+
+	var closer func(l, backward, forward K) bool
+	var within func(l, r K) bool
+	if isFloat[K]() {
+		closer = func(l, backward, forward K) bool {
+			return float64(forward)-float64(l) < float64(l)-float64(backward)
+		}
+		within = func(l, r K) bool {
+			return math.Abs(float64(r)-float64(l)) <= opts.Tolerance
+		}
+	} else {
+		// the difference of two integers always fits into an uint64 if the smaller one is subtracted
+		distance := func(lo, hi K) uint64 {
+			return uint64(hi) - uint64(lo)
+		}
+		closer = func(l, backward, forward K) bool {
+			return distance(l, forward) < distance(backward, l)
+		}
+		// the distance is an integer, so it is within the tolerance if it is within its integer part
+		limit := uint64(math.MaxUint64)
+		if opts.Tolerance < math.MaxUint64 {
+			limit = uint64(opts.Tolerance)
+		}
+		within = func(l, r K) bool {
+			return distance(min(l, r), max(l, r)) <= limit
+		}
+	}
+	if !opts.HasTolerance {
+		within = nil
+	}
+	return asOfMatches(left, right, cmp.Compare[K], closer, within, groups, opts.Direction)
+}
+
+// asOfMatches returns the position of the right row matched to every left row or -1
+// the backward and forward matches are found in one pass over both sides each
+// closer tells if the forward key is closer to the left key than the backward key and is only used by Nearest,
+// within tells if the keys of a match are within the tolerance and is nil without tolerance
+func asOfMatches[K any](left, right []K, compare func(a, b K) int, closer func(l, backward, forward K) bool, within func(l, r K) bool, groups joinKeys, direction AsOfDirection) ([]int, error) {
+	sorted := func(keys []K) bool {
+		return slices.IsSortedFunc(keys, compare)
+	}
+	if !sorted(left) || !sorted(right) {
+		return nil, errors.New("keys must be sorted in ascending order")
+	}
+
+	var backward, forward []int
+	if direction == Backward || direction == Nearest {
+		backward = make([]int, len(left))
+		last := make(map[int]int)
+		j := 0
+		for i := range left {
+			for j < len(right) && compare(right[j], left[i]) <= 0 {
+				if groups.right[j] >= 0 {
+					last[groups.right[j]] = j
+				}
+				j++
+			}
+			backward[i] = -1
+			if p, ok := last[groups.left[i]]; ok {
+				backward[i] = p
+			}
+		}
+	}
+	if direction == Forward || direction == Nearest {
+		forward = make([]int, len(left))
+		next := make(map[int]int)
+		j := len(right) - 1
+		for i := len(left) - 1; i >= 0; i-- {
+			for j >= 0 && compare(right[j], left[i]) >= 0 {
+				if groups.right[j] >= 0 {
+					next[groups.right[j]] = j
+				}
+				j--
+			}
+			forward[i] = -1
+			if p, ok := next[groups.left[i]]; ok {
+				forward[i] = p
+			}
+		}
+	}
+
+	var matches []int
+	switch direction {
+	case Backward:
+		matches = backward
+	case Forward:
+		matches = forward
+	case Nearest:
+		matches = backward
+		for i, f := range forward {
+			if f >= 0 && (backward[i] < 0 || closer(left[i], right[backward[i]], right[f])) {
+				matches[i] = f
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unknown direction %d", direction)
+	}
+
+	if within != nil {
+		for i, m := range matches {
+			if m >= 0 && !within(left[i], right[m]) {
+				matches[i] = -1
+			}
+		}
+	}
+	return matches, nil
+}
@@ -0,0 +1,165 @@
+package series
+
+import (
+	"math"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestMergeAsOf(t *testing.T) {
+	nan := math.NaN()
+	trades := NewDataFrame[int](
+		NewIndexSeries("time", []float64{1, 3, 5, 8, 10}),
+		NewIndexSeries("price", []float64{10, 11, 12, 13, 14}),
+	)
+	quotes := NewDataFrame[int](
+		NewIndexSeries("time", []float64{2, 3, 7, 12}),
+		NewIndexSeries("price", []float64{100, 101, 102, 103}),
+	)
+	for name, tc := range map[string]struct {
+		opts AsOfOptions
+		want []float64
+	}{
+		"backward":       {AsOfOptions{}, []float64{nan, 101, 101, 102, 102}},
+		"forward":        {AsOfOptions{Direction: Forward}, []float64{100, 101, 102, 103, 103}},
+		"nearest":        {AsOfOptions{Direction: Nearest}, []float64{100, 101, 101, 102, 103}},
+		"with tolerance": {AsOfOptions{Direction: Backward, HasTolerance: true, Tolerance: 1}, []float64{nan, 101, nan, 102, nan}},
+		"exact matches":  {AsOfOptions{Direction: Nearest, HasTolerance: true}, []float64{nan, 101, nan, nan, nan}},
+	} {
+		df, err := MergeAsOf(trades, quotes, "time", tc.opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(df.Columns(), []string{"time", "price_x", "price_y"}) {
+			t.Errorf("%s: unexpected columns %v", name, df.Columns())
+		}
+		if got := GetColumn[float64](df, "price_y").Values(); !equalNaN(got, tc.want) {
+			t.Errorf("%s: expected %v, got %v", name, tc.want, got)
+		}
+		if GetColumn[float64](trades, "price").Name() != "price" {
+			t.Errorf("%s: the left columns were renamed", name)
+		}
+	}
+
+	t.Run("by groups and time keys", func(t *testing.T) {
+		at := func(minute int) time.Time {
+			return time.Date(2024, 5, 1, 9, minute, 0, 0, time.UTC)
+		}
+		trades := NewDataFrame(
+			Column[string](NewSeries("time", []time.Time{at(1), at(2), at(4), at(6)}, []string{"t1", "t2", "t3", "t4"})),
+			Column[string](NewSeries("ticker", []string{"A", "B", "A", "C"}, []string{"t1", "t2", "t3", "t4"})),
+		)
+		quotes := NewDataFrame(
+			Column[string](NewSeries("time", []time.Time{at(0), at(1), at(3), at(5)}, []string{"q1", "q2", "q3", "q4"})),
+			Column[string](NewSeries("ticker", []string{"B", "A", "B", "A"}, []string{"q1", "q2", "q3", "q4"})),
+			Column[string](NewSeries("bid", []int{1, 2, 3, 4}, []string{"q1", "q2", "q3", "q4"})),
+		)
+		df, err := MergeAsOf(trades, quotes, "time", AsOfOptions{By: []string{"ticker"}, Direction: Nearest})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(df.Index(), []string{"t1", "t2", "t3", "t4"}) {
+			t.Errorf("unexpected index %v", df.Index())
+		}
+		if bid := GetColumn[int](df, "bid").Values(); !slices.Equal(bid, []int{2, 3, 4, 0}) {
+			t.Errorf("unexpected bids %v", bid)
+		}
+
+		df, err = MergeAsOf(trades, quotes, "time", AsOfOptions{By: []string{"ticker"}, HasTolerance: true, TimeTolerance: 90 * time.Second})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if bid := GetColumn[int](df, "bid").Values(); !slices.Equal(bid, []int{2, 0, 0, 0}) {
+			t.Errorf("unexpected bids with tolerance %v", bid)
+		}
+
+		// the gap of 600 years does not fit into a time.Duration
+		far := NewDataFrame[int](NewIndexSeries("time", []time.Time{time.Date(2500, 1, 1, 0, 0, 0, 0, time.UTC)}))
+		past := NewDataFrame[int](
+			NewIndexSeries("time", []time.Time{time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC)}),
+			NewIndexSeries("q", []int{42}),
+		)
+		matched, err := MergeAsOf(far, past, "time", AsOfOptions{HasTolerance: true, TimeTolerance: time.Hour})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if q := GetColumn[int](matched, "q").Values(); !slices.Equal(q, []int{0}) {
+			t.Errorf("expected no match 600 years apart, got %v", q)
+		}
+	})
+
+	t.Run("compares large integers exactly", func(t *testing.T) {
+		// base+1 and base+2 are the same float64, the keys must not be converted
+		const base = int64(1) << 60
+		left := NewDataFrame[int](NewIndexSeries("k", []int64{base + 1}))
+		right := NewDataFrame[int](
+			NewIndexSeries("k", []int64{base - 2, base + 1, base + 2}),
+			NewIndexSeries("v", []string{"past", "exact", "future"}),
+		)
+		for _, direction := range []AsOfDirection{Backward, Forward, Nearest} {
+			df, err := MergeAsOf(left, right, "k", AsOfOptions{Direction: direction})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := GetColumn[string](df, "v").At(0); got != "exact" {
+				t.Errorf("%d: expected the exact match, got %q", direction, got)
+			}
+		}
+
+		left = NewDataFrame[int](NewIndexSeries("k", []int64{base}))
+		df, err := MergeAsOf(left, right, "k", AsOfOptions{Direction: Nearest})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := GetColumn[string](df, "v").At(0); got != "exact" {
+			t.Errorf("expected the nearest key base+1, got %q", got)
+		}
+
+		// the distance between the smallest and the largest int64 does not fit into an int64
+		extremes := NewDataFrame[int](NewIndexSeries("k", []int64{math.MinInt64, math.MaxInt64}), NewIndexSeries("v", []int{1, 2}))
+		df, err = MergeAsOf(NewDataFrame[int](NewIndexSeries("k", []int64{-1})), extremes, "k", AsOfOptions{Direction: Nearest})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := GetColumn[int](df, "v").At(0); got != 1 {
+			t.Errorf("expected the nearest key MinInt64, got %d", got)
+		}
+		df, err = MergeAsOf(NewDataFrame[int](NewIndexSeries("k", []int64{0})), extremes, "k", AsOfOptions{Direction: Nearest, HasTolerance: true, Tolerance: math.MaxInt64})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := GetColumn[int](df, "v").At(0); got != 2 {
+			t.Errorf("expected the nearest key MaxInt64 within the tolerance, got %d", got)
+		}
+	})
+}
+
+func TestMergeAsOf_Errors(t *testing.T) {
+	sorted := NewDataFrame[int](NewIndexSeries("k", []int{1, 2, 3}), NewIndexSeries("g", []string{"a", "b", "c"}))
+	unsorted := NewDataFrame[int](NewIndexSeries("k", []int{2, 1, 3}))
+	withNaN := NewDataFrame[int](NewIndexSeries("k", []float64{1, math.NaN()}))
+	floats := NewDataFrame[int](NewIndexSeries("k", []float64{1}))
+	times := NewDataFrame[int](NewIndexSeries("k", []time.Time{time.Unix(0, 0)}))
+	for name, tc := range map[string]struct {
+		left, right *DataFrame[int]
+		on          string
+		opts        AsOfOptions
+	}{
+		"unsorted keys":       {sorted, unsorted, "k", AsOfOptions{}},
+		"missing key":         {sorted, unsorted, "x", AsOfOptions{}},
+		"missing by":          {sorted, unsorted, "k", AsOfOptions{By: []string{"g"}}},
+		"text keys":           {sorted, sorted, "g", AsOfOptions{}},
+		"negative tolerance":  {sorted, sorted, "k", AsOfOptions{HasTolerance: true, Tolerance: -1}},
+		"NaN tolerance":       {sorted, sorted, "k", AsOfOptions{HasTolerance: true, Tolerance: math.NaN()}},
+		"tolerance unused":    {sorted, sorted, "k", AsOfOptions{Tolerance: 1}},
+		"time tolerance":      {sorted, sorted, "k", AsOfOptions{HasTolerance: true, TimeTolerance: time.Second}},
+		"numeric tolerance":   {times, times, "k", AsOfOptions{HasTolerance: true, Tolerance: 1}},
+		"different key types": {sorted, floats, "k", AsOfOptions{}},
+		"NaN keys":            {withNaN, floats, "k", AsOfOptions{}},
+	} {
+		if _, err := MergeAsOf(tc.left, tc.right, tc.on, tc.opts); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
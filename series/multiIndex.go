@@ -0,0 +1,387 @@
+package series
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// MaxLevels is the largest number of levels of a MultiIndex
+const MaxLevels = 8
+
+// MultiIndex is a hierarchical label made of one value for each of its named levels
+// it is comparable so it can be used as the label type of a Series, two MultiIndex are equal
+// if they have the same level names and the same values
+type MultiIndex struct {
+	levels *levelNames
+	values [MaxLevels]any
+}
+
+// levelNames are the names of the levels of a MultiIndex
+// they are interned so MultiIndex with the same names share the pointer and compare equal
+type levelNames struct {
+	names []string
+}
+
+// interned holds the levelNames of every set of names in use
+var interned sync.Map
+
+// internLevels returns the shared levelNames for the given names
+func internLevels(names []string) *levelNames {
+	key := fmt.Sprintf("%q", names)
+	if levels, ok := interned.Load(key); ok {
+		return levels.(*levelNames)
+	}
+	levels, _ := interned.LoadOrStore(key, &levelNames{names: slices.Clone(names)})
+	return levels.(*levelNames)
+}
+
+// NewMultiIndex creates a MultiIndex with the given level names and one comparable value per level
+func NewMultiIndex(names []string, values ...any) MultiIndex {
+	levels := newLevels(names)
+	if len(values) != len(names) {
+		panic("values length must match number of levels")
+	}
+	return levels.multiIndex(values)
+}
+
+// newLevels checks the level names and returns their shared levelNames
+func newLevels(names []string) *levelNames {
+	if len(names) == 0 || len(names) > MaxLevels {
+		panic(fmt.Sprintf("a MultiIndex must have between 1 and %d levels", MaxLevels))
+	}
+	for i, name := range names {
+		if slices.Contains(names[:i], name) {
+			panic(fmt.Sprintf("duplicate level name %q", name))
+		}
+	}
+	return internLevels(names)
+}
+
+// multiIndex creates a MultiIndex with these levels and one comparable value per level
+func (l *levelNames) multiIndex(values []any) MultiIndex {
+	m := MultiIndex{levels: l}
+	for i, v := range values {
+		if v != nil && !reflect.TypeOf(v).Comparable() {
+			panic(fmt.Sprintf("level %q holds a value of type %T which is not comparable", l.names[i], v))
+		}
+		m.values[i] = v
+	}
+	return m
+}
+
+// subset returns the levelNames of the levels at the given positions in the given order
+func (l *levelNames) subset(positions []int) *levelNames {
+	names := make([]string, len(positions))
+	for i, p := range positions {
+		names[i] = l.names[p]
+	}
+	return internLevels(names)
+}
+
+// MultiIndexFromArrays creates the labels for a Series from one slice of values per level
+// all slices must have the same length
+func MultiIndexFromArrays(names []string, levels ...[]any) []MultiIndex {
+	if len(levels) != len(names) {
+		panic("levels length must match number of names")
+	}
+	shared := newLevels(names)
+	for _, level := range levels {
+		if len(level) != len(levels[0]) {
+			panic("all levels must have the same length")
+		}
+	}
+
+	index := make([]MultiIndex, len(levels[0]))
+	values := make([]any, len(levels))
+	for i := range index {
+		for l, level := range levels {
+			values[l] = level[i]
+		}
+		index[i] = shared.multiIndex(values)
+	}
+	return index
+}
+
+// MultiIndexFromColumns creates the labels for the rows of a DataFrame from the values of the given columns
+// the levels are named like the columns
+func MultiIndexFromColumns[R comparable](df *DataFrame[R], names ...string) []MultiIndex {
+	shared := newLevels(names)
+	columns := make([]Column[R], len(names))
+	for i, name := range names {
+		columns[i] = df.Column(name)
+	}
+
+	index := make([]MultiIndex, df.Len())
+	values := make([]any, len(columns))
+	for i := range index {
+		for l, c := range columns {
+			values[l] = c.valueAt(i)
+		}
+		index[i] = shared.multiIndex(values)
+	}
+	return index
+}
+
+// NLevels returns the number of levels
+func (m MultiIndex) NLevels() int {
+	if m.levels == nil {
+		return 0
+	}
+	return len(m.levels.names)
+}
+
+// Names returns the names of the levels in order
+func (m MultiIndex) Names() []string {
+	if m.levels == nil {
+		return nil
+	}
+	return slices.Clone(m.levels.names)
+}
+
+// Values returns the values of the levels in order
+func (m MultiIndex) Values() []any {
+	return slices.Clone(m.values[:m.NLevels()])
+}
+
+// At returns the value of the level at the given position
+func (m MultiIndex) At(i int) any {
+	if i < 0 || i >= m.NLevels() {
+		panic(fmt.Sprintf("level %d out of bounds", i))
+	}
+	return m.values[i]
+}
+
+// Get returns the value of the level with the given name
+func (m MultiIndex) Get(level string) any {
+	return m.values[m.levelPos(level)]
+}
+
+// String returns a string representation of the MultiIndex
+func (m MultiIndex) String() string {
+	parts := make([]string, m.NLevels())
+	for i := range parts {
+		parts[i] = fmt.Sprint(m.values[i])
+	}
+	return "(" + strings.Join(parts, ", ") + ")"
+}
+
+// levelPos returns the position of the level with the given name
+func (m MultiIndex) levelPos(level string) int {
+	pos := slices.Index(m.Names(), level)
+	if pos < 0 {
+		panic(fmt.Sprintf("no level named %q", level))
+	}
+	return pos
+}
+
+// withLevels returns a MultiIndex with the given levels holding the values of the levels at the given positions
+// levels must be the subset of the levels of m at the positions, which callers intern once for all labels
+func (m MultiIndex) withLevels(levels *levelNames, positions []int) MultiIndex {
+	out := MultiIndex{levels: levels}
+	for i, p := range positions {
+		out.values[i] = m.values[p]
+	}
+	return out
+}
+
+// levelPositions returns the positions of the given levels of the labels of a Series
+// all labels must have the same levels
+func levelPositions(index []MultiIndex, levels []string) []int {
+	first := index[0]
+	for _, m := range index {
+		if m.levels != first.levels {
+			panic("all labels must have the same levels")
+		}
+	}
+
+	positions := make([]int, len(levels))
+	for i, level := range levels {
+		positions[i] = first.levelPos(level)
+		if slices.Contains(positions[:i], positions[i]) {
+			panic(fmt.Sprintf("duplicate level name %q", level))
+		}
+	}
+	return positions
+}
+
+// relabel returns a Series with the values of s and every label replaced by f
+func relabel[T comparable](s *Series[T, MultiIndex], f func(m MultiIndex) MultiIndex) *Series[T, MultiIndex] {
+	index := make([]MultiIndex, s.Len())
+	for i, m := range s.index {
+		index[i] = f(m)
+	}
+	return NewSeries(s.name, slices.Clone(s.values), index)
+}
+
+// XS returns the cross-section of the Series where the given level has the value key
+// the level is dropped from the labels of the result, panics if no label has the key
+func XS[T comparable](s *Series[T, MultiIndex], level string, key any) *Series[T, MultiIndex] {
+	pos := levelPositions(s.index, []string{level})[0]
+	if s.index[0].NLevels() == 1 {
+		panic("cannot drop the only level")
+	}
+	keep := slices.DeleteFunc(rangePositions(0, s.index[0].NLevels()), func(p int) bool { return p == pos })
+
+	var positions []int
+	for i, m := range s.index {
+		if m.values[pos] == key {
+			positions = append(positions, i)
+		}
+	}
+	if len(positions) == 0 {
+		panic(fmt.Sprintf("key %v not found in level %q", key, level))
+	}
+	levels := s.index[0].levels.subset(keep)
+	return relabel(s.takePositions(positions), func(m MultiIndex) MultiIndex {
+		return m.withLevels(levels, keep)
+	})
+}
+
+// SwapLevel returns a new Series with the levels a and b of the labels swapped
+func SwapLevel[T comparable](s *Series[T, MultiIndex], a, b string) *Series[T, MultiIndex] {
+	positions := levelPositions(s.index, []string{a})
+	order := rangePositions(0, s.index[0].NLevels())
+	pa, pb := positions[0], s.index[0].levelPos(b)
+	order[pa], order[pb] = order[pb], order[pa]
+	levels := s.index[0].levels.subset(order)
+	return relabel(s, func(m MultiIndex) MultiIndex {
+		return m.withLevels(levels, order)
+	})
+}
+
+// DropLevel returns a new Series with the given level removed from the labels
+// the result may have the same label more than once
+func DropLevel[T comparable](s *Series[T, MultiIndex], level string) *Series[T, MultiIndex] {
+	pos := levelPositions(s.index, []string{level})[0]
+	if s.index[0].NLevels() == 1 {
+		panic("cannot drop the only level")
+	}
+	keep := slices.DeleteFunc(rangePositions(0, s.index[0].NLevels()), func(p int) bool { return p == pos })
+	levels := s.index[0].levels.subset(keep)
+	return relabel(s, func(m MultiIndex) MultiIndex {
+		return m.withLevels(levels, keep)
+	})
+}
+
+// SortByLevel sorts the Series by the values of the given levels, all levels in order if none are given
+// every level must hold numbers, strings, bools or times of one type, NaN and nil values are put last
+// the sort is stable, values with equal labels keep their order
+func SortByLevel[T comparable](s *Series[T, MultiIndex], asc bool, levels ...string) *Series[T, MultiIndex] {
+	if len(levels) == 0 {
+		levels = s.index[0].Names()
+	}
+	positions := levelPositions(s.index, levels)
+	for _, p := range positions {
+		var first any
+		for _, m := range s.index {
+			v := m.values[p]
+			switch {
+			case v == nil:
+			case !orderedKey(v):
+				panic(fmt.Sprintf("cannot sort level %q of type %T", m.levels.names[p], v))
+			case first == nil:
+				first = v
+			case reflect.TypeOf(v) != reflect.TypeOf(first):
+				panic(fmt.Sprintf("cannot sort level %q holding values of types %T and %T", m.levels.names[p], first, v))
+			}
+		}
+	}
+
+	sorted := sortedPositions(s.Len(), func(i, j int) int {
+		for _, p := range positions {
+			if c := compareLevel(s.index[i].values[p], s.index[j].values[p], asc); c != 0 {
+				return c
+			}
+		}
+		return 0
+	})
+	return s.takePositions(sorted)
+}
+
+// compareLevel compares two level values in the given direction and puts NaN and nil values last
+func compareLevel(a, b any, asc bool) int {
+	aMissing, bMissing := a == nil || a != a, b == nil || b != b
+	switch {
+	case aMissing && bMissing:
+		return 0
+	case aMissing:
+		return 1
+	case bMissing:
+		return -1
+	}
+	if asc {
+		return compareKey(a, b)
+	}
+	return compareKey(b, a)
+}
+
+// GroupByLevel groups the values of the Series by the values of the given levels
+// it yields the group labels, holding only the given levels, with the values of each group in order of first appearance
+// values whose label has NaN in one of the levels are in no group since NaN is not equal to itself
+func GroupByLevel[T comparable](s *Series[T, MultiIndex], levels ...string) iter.Seq2[MultiIndex, *Series[T, MultiIndex]] {
+	groups, _ := GroupByLevelCtx(context.Background(), s, levels...)
+	return groups
+}
+
+// GroupByLevelCtx is GroupByLevel but stops grouping and returns ctx.Err() when ctx is cancelled
+// the groups are found before it returns, iterating over them is not cancellable
+func GroupByLevelCtx[T comparable](ctx context.Context, s *Series[T, MultiIndex], levels ...string) (iter.Seq2[MultiIndex, *Series[T, MultiIndex]], error) {
+	positions := levelPositions(s.index, levels)
+	names := s.index[0].levels.subset(positions)
+	keys, ids, err := firstSeenCtx(ctx, s.Len(), func(i int) (MultiIndex, bool) {
+		key := s.index[i].withLevels(names, positions)
+		return key, key == key
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return func(yield func(MultiIndex, *Series[T, MultiIndex]) bool) {
+		groups := make([][]int, len(keys))
+		for i, id := range ids {
+			if id >= 0 {
+				groups[id] = append(groups[id], i)
+			}
+		}
+		for id, key := range keys {
+			if !yield(key, s.takePositions(groups[id])) {
+				return
+			}
+		}
+	}, nil
+}
+
+// AggregateByLevel combines the values of every group of GroupByLevel into one value
+// the result is labeled by the group labels in order of first appearance
+func AggregateByLevel[T Numeric](s *NumericSeries[T, MultiIndex], agg Aggregation, levels ...string) *NumericSeries[float64, MultiIndex] {
+	result, _ := AggregateByLevelCtx(context.Background(), s, agg, levels...)
+	return result
+}
+
+// AggregateByLevelCtx is AggregateByLevel but stops and returns ctx.Err() when ctx is cancelled
+// the context is checked while grouping and before every group is aggregated
+func AggregateByLevelCtx[T Numeric](ctx context.Context, s *NumericSeries[T, MultiIndex], agg Aggregation, levels ...string) (*NumericSeries[float64, MultiIndex], error) {
+	groups, err := GroupByLevelCtx(ctx, s.Series, levels...)
+	if err != nil {
+		return nil, err
+	}
+	var values []float64
+	var index []MultiIndex
+	for key, group := range groups {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		floats := make([]float64, group.Len())
+		for i, v := range group.values {
+			floats[i] = float64(v)
+		}
+		values = append(values, agg.aggregate(floats))
+		index = append(index, key)
+	}
+	return NewNumericSeries(s.name, values, index), nil
+}
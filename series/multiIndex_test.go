@@ -0,0 +1,201 @@
+package series
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestMultiIndex(t *testing.T) {
+	m := NewMultiIndex([]string{"region", "store"}, "north", 7)
+	if m != NewMultiIndex([]string{"region", "store"}, "north", 7) {
+		t.Error("expected equal labels to compare equal")
+	}
+	if m == NewMultiIndex([]string{"area", "store"}, "north", 7) {
+		t.Error("expected labels with other level names to differ")
+	}
+	if m.NLevels() != 2 || m.Get("store") != 7 || m.At(0) != "north" || m.String() != "(north, 7)" {
+		t.Errorf("unexpected label %v", m)
+	}
+	if !slices.Equal(m.Names(), []string{"region", "store"}) || !slices.Equal(m.Values(), []any{"north", 7}) {
+		t.Errorf("unexpected names %v or values %v", m.Names(), m.Values())
+	}
+
+	s := NewSeries("sales", []float64{10, 40}, MultiIndexFromArrays([]string{"region", "store", "year"},
+		[]any{"north", "south"},
+		[]any{"a", "c"},
+		[]any{2024, 2023},
+	))
+	if s.Get(NewMultiIndex([]string{"region", "store", "year"}, "south", "c", 2023)) != 40 {
+		t.Error("expected to look up values by MultiIndex")
+	}
+
+	df := NewDataFrame[int](
+		NewIndexSeries("region", []string{"north", "south"}),
+		NewIndexSeries("id", []int{1, 2}),
+	)
+	if index := MultiIndexFromColumns(df, "id", "region"); index[1] != NewMultiIndex([]string{"id", "region"}, 2, "south") {
+		t.Errorf("unexpected index %v", index)
+	}
+
+	for name, f := range map[string]func(){
+		"no levels":           func() { NewMultiIndex(nil) },
+		"too many values":     func() { NewMultiIndex([]string{"a"}, 1, 2) },
+		"duplicate names":     func() { NewMultiIndex([]string{"a", "a"}, 1, 2) },
+		"not comparable":      func() { NewMultiIndex([]string{"a"}, []int{1}) },
+		"unknown level":       func() { m.Get("city") },
+		"different lengths":   func() { MultiIndexFromArrays([]string{"a", "b"}, []any{1, 2}, []any{1}) },
+		"level out of bounds": func() { m.At(2) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s: expected panic", name)
+				}
+			}()
+			f()
+		}()
+	}
+}
+
+func TestXS(t *testing.T) {
+	index := MultiIndexFromArrays([]string{"region", "store", "year"},
+		[]any{"north", "south", "north", "south", "north"},
+		[]any{"a", "c", "b", "c", "a"},
+		[]any{2024, 2024, 2023, 2023, 2023},
+	)
+	north := XS(NewSeries("sales", []float64{10, 20, 30, 40, 50}, index), "region", "north")
+	if !slices.Equal(north.Values(), []float64{10, 30, 50}) {
+		t.Errorf("unexpected values %v", north.Values())
+	}
+	if !slices.Equal(north.index[0].Names(), []string{"store", "year"}) || north.index[1].Get("store") != "b" {
+		t.Errorf("unexpected index %v", north.Index())
+	}
+
+	y2023 := XS(north, "year", 2023)
+	if y2023.Get(NewMultiIndex([]string{"store"}, "a")) != 50 {
+		t.Errorf("unexpected cross-section %v", y2023)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for a missing key")
+		}
+	}()
+	XS(north, "store", "z")
+}
+
+func TestSwapAndDropLevel(t *testing.T) {
+	s := NewSeries("sales", []float64{10, 20}, MultiIndexFromArrays([]string{"region", "store", "year"},
+		[]any{"north", "south"},
+		[]any{"a", "c"},
+		[]any{2024, 2023},
+	))
+	swapped := SwapLevel(s, "year", "region")
+	if !slices.Equal(swapped.index[0].Names(), []string{"year", "store", "region"}) || swapped.index[0].At(0) != 2024 {
+		t.Errorf("unexpected index %v", swapped.Index())
+	}
+	if !slices.Equal(swapped.Values(), s.Values()) {
+		t.Errorf("unexpected values %v", swapped.Values())
+	}
+
+	dropped := DropLevel(DropLevel(s, "store"), "year")
+	if dropped.index[1] != NewMultiIndex([]string{"region"}, "south") {
+		t.Errorf("unexpected index %v", dropped.Index())
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic when dropping the only level")
+		}
+	}()
+	DropLevel(dropped, "region")
+}
+
+func TestSortByLevel(t *testing.T) {
+	s := NewSeries("sales", []float64{10, 20, 30, 40, 50}, MultiIndexFromArrays([]string{"store", "year"},
+		[]any{"a", "c", "b", "c", "a"},
+		[]any{2024, 2024, 2023, 2023, 2023},
+	))
+	sorted := SortByLevel(s, true, "year", "store")
+	if !slices.Equal(sorted.Values(), []float64{50, 30, 40, 10, 20}) {
+		t.Errorf("unexpected order %v", sorted.Values())
+	}
+	if all := SortByLevel(s, false); !slices.Equal(all.Values(), []float64{20, 40, 30, 10, 50}) {
+		t.Errorf("unexpected descending order %v", all.Values())
+	}
+
+	withNaN := NewSeries("v", []int{1, 2, 3}, MultiIndexFromArrays([]string{"x"}, []any{math.NaN(), 2.0, 1.0}))
+	if v := SortByLevel(withNaN, false).Values(); !slices.Equal(v, []int{2, 3, 1}) {
+		t.Errorf("expected NaN last, got %v", v)
+	}
+
+	defer func() {
+		if r := recover(); r == nil || !strings.Contains(fmt.Sprint(r), "types int and string") {
+			t.Errorf("expected a panic naming both types, got %v", r)
+		}
+	}()
+	SortByLevel(NewSeries("v", []int{1, 2}, MultiIndexFromArrays([]string{"x"}, []any{1, "x"})), true)
+}
+
+func TestGroupByLevel(t *testing.T) {
+	index := MultiIndexFromArrays([]string{"region", "store", "year"},
+		[]any{"north", "south", "north", "south", "north"},
+		[]any{"a", "c", "b", "c", "a"},
+		[]any{2024, 2024, 2023, 2023, 2023},
+	)
+	s := NewNumericSeries("sales", []float64{10, 20, 30, 40, 50}, index)
+	var keys []MultiIndex
+	var sizes []int
+	for key, group := range GroupByLevel(s.Series, "region") {
+		keys = append(keys, key)
+		sizes = append(sizes, group.Len())
+	}
+	if len(keys) != 2 || keys[0].Get("region") != "north" || !slices.Equal(sizes, []int{3, 2}) {
+		t.Errorf("unexpected groups %v %v", keys, sizes)
+	}
+
+	sums := AggregateByLevel(s, AggSum, "region", "year")
+	if !slices.Equal(sums.Values(), []float64{10, 20, 80, 40}) {
+		t.Errorf("unexpected sums %v", sums.Values())
+	}
+	if sums.Get(NewMultiIndex([]string{"region", "year"}, "north", 2023)) != 80 {
+		t.Errorf("unexpected index %v", sums.Index())
+	}
+	if mean := AggregateByLevel(s, AggMean, "store"); mean.At(0) != 30 {
+		t.Errorf("unexpected means %v", mean.Values())
+	}
+
+	// NaN is not equal to itself, so labels with NaN are in no group
+	withNaN := NewNumericSeries("v", []float64{1, 2, 3, 4}, MultiIndexFromArrays([]string{"x", "y"},
+		[]any{math.NaN(), 1.0, math.NaN(), 1.0},
+		[]any{"a", "a", "a", "b"},
+	))
+	if counts := AggregateByLevel(withNaN, AggCount, "x"); !slices.Equal(counts.Values(), []float64{2}) {
+		t.Errorf("expected one group without NaN, got %v %v", counts.Index(), counts.Values())
+	}
+	if sums := AggregateByLevel(withNaN, AggSum, "y"); !slices.Equal(sums.Values(), []float64{6, 4}) {
+		t.Errorf("expected NaN in other levels to be ignored, got %v", sums.Values())
+	}
+}
+
+func TestGroupByLevelCtx(t *testing.T) {
+	s := NewNumericSeries("v", []float64{1, 2, 3}, MultiIndexFromArrays([]string{"g"}, []any{"a", "b", "a"}))
+	sums, err := AggregateByLevelCtx(context.Background(), s, AggSum, "g")
+	if err != nil || !slices.Equal(sums.Values(), []float64{4, 2}) {
+		t.Errorf("unexpected sums %v %v", sums, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := GroupByLevelCtx(ctx, s.Series, "g"); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+	if _, err := AggregateByLevelCtx(ctx, s, AggSum, "g"); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
@@ -0,0 +1,255 @@
+package series
+
+import (
+	"math"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// defaultChunkSize is the ChunkSize of an Engine created by NewEngine
+const defaultChunkSize = 32_768
+
+// Engine executes operations on large NumericSeries in chunks spread over several goroutines
+// the chunks only depend on ChunkSize and are always combined in the same order,
+// so floating point results do not change with the number of workers
+type Engine struct {
+	// Workers is the number of goroutines, 0 means runtime.GOMAXPROCS
+	Workers int
+	// Threshold is the length below which the Series is processed serially by the NumericSeries methods,
+	// splitting it into chunks and starting goroutines costs more than it saves for short Series
+	Threshold int
+	// ChunkSize is the number of values one goroutine processes at a time
+	ChunkSize int
+}
+
+// NewEngine creates a new Engine using all CPUs which only kicks in for Series of 100000 values or more
+func NewEngine() *Engine {
+	return &Engine{
+		Workers:   0,
+		Threshold: 100_000,
+		ChunkSize: defaultChunkSize,
+	}
+}
+
+// workers returns the number of goroutines to use
+func (e *Engine) workers() int {
+	if e.Workers <= 0 {
+		return runtime.GOMAXPROCS(0)
+	}
+	return e.Workers
+}
+
+// chunkSize returns the configured chunk size or the default if not set
+func (e *Engine) chunkSize() int {
+	if e.ChunkSize <= 0 {
+		return defaultChunkSize
+	}
+	return e.ChunkSize
+}
+
+// layout returns the size and number of the chunks for n values
+// below the threshold all values go into a single chunk
+func (e *Engine) layout(n int) (int, int) {
+	size := e.chunkSize()
+	if n < e.Threshold {
+		size = max(n, 1)
+	}
+	return size, (n + size - 1) / size
+}
+
+// run splits n values into chunks and calls work for every chunk
+// chunk is the number of the chunk, start and end are the positions of its values
+func (e *Engine) run(n int, work func(chunk, start, end int)) {
+	size, count := e.layout(n)
+
+	workers := min(e.workers(), count)
+	if workers <= 1 {
+		for c := range count {
+			work(c, c*size, min((c+1)*size, n))
+		}
+		return
+	}
+
+	var next atomic.Int64
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Go(func() {
+			for {
+				c := int(next.Add(1) - 1)
+				if c >= count {
+					return
+				}
+				work(c, c*size, min((c+1)*size, n))
+			}
+		})
+	}
+	wg.Wait()
+}
+
+// ParallelNumericSeries runs the operations of a NumericSeries on an Engine
+type ParallelNumericSeries[T Numeric, R comparable] struct {
+	ns     *NumericSeries[T, R]
+	engine *Engine
+}
+
+// Parallel returns the NumericSeries with operations executed by the given Engine
+func (ns *NumericSeries[T, R]) Parallel(e *Engine) *ParallelNumericSeries[T, R] {
+	return &ParallelNumericSeries[T, R]{ns: ns, engine: e}
+}
+
+// serial checks if the Series is shorter than the Threshold of the Engine
+// the serial methods give the same results as a single chunk
+func (p *ParallelNumericSeries[T, R]) serial() bool {
+	return p.ns.Len() < p.engine.Threshold
+}
+
+// partials computes one result per chunk with f and returns them in chunk order
+func partials[T Numeric, R comparable, P any](p *ParallelNumericSeries[T, R], f func(values []T) P) []P {
+	values := p.ns.values
+	_, count := p.engine.layout(len(values))
+	results := make([]P, count)
+	p.engine.run(len(values), func(chunk, start, end int) {
+		results[chunk] = f(values[start:end])
+	})
+	return results
+}
+
+// pairwiseSum adds the values by recursively splitting them in halves
+// which keeps the rounding error lower than adding them one after another
+func pairwiseSum[T Numeric](values []T) T {
+	switch len(values) {
+	case 0:
+		var zero T
+		return zero
+	case 1:
+		return values[0]
+	}
+	half := len(values) / 2
+	return pairwiseSum(values[:half]) + pairwiseSum(values[half:])
+}
+
+// Sum returns the sum of the Series
+func (p *ParallelNumericSeries[T, R]) Sum() T {
+	if p.serial() {
+		return p.ns.Sum()
+	}
+	return pairwiseSum(partials(p, func(values []T) T {
+		var sum T
+		for _, v := range values {
+			sum += v
+		}
+		return sum
+	}))
+}
+
+// Mean returns the mean of the Series
+func (p *ParallelNumericSeries[T, R]) Mean() float64 {
+	return float64(p.Sum()) / float64(p.ns.Len())
+}
+
+// Min returns the smallest value in the Series
+// like NumericSeries.Min NaN values are ignored unless the first value is NaN
+func (p *ParallelNumericSeries[T, R]) Min() T {
+	return p.extreme(func(a, b T) bool { return a < b })
+}
+
+// Max returns the largest value in the Series
+// like NumericSeries.Max NaN values are ignored unless the first value is NaN
+func (p *ParallelNumericSeries[T, R]) Max() T {
+	return p.extreme(func(a, b T) bool { return a > b })
+}
+
+// extreme returns the value for which better is true against all others
+func (p *ParallelNumericSeries[T, R]) extreme(better func(a, b T) bool) T {
+	first := p.ns.values[0]
+	if isNaN(first) {
+		return first
+	}
+
+	type result struct {
+		value T
+		found bool
+	}
+
+	best := first
+	for _, r := range partials(p, func(values []T) result {
+		var r result
+		for _, v := range values {
+			if !isNaN(v) && (!r.found || better(v, r.value)) {
+				r = result{value: v, found: true}
+			}
+		}
+		return r
+	}) {
+		if r.found && better(r.value, best) {
+			best = r.value
+		}
+	}
+	return best
+}
+
+// StdDev returns the standard deviation of the Series
+// dof is degrees of freedom, typically 0 for population(complete set) and 1 for sample(uncomplete set)
+func (p *ParallelNumericSeries[T, R]) StdDev(dof int) float64 {
+	if dof < 0 {
+		panic("degrees of freedom must be non-negative")
+	}
+	if p.serial() {
+		return p.ns.StdDev(dof)
+	}
+
+	mean := p.Mean()
+	sumSquaredDiff := pairwiseSum(partials(p, func(values []T) float64 {
+		var sum float64
+		for _, v := range values {
+			diff := float64(v) - mean
+			sum += diff * diff
+		}
+		return sum
+	}))
+
+	variance := sumSquaredDiff / float64(p.ns.Len()-dof)
+	return math.Sqrt(variance)
+}
+
+// Operation executes a custom operation on two NumericSeries element-wise
+// op is called from several goroutines at once and must not have side effects
+// use an empty string for name to get the default name
+func (p *ParallelNumericSeries[T, R]) Operation(other *NumericSeries[T, R], op func(a, b T) T, name string) *NumericSeries[T, R] {
+	ns := p.ns
+	if p.serial() {
+		return ns.Operation(other, op, name)
+	}
+	if ns.Len() != other.Len() {
+		panic("series must be of the same length to perform operation")
+	}
+
+	if name == "" {
+		name = ns.name + "_op_" + other.name
+	}
+
+	resultValues := make([]T, ns.Len())
+	p.engine.run(ns.Len(), func(_, start, end int) {
+		for i := start; i < end; i++ {
+			resultValues[i] = op(ns.values[i], other.values[i])
+		}
+	})
+	return NewNumericSeries[T, R](name, resultValues, ns.index)
+}
+
+// Map applies f to every value and returns the results as a new Series
+// f is called from several goroutines at once and must not have side effects
+func (p *ParallelNumericSeries[T, R]) Map(f func(v T) T) *NumericSeries[T, R] {
+	ns := p.ns
+	if p.serial() {
+		return ns.Map(f)
+	}
+	mapped := make([]T, ns.Len())
+	p.engine.run(ns.Len(), func(_, start, end int) {
+		for i := start; i < end; i++ {
+			mapped[i] = f(ns.values[i])
+		}
+	})
+	return NewNumericSeries[T, R](ns.name, mapped, ns.index)
+}
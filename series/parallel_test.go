@@ -0,0 +1,180 @@
+package series
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func randomNumericSeries(n int) *NumericSeries[float64, int] {
+	rng := rand.New(rand.NewSource(42))
+	values := make([]float64, n)
+	for i := range values {
+		values[i] = rng.NormFloat64() * 1000
+	}
+	return NewIndexNumericSeries("random", values)
+}
+
+func TestEngine_Reductions(t *testing.T) {
+	ns := randomNumericSeries(250_000)
+	engine := &Engine{Workers: 4, Threshold: 1000, ChunkSize: 10_000}
+	p := ns.Parallel(engine)
+
+	if math.Abs(p.Sum()-ns.Sum()) > 1e-6 {
+		t.Errorf("expected sum %f, got %f", ns.Sum(), p.Sum())
+	}
+	if math.Abs(p.Mean()-ns.Mean()) > 1e-9 {
+		t.Errorf("expected mean %f, got %f", ns.Mean(), p.Mean())
+	}
+	if p.Min() != ns.Min() {
+		t.Errorf("expected min %f, got %f", ns.Min(), p.Min())
+	}
+	if p.Max() != ns.Max() {
+		t.Errorf("expected max %f, got %f", ns.Max(), p.Max())
+	}
+	if math.Abs(p.StdDev(1)-ns.StdDev(1)) > 1e-9 {
+		t.Errorf("expected std dev %f, got %f", ns.StdDev(1), p.StdDev(1))
+	}
+}
+
+func TestEngine_IntegerSum(t *testing.T) {
+	values := make([]int64, 123_457)
+	for i := range values {
+		values[i] = int64(i)
+	}
+	ns := NewIndexNumericSeries("ints", values)
+
+	p := ns.Parallel(&Engine{Workers: 3, Threshold: 0, ChunkSize: 1000})
+	if p.Sum() != ns.Sum() {
+		t.Errorf("expected sum %d, got %d", ns.Sum(), p.Sum())
+	}
+}
+
+func TestEngine_Deterministic(t *testing.T) {
+	ns := randomNumericSeries(200_000)
+
+	expectedSum := ns.Parallel(&Engine{Workers: 1, Threshold: 0, ChunkSize: 4096}).Sum()
+	expectedStd := ns.Parallel(&Engine{Workers: 1, Threshold: 0, ChunkSize: 4096}).StdDev(0)
+	for _, workers := range []int{2, 3, 8, 16} {
+		p := ns.Parallel(&Engine{Workers: workers, Threshold: 0, ChunkSize: 4096})
+		for range 5 {
+			if sum := p.Sum(); sum != expectedSum {
+				t.Fatalf("sum with %d workers changed from %v to %v", workers, expectedSum, sum)
+			}
+			if std := p.StdDev(0); std != expectedStd {
+				t.Fatalf("std dev with %d workers changed from %v to %v", workers, expectedStd, std)
+			}
+		}
+	}
+}
+
+func TestEngine_MinMaxNaN(t *testing.T) {
+	values := make([]float64, 100)
+	for i := range values {
+		values[i] = float64(i)
+	}
+	values[50] = math.NaN()
+	values[60] = -5
+	ns := NewIndexNumericSeries("x", values)
+	engine := &Engine{Workers: 4, Threshold: 0, ChunkSize: 10}
+
+	if ns.Parallel(engine).Min() != -5 {
+		t.Errorf("expected min -5, got %f", ns.Parallel(engine).Min())
+	}
+
+	values[0] = math.NaN()
+	ns = NewIndexNumericSeries("x", values)
+	if !math.IsNaN(ns.Parallel(engine).Max()) || !math.IsNaN(ns.Max()) {
+		t.Error("expected NaN max like NumericSeries.Max when the first value is NaN")
+	}
+}
+
+func TestEngine_OperationAndMap(t *testing.T) {
+	ns := randomNumericSeries(50_000)
+	other := randomNumericSeries(50_000)
+	engine := &Engine{Workers: 4, Threshold: 0, ChunkSize: 999}
+
+	add := func(a, b float64) float64 { return a + b }
+	expected := ns.Operation(other, add, "")
+	result := ns.Parallel(engine).Operation(other, add, "")
+	for i := range expected.Len() {
+		if result.At(i) != expected.At(i) {
+			t.Fatalf("expected %f at position %d, got %f", expected.At(i), i, result.At(i))
+		}
+	}
+	if result.Name() != expected.Name() {
+		t.Errorf("expected name %s, got %s", expected.Name(), result.Name())
+	}
+
+	double := func(v float64) float64 { return v * 2 }
+	mapped := ns.Parallel(engine).Map(double)
+	serial := ns.Map(double)
+	for i := range serial.Len() {
+		if mapped.At(i) != serial.At(i) {
+			t.Fatalf("expected %f at position %d, got %f", serial.At(i), i, mapped.At(i))
+		}
+	}
+}
+
+func TestEngine_BelowThreshold(t *testing.T) {
+	ns := NewIndexNumericSeries("small", []int{1, 2, 3})
+
+	p := ns.Parallel(NewEngine())
+	if p.Sum() != 6 || p.Min() != 1 || p.Max() != 3 {
+		t.Error("unexpected results below threshold")
+	}
+
+	// the serial fallback must give the same results as a single chunk
+	floats := randomNumericSeries(5000)
+	single := floats.Parallel(&Engine{Workers: 1, Threshold: 0, ChunkSize: 5000})
+	fallback := floats.Parallel(&Engine{Workers: 4, Threshold: 5001, ChunkSize: 100})
+	if single.Sum() != fallback.Sum() || single.StdDev(1) != fallback.StdDev(1) {
+		t.Error("expected the serial fallback to match a single chunk")
+	}
+	square := func(v float64) float64 { return v * v }
+	if single.Map(square).At(17) != fallback.Map(square).At(17) {
+		t.Error("expected the serial fallback to map the same values")
+	}
+}
+
+// BenchmarkEngine compares the serial NumericSeries methods with the Engine below, at and far above its Threshold
+// run it with -cpu 1,2,4,8 to see how the Engine scales with the number of CPUs, CI runs it on every push
+func BenchmarkEngine(b *testing.B) {
+	square := func(v float64) float64 { return v * v }
+	for _, n := range []int{10_000, NewEngine().Threshold, 4_000_000} {
+		ns := randomNumericSeries(n)
+		p := ns.Parallel(NewEngine())
+
+		b.Run(fmt.Sprintf("Sum/%d/serial", n), func(b *testing.B) {
+			for b.Loop() {
+				ns.Sum()
+			}
+		})
+		b.Run(fmt.Sprintf("Sum/%d/parallel", n), func(b *testing.B) {
+			for b.Loop() {
+				p.Sum()
+			}
+		})
+		b.Run(fmt.Sprintf("StdDev/%d/serial", n), func(b *testing.B) {
+			for b.Loop() {
+				ns.StdDev(1)
+			}
+		})
+		b.Run(fmt.Sprintf("StdDev/%d/parallel", n), func(b *testing.B) {
+			for b.Loop() {
+				p.StdDev(1)
+			}
+		})
+		b.Run(fmt.Sprintf("Map/%d/serial", n), func(b *testing.B) {
+			for b.Loop() {
+				ns.Map(square)
+			}
+		})
+		b.Run(fmt.Sprintf("Map/%d/parallel", n), func(b *testing.B) {
+			for b.Loop() {
+				p.Map(square)
+			}
+		})
+	}
+}
@@ -0,0 +1,1107 @@
+package series
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/bits"
+	"slices"
+	"time"
+)
+
+// physical types of Parquet columns
+const (
+	parquetBoolean   = 0
+	parquetInt32     = 1
+	parquetInt64     = 2
+	parquetInt96     = 3
+	parquetFloat     = 4
+	parquetDouble    = 5
+	parquetByteArray = 6
+)
+
+// repetition types of schema elements
+const (
+	parquetRequired = 0
+	parquetOptional = 1
+	parquetRepeated = 2
+)
+
+// converted types, the annotations used before logical types were introduced
+const (
+	parquetUTF8            = 0
+	parquetTimestampMillis = 9
+	parquetTimestampMicros = 10
+	parquetUint8           = 11
+	parquetInt8            = 15
+)
+
+// ids of the LogicalType union and the TimeUnit union
+const (
+	parquetLogicalString    = 1
+	parquetLogicalTimestamp = 8
+	parquetLogicalInteger   = 10
+
+	parquetMillis = 1
+	parquetMicros = 2
+	parquetNanos  = 3
+)
+
+// encodings
+const (
+	parquetPlain           = 0
+	parquetPlainDictionary = 2
+	parquetRLE             = 3
+	parquetRLEDictionary   = 8
+)
+
+// compression codecs
+const (
+	parquetCodecNone = 0
+	parquetCodecGzip = 2
+)
+
+// page types
+const (
+	parquetDataPage       = 0
+	parquetDictionaryPage = 2
+	parquetDataPageV2     = 3
+)
+
+// parquetMagic starts and ends every Parquet file
+var parquetMagic = []byte("PAR1")
+
+// julianUnixEpoch is the julian day of 1970-01-01, used by INT96 timestamps
+const julianUnixEpoch = 2440588
+
+// ParquetCompression is the codec used to compress the pages of a Parquet file
+type ParquetCompression int
+
+const (
+	ParquetUncompressed ParquetCompression = iota
+	ParquetGzip
+)
+
+// ParquetWriteOptions configures how a Parquet file is written
+type ParquetWriteOptions struct {
+	Compression ParquetCompression
+	// Dictionary writes the distinct values of every column but bool columns into a dictionary page
+	// and the values as indices into it, which is smaller for columns with many repeated values
+	Dictionary bool
+	// RowGroupSize is the maximum number of rows of a row group, 0 writes all rows into one row group
+	RowGroupSize int
+	// DataPageV2 writes version 2 data pages, which keep the definition levels uncompressed
+	DataPageV2 bool
+}
+
+// parquetColumn is a column converted to its physical Parquet type
+// values is one of []bool, []int32, []int64, []float32, []float64 and []string
+type parquetColumn struct {
+	name     string
+	physical int32
+	element  thriftStruct
+	values   any
+	valid    []bool
+}
+
+// parquetField is a leaf column of the schema of a Parquet file
+type parquetField struct {
+	name      string
+	physical  int64
+	optional  bool
+	converted int64
+	logical   thriftStruct
+}
+
+// parquetFile is an opened Parquet file
+type parquetFile struct {
+	r         io.ReaderAt
+	size      int64
+	numRows   int64
+	fields    []parquetField
+	rowGroups []thriftStruct
+}
+
+// WriteParquet writes the Series to a Parquet file
+// the values are written to a column named like the Series and the index to ArrowIndexColumn
+func WriteParquet[T comparable, R comparable](w io.Writer, s *Series[T, R], opts ParquetWriteOptions) error {
+	return NewDataFrame[R](s).WriteParquet(w, opts)
+}
+
+// WriteParquet writes the DataFrame to a Parquet file
+// the index is written as the last column, named ArrowIndexColumn like pyarrow does for a pandas index
+// supported value and label types are the Numeric types, string, bool and time.Time which is written as a UTC nanosecond timestamp
+// missing values of a CategoricalSeries are written as nulls
+func (df *DataFrame[R]) WriteParquet(w io.Writer, opts ParquetWriteOptions) error {
+	columns := make([]parquetColumn, 0, len(df.columns)+1)
+	for _, c := range df.columns {
+		pc, err := toParquetColumn(columnToArrow(c))
+		if err != nil {
+			return err
+		}
+		columns = append(columns, pc)
+	}
+	pc, err := toParquetColumn(arrowColumn{name: ArrowIndexColumn, values: df.index})
+	if err != nil {
+		return err
+	}
+	return writeParquetFile(w, append(columns, pc), len(df.index), opts)
+}
+
+// ReadParquet reads a Series from the given column of a Parquet file of the given size
+// column "" reads the first column which is not ArrowIndexColumn
+// numeric columns can be read into any Numeric type and are converted like a Go conversion
+// without an index column R must be int and the positions are used as labels
+// nulls are read as NaN for floating point values and as the zero value otherwise
+func ReadParquet[T comparable, R comparable](r io.ReaderAt, size int64, column string) (*Series[T, R], error) {
+	pf, err := openParquet(r, size)
+	if err != nil {
+		return nil, err
+	}
+
+	if column == "" {
+		for _, f := range pf.fields {
+			if f.name != ArrowIndexColumn {
+				column = f.name
+				break
+			}
+		}
+		if column == "" {
+			return nil, errors.New("parquet file has no value column")
+		}
+	}
+
+	columns, err := pf.readColumns([]string{column})
+	if err != nil {
+		return nil, err
+	}
+	index, err := arrowIndex[R](columns, int(pf.numRows))
+	if err != nil {
+		return nil, err
+	}
+	values, ok := convertArrowValues[T](columns[0].values)
+	if !ok {
+		return nil, fmt.Errorf("cannot read parquet column %q of type %T into %T", column, columns[0].values, values)
+	}
+	return NewSeries(column, values, index), nil
+}
+
+// ReadNumericParquet reads a NumericSeries from the given column of a Parquet file like ReadParquet
+func ReadNumericParquet[T Numeric, R comparable](r io.ReaderAt, size int64, column string) (*NumericSeries[T, R], error) {
+	s, err := ReadParquet[T, R](r, size, column)
+	if err != nil {
+		return nil, err
+	}
+	return &NumericSeries[T, R]{Series: s}, nil
+}
+
+// ReadDataFrameParquet reads a DataFrame from a Parquet file of the given size
+// only the given columns are read in the given order, without columns every column is read
+// every column becomes a Series of the Go type matching its Parquet type,
+// e.g. int64 for 64 bit integers and time.Time for timestamps
+// without an index column R must be int and the positions are used as labels
+// nulls are read as NaN for floating point values and as the zero value otherwise
+func ReadDataFrameParquet[R comparable](r io.ReaderAt, size int64, columns ...string) (*DataFrame[R], error) {
+	pf, err := openParquet(r, size)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(columns) == 0 {
+		for _, f := range pf.fields {
+			if f.name != ArrowIndexColumn {
+				columns = append(columns, f.name)
+			}
+		}
+	}
+	read, err := pf.readColumns(columns)
+	if err != nil {
+		return nil, err
+	}
+
+	index, err := arrowIndex[R](read, int(pf.numRows))
+	if err != nil {
+		return nil, err
+	}
+	var dfColumns []Column[R]
+	for _, c := range read {
+		if c.name != ArrowIndexColumn {
+			dfColumns = append(dfColumns, arrowToColumn(c, index))
+		}
+	}
+	if len(dfColumns) == 0 {
+		return nil, errors.New("parquet file has no value column")
+	}
+	return NewDataFrame(dfColumns...), nil
+}
+
+// toParquetColumn converts the values to their physical type and returns them with the schema element
+func toParquetColumn(c arrowColumn) (parquetColumn, error) {
+	pc := parquetColumn{name: c.name, valid: c.valid}
+	switch v := c.values.(type) {
+	case []int:
+		pc.physical, pc.values = parquetInt64, castSlice[int64](v)
+	case []int8:
+		pc.physical, pc.values, pc.element = parquetInt32, castSlice[int32](v), parquetInteger(8, true)
+	case []int16:
+		pc.physical, pc.values, pc.element = parquetInt32, castSlice[int32](v), parquetInteger(16, true)
+	case []int32:
+		pc.physical, pc.values = parquetInt32, v
+	case []int64:
+		pc.physical, pc.values = parquetInt64, v
+	case []uint:
+		pc.physical, pc.values, pc.element = parquetInt64, castSlice[int64](v), parquetInteger(64, false)
+	case []uint8:
+		pc.physical, pc.values, pc.element = parquetInt32, castSlice[int32](v), parquetInteger(8, false)
+	case []uint16:
+		pc.physical, pc.values, pc.element = parquetInt32, castSlice[int32](v), parquetInteger(16, false)
+	case []uint32:
+		pc.physical, pc.values, pc.element = parquetInt32, castSlice[int32](v), parquetInteger(32, false)
+	case []uint64:
+		pc.physical, pc.values, pc.element = parquetInt64, castSlice[int64](v), parquetInteger(64, false)
+	case []float32:
+		pc.physical, pc.values = parquetFloat, v
+	case []float64:
+		pc.physical, pc.values = parquetDouble, v
+	case []bool:
+		pc.physical, pc.values = parquetBoolean, v
+	case []string:
+		pc.physical, pc.values = parquetByteArray, v
+		pc.element = thriftStruct{
+			{6, int32(parquetUTF8)},
+			{10, thriftStruct{{parquetLogicalString, thriftStruct{}}}},
+		}
+	case []time.Time:
+		nanos := make([]int64, len(v))
+		for i, t := range v {
+			nanos[i] = t.UnixNano()
+		}
+		pc.physical, pc.values = parquetInt64, nanos
+		timestamp := thriftStruct{{1, true}, {2, thriftStruct{{parquetNanos, thriftStruct{}}}}}
+		pc.element = thriftStruct{{10, thriftStruct{{parquetLogicalTimestamp, timestamp}}}}
+	default:
+		return pc, fmt.Errorf("column %q: cannot write values of type %T to parquet", c.name, c.values)
+	}
+	return pc, nil
+}
+
+// parquetInteger returns the converted and logical type of an integer column
+func parquetInteger(bitWidth int, signed bool) thriftStruct {
+	converted := parquetInt8 + bits.TrailingZeros(uint(bitWidth/8))
+	if !signed {
+		converted = parquetUint8 + bits.TrailingZeros(uint(bitWidth/8))
+	}
+	integer := thriftStruct{{1, int8(bitWidth)}, {2, signed}}
+	return thriftStruct{
+		{6, int32(converted)},
+		{10, thriftStruct{{parquetLogicalInteger, integer}}},
+	}
+}
+
+// parquetWriter writes a Parquet file and keeps track of the offset
+type parquetWriter struct {
+	w      io.Writer
+	offset int64
+	err    error
+	opts   ParquetWriteOptions
+}
+
+// write writes b unless an earlier write failed
+func (pw *parquetWriter) write(b []byte) {
+	if pw.err != nil {
+		return
+	}
+	n, err := pw.w.Write(b)
+	pw.offset += int64(n)
+	pw.err = err
+}
+
+// writeParquetFile writes the columns in row groups followed by the file metadata
+func writeParquetFile(w io.Writer, columns []parquetColumn, length int, opts ParquetWriteOptions) error {
+	pw := &parquetWriter{w: w, opts: opts}
+	pw.write(parquetMagic)
+
+	schema := []thriftStruct{{{4, "schema"}, {5, int32(len(columns))}}}
+	for _, c := range columns {
+		repetition := parquetRequired
+		if c.valid != nil {
+			repetition = parquetOptional
+		}
+		element := thriftStruct{{1, c.physical}, {3, int32(repetition)}, {4, c.name}}
+		schema = append(schema, append(element, c.element...))
+	}
+
+	groupSize := opts.RowGroupSize
+	if groupSize <= 0 {
+		groupSize = length
+	}
+	var rowGroups []thriftStruct
+	for start := 0; start < length; start += groupSize {
+		end := min(start+groupSize, length)
+		chunks := make([]thriftStruct, len(columns))
+		total := int64(0)
+		for i, c := range columns {
+			chunk, size, err := pw.writeChunk(c, start, end)
+			if err != nil {
+				return err
+			}
+			chunks[i] = chunk
+			total += size
+		}
+		rowGroups = append(rowGroups, thriftStruct{{1, chunks}, {2, total}, {3, int64(end - start)}})
+	}
+
+	footer := appendThrift(nil, thriftStruct{
+		{1, int32(1)},
+		{2, schema},
+		{3, int64(length)},
+		{4, rowGroups},
+		{6, "pango"},
+	})
+	pw.write(footer)
+	pw.write(binary.LittleEndian.AppendUint32(nil, uint32(len(footer))))
+	pw.write(parquetMagic)
+	return pw.err
+}
+
+// writeChunk writes the rows from start to end of the column and returns the ColumnChunk and its uncompressed size
+func (pw *parquetWriter) writeChunk(c parquetColumn, start, end int) (thriftStruct, int64, error) {
+	var valid []bool
+	if c.valid != nil {
+		valid = c.valid[start:end]
+	}
+	switch v := c.values.(type) {
+	case []bool:
+		return writeParquetChunk(pw, c, v[start:end], valid, packBits)
+	case []int32:
+		return writeParquetChunk(pw, c, v[start:end], valid, plainFixed[int32])
+	case []int64:
+		return writeParquetChunk(pw, c, v[start:end], valid, plainFixed[int64])
+	case []float32:
+		return writeParquetChunk(pw, c, v[start:end], valid, plainFixed[float32])
+	case []float64:
+		return writeParquetChunk(pw, c, v[start:end], valid, plainFixed[float64])
+	case []string:
+		return writeParquetChunk(pw, c, v[start:end], valid, plainStrings)
+	}
+	panic(fmt.Sprintf("unexpected parquet values %T", c.values))
+}
+
+// writeParquetChunk writes the column chunk holding values, valid is nil for required columns
+func writeParquetChunk[S comparable](pw *parquetWriter, c parquetColumn, values []S, valid []bool, plain func([]S) []byte) (thriftStruct, int64, error) {
+	chunkStart := pw.offset
+	var levels []byte
+	nulls := 0
+	if valid != nil {
+		defined := make([]uint32, len(valid))
+		present := make([]S, 0, len(values))
+		for i, ok := range valid {
+			if ok {
+				defined[i] = 1
+				present = append(present, values[i])
+			}
+		}
+		levels = appendHybrid(nil, defined, 1)
+		nulls = len(values) - len(present)
+		values = present
+	}
+
+	var uncompressed, compressed int64
+	encodings := []int32{parquetPlain, parquetRLE}
+	encoding := int32(parquetPlain)
+	var data []byte
+	dictionaryOffset := int64(-1)
+	if pw.opts.Dictionary && c.physical != parquetBoolean {
+		positions := make(map[S]uint32)
+		var dictionary []S
+		indices := make([]uint32, len(values))
+		// NaN is never equal to itself so the map would get an entry for every NaN, they share one instead
+		nan := -1
+		for i, v := range values {
+			if isNaN(v) {
+				if nan < 0 {
+					nan = len(dictionary)
+					dictionary = append(dictionary, v)
+				}
+				indices[i] = uint32(nan)
+				continue
+			}
+			p, ok := positions[v]
+			if !ok {
+				p = uint32(len(dictionary))
+				positions[v] = p
+				dictionary = append(dictionary, v)
+			}
+			indices[i] = p
+		}
+
+		dictionaryOffset = pw.offset
+		u, cs, err := pw.writePage(parquetDictionaryPage, 7, thriftStruct{
+			{1, int32(len(dictionary))},
+			{2, int32(parquetPlain)},
+		}, nil, plain(dictionary))
+		if err != nil {
+			return nil, 0, err
+		}
+		uncompressed, compressed = u, cs
+
+		bitWidth := max(bits.Len(uint(max(len(dictionary)-1, 0))), 1)
+		data = appendHybrid([]byte{byte(bitWidth)}, indices, bitWidth)
+		encoding = parquetRLEDictionary
+		encodings = append(encodings, parquetRLEDictionary)
+	} else {
+		data = plain(values)
+	}
+
+	dataOffset := pw.offset
+	n := int32(len(values) + nulls)
+	var u, cs int64
+	var err error
+	if pw.opts.DataPageV2 {
+		u, cs, err = pw.writePage(parquetDataPageV2, 8, thriftStruct{
+			{1, n},
+			{2, int32(nulls)},
+			{3, n},
+			{4, encoding},
+			{5, int32(len(levels))},
+			{6, int32(0)},
+			{7, pw.opts.Compression != ParquetUncompressed},
+		}, levels, data)
+	} else {
+		if levels != nil {
+			levels = append(binary.LittleEndian.AppendUint32(nil, uint32(len(levels))), levels...)
+		}
+		u, cs, err = pw.writePage(parquetDataPage, 5, thriftStruct{
+			{1, n},
+			{2, encoding},
+			{3, int32(parquetRLE)},
+			{4, int32(parquetRLE)},
+		}, nil, append(levels, data...))
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	uncompressed += u
+	compressed += cs
+
+	codec := int32(parquetCodecNone)
+	if pw.opts.Compression == ParquetGzip {
+		codec = parquetCodecGzip
+	}
+	meta := thriftStruct{
+		{1, c.physical},
+		{2, encodings},
+		{3, []string{c.name}},
+		{4, codec},
+		{5, int64(n)},
+		{6, uncompressed},
+		{7, compressed},
+		{9, dataOffset},
+	}
+	if dictionaryOffset >= 0 {
+		meta = append(meta, thriftField{11, dictionaryOffset})
+	}
+	return thriftStruct{{2, chunkStart}, {3, meta}}, uncompressed, pw.err
+}
+
+// writePage writes a page header and page and returns their uncompressed and compressed size
+// typeHeader is the header of the page type stored in the field with the given id of the page header,
+// levels are written uncompressed in front of the compressed data
+func (pw *parquetWriter) writePage(pageType int32, id int16, typeHeader thriftStruct, levels, data []byte) (int64, int64, error) {
+	compressed := data
+	if pw.opts.Compression == ParquetGzip {
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		if _, err := zw.Write(data); err != nil {
+			return 0, 0, err
+		}
+		if err := zw.Close(); err != nil {
+			return 0, 0, err
+		}
+		compressed = buf.Bytes()
+	}
+
+	headerBytes := appendThrift(nil, thriftStruct{
+		{1, pageType},
+		{2, int32(len(levels) + len(data))},
+		{3, int32(len(levels) + len(compressed))},
+		{id, typeHeader},
+	})
+	pw.write(headerBytes)
+	pw.write(levels)
+	pw.write(compressed)
+
+	size := int64(len(headerBytes) + len(levels))
+	return size + int64(len(data)), size + int64(len(compressed)), pw.err
+}
+
+// plainFixed encodes fixed width values little endian
+func plainFixed[S Numeric](values []S) []byte {
+	data, err := binary.Append(nil, binary.LittleEndian, values)
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
+
+// plainStrings encodes every string as its 4 byte length followed by its bytes
+func plainStrings(values []string) []byte {
+	var data []byte
+	for _, v := range values {
+		data = binary.LittleEndian.AppendUint32(data, uint32(len(v)))
+		data = append(data, v...)
+	}
+	return data
+}
+
+// appendHybrid appends the values with the RLE/bit-packed hybrid encoding
+// runs of at least 8 equal values are run length encoded, everything between them is bit-packed in groups of 8
+func appendHybrid(buf []byte, values []uint32, bitWidth int) []byte {
+	runLength := func(i int) int {
+		n := 1
+		for i+n < len(values) && values[i+n] == values[i] {
+			n++
+		}
+		return n
+	}
+
+	for i := 0; i < len(values); {
+		if run := runLength(i); run >= 8 {
+			buf = binary.AppendUvarint(buf, uint64(run)<<1)
+			for b := 0; b < (bitWidth+7)/8; b++ {
+				buf = append(buf, byte(values[i]>>(8*b)))
+			}
+			i += run
+			continue
+		}
+
+		start := i
+		for i < len(values) {
+			i += 8
+			if i < len(values) && runLength(i) >= 8 {
+				break
+			}
+		}
+		groups := (i - start + 7) / 8
+		buf = binary.AppendUvarint(buf, uint64(groups)<<1|1)
+		packed := make([]byte, groups*bitWidth)
+		for j := range 8 * groups {
+			v := uint32(0)
+			if start+j < len(values) {
+				v = values[start+j]
+			}
+			for b := range bitWidth {
+				if v&(1<<b) != 0 {
+					bit := j*bitWidth + b
+					packed[bit/8] |= 1 << (bit % 8)
+				}
+			}
+		}
+		buf = append(buf, packed...)
+		i = min(i, len(values))
+	}
+	return buf
+}
+
+// decodeHybrid decodes n values of the RLE/bit-packed hybrid encoding
+func decodeHybrid(data []byte, bitWidth int, n int) ([]uint32, error) {
+	if bitWidth > 32 {
+		return nil, fmt.Errorf("invalid bit width %d", bitWidth)
+	}
+
+	var values []uint32
+	for len(values) < n {
+		header, k := binary.Uvarint(data)
+		if k <= 0 {
+			return nil, errors.New("parquet levels or indices are too short")
+		}
+		data = data[k:]
+
+		if header&1 == 1 {
+			// every group of 8 values takes bitWidth bytes, the number of groups comes from the file
+			// so it is bounded before multiplying
+			groups := header >> 1
+			if bitWidth > 0 && groups > uint64(len(data)/bitWidth) {
+				return nil, errors.New("parquet bit-packed run is too short")
+			}
+			size := int(groups) * bitWidth
+			count := min(groups, uint64(n)) * 8
+			for j := range int(min(count, uint64(n-len(values)))) {
+				v := uint32(0)
+				for b := range bitWidth {
+					bit := j*bitWidth + b
+					if data[bit/8]&(1<<(bit%8)) != 0 {
+						v |= 1 << b
+					}
+				}
+				values = append(values, v)
+			}
+			data = data[size:]
+			continue
+		}
+
+		width := (bitWidth + 7) / 8
+		if width > len(data) {
+			return nil, errors.New("parquet run length encoded run is too short")
+		}
+		v := uint32(0)
+		for b := range width {
+			v |= uint32(data[b]) << (8 * b)
+		}
+		data = data[width:]
+		for range min(header>>1, uint64(n-len(values))) {
+			values = append(values, v)
+		}
+	}
+	return values, nil
+}
+
+// openParquet reads the metadata of a Parquet file of the given size
+func openParquet(r io.ReaderAt, size int64) (*parquetFile, error) {
+	if size < 12 {
+		return nil, errors.New("file is too small to be parquet")
+	}
+	var tail [8]byte
+	if _, err := r.ReadAt(tail[:], size-8); err != nil {
+		return nil, fmt.Errorf("reading parquet footer: %w", err)
+	}
+	var head [4]byte
+	if _, err := r.ReadAt(head[:], 0); err != nil {
+		return nil, fmt.Errorf("reading parquet header: %w", err)
+	}
+	if !bytes.Equal(head[:], parquetMagic) || !bytes.Equal(tail[4:], parquetMagic) {
+		return nil, errors.New("not a parquet file")
+	}
+
+	footerSize := int64(binary.LittleEndian.Uint32(tail[:]))
+	if footerSize > size-12 {
+		return nil, errors.New("parquet footer is larger than the file")
+	}
+	footer := make([]byte, footerSize)
+	if _, err := r.ReadAt(footer, size-8-footerSize); err != nil {
+		return nil, fmt.Errorf("reading parquet footer: %w", err)
+	}
+	meta, _, err := readThrift(footer)
+	if err != nil {
+		return nil, fmt.Errorf("reading parquet footer: %w", err)
+	}
+
+	pf := &parquetFile{r: r, size: size, numRows: meta.int(3, 0)}
+	schema := meta.list(2)
+	if len(schema) == 0 {
+		return nil, errors.New("parquet file has no schema")
+	}
+	for _, e := range schema[1:] {
+		element, _ := e.(thriftStruct)
+		if element.int(5, 0) > 0 || element.int(3, parquetRequired) == parquetRepeated {
+			return nil, fmt.Errorf("nested parquet column %q is not supported", element.string(4))
+		}
+		pf.fields = append(pf.fields, parquetField{
+			name:      element.string(4),
+			physical:  element.int(1, -1),
+			optional:  element.int(3, parquetRequired) == parquetOptional,
+			converted: element.int(6, -1),
+			logical:   element.strct(10),
+		})
+	}
+
+	rows := int64(0)
+	for _, g := range meta.list(4) {
+		group, _ := g.(thriftStruct)
+		if len(group.list(1)) != len(pf.fields) {
+			return nil, errors.New("parquet row group does not match the schema")
+		}
+		rows += group.int(3, 0)
+		pf.rowGroups = append(pf.rowGroups, group)
+	}
+	if rows != pf.numRows || rows < 0 {
+		return nil, errors.New("parquet row groups do not match the number of rows")
+	}
+	return pf, nil
+}
+
+// readColumns reads the named columns and the index column if there is one
+func (pf *parquetFile) readColumns(names []string) ([]arrowColumn, error) {
+	positions := make([]int, 0, len(names)+1)
+	for _, name := range names {
+		i := slices.IndexFunc(pf.fields, func(f parquetField) bool { return f.name == name })
+		if i < 0 {
+			return nil, fmt.Errorf("parquet file has no column %q", name)
+		}
+		positions = append(positions, i)
+	}
+	if i := slices.IndexFunc(pf.fields, func(f parquetField) bool { return f.name == ArrowIndexColumn }); i >= 0 && !slices.Contains(positions, i) {
+		positions = append(positions, i)
+	}
+
+	columns := make([]arrowColumn, len(positions))
+	for j, i := range positions {
+		c, err := pf.readColumn(i)
+		if err != nil {
+			return nil, fmt.Errorf("parquet column %q: %w", pf.fields[i].name, err)
+		}
+		columns[j] = c
+	}
+	return columns, nil
+}
+
+// readColumn reads the column with the given position in all row groups
+func (pf *parquetFile) readColumn(i int) (arrowColumn, error) {
+	f := pf.fields[i]
+	var values any
+	var valid []bool
+	var err error
+	switch f.physical {
+	case parquetBoolean:
+		values, valid, err = readParquetColumn(pf, i, func(data []byte, n int) ([]bool, error) {
+			return unpackBits(nil, data, n)
+		})
+	case parquetInt32:
+		values, valid, err = readParquetColumn(pf, i, decodePlainFixed[int32])
+	case parquetInt64:
+		values, valid, err = readParquetColumn(pf, i, decodePlainFixed[int64])
+	case parquetInt96:
+		values, valid, err = readParquetColumn(pf, i, decodePlainInt96)
+	case parquetFloat:
+		values, valid, err = readParquetColumn(pf, i, decodePlainFixed[float32])
+	case parquetDouble:
+		values, valid, err = readParquetColumn(pf, i, decodePlainFixed[float64])
+	case parquetByteArray:
+		values, valid, err = readParquetColumn(pf, i, decodePlainStrings)
+	default:
+		err = fmt.Errorf("unsupported physical type %d", f.physical)
+	}
+	if err != nil {
+		return arrowColumn{}, err
+	}
+
+	values = f.logicalValues(values)
+	return arrowColumn{name: f.name, values: nullsToZero(values, valid), valid: valid}, nil
+}
+
+// logicalValues converts the physical values to the Go type of the logical or converted type of the field
+func (f parquetField) logicalValues(values any) any {
+	integer := f.logical.strct(parquetLogicalInteger)
+	timestamp := f.logical.strct(parquetLogicalTimestamp)
+	bitWidth, signed := integer.int(1, 0), integer.bool(2, true)
+	if integer == nil && f.converted >= parquetUint8 && f.converted < parquetInt8+4 {
+		bitWidth = int64(8 << ((f.converted - parquetUint8) % 4))
+		signed = f.converted >= parquetInt8
+	}
+
+	switch v := values.(type) {
+	case []int32:
+		switch {
+		case bitWidth == 8 && signed:
+			return castSlice[int8](v)
+		case bitWidth == 16 && signed:
+			return castSlice[int16](v)
+		case bitWidth == 8:
+			return castSlice[uint8](v)
+		case bitWidth == 16:
+			return castSlice[uint16](v)
+		case bitWidth == 32 && !signed:
+			return castSlice[uint32](v)
+		}
+	case []int64:
+		unit := int64(0)
+		switch {
+		case f.physical == parquetInt96:
+			unit = parquetNanos
+		case timestamp != nil:
+			unit = int64(timestamp.strct(2).union())
+		case f.converted == parquetTimestampMillis:
+			unit = parquetMillis
+		case f.converted == parquetTimestampMicros:
+			unit = parquetMicros
+		case bitWidth == 64 && !signed:
+			return castSlice[uint64](v)
+		}
+		if unit != 0 {
+			return parquetTimes(v, unit)
+		}
+	}
+	return values
+}
+
+// parquetTimes converts timestamps in the given unit since the unix epoch to UTC times
+func parquetTimes(values []int64, unit int64) []time.Time {
+	times := make([]time.Time, len(values))
+	for i, v := range values {
+		switch unit {
+		case parquetMillis:
+			times[i] = time.UnixMilli(v).UTC()
+		case parquetMicros:
+			times[i] = time.UnixMicro(v).UTC()
+		default:
+			times[i] = time.Unix(0, v).UTC()
+		}
+	}
+	return times
+}
+
+// readParquetColumn reads the values of the column with the given position from every row group
+// plain decodes n plainly encoded values, nulls are left as the zero value and marked in valid
+func readParquetColumn[S any](pf *parquetFile, i int, plain func(data []byte, n int) ([]S, error)) ([]S, []bool, error) {
+	var values []S
+	var valid []bool
+	if pf.fields[i].optional {
+		valid = []bool{}
+	}
+
+	for _, group := range pf.rowGroups {
+		chunk, _ := group.list(1)[i].(thriftStruct)
+		meta := chunk.strct(3)
+		if chunk.has(1) {
+			return nil, nil, errors.New("column chunks in other files are not supported")
+		}
+		if meta == nil {
+			return nil, nil, errors.New("column chunk has no metadata")
+		}
+
+		start := meta.int(9, -1)
+		if offset := meta.int(11, 0); offset > 0 && offset < start {
+			start = offset
+		}
+		size := meta.int(7, -1)
+		if start < 0 || size < 0 || start+size > pf.size {
+			return nil, nil, errors.New("column chunk is out of bounds of the file")
+		}
+		data := make([]byte, size)
+		if _, err := pf.r.ReadAt(data, start); err != nil {
+			return nil, nil, err
+		}
+
+		numValues := meta.int(5, 0)
+		if numValues != group.int(3, 0) {
+			return nil, nil, errors.New("column chunk does not match the number of rows")
+		}
+		var err error
+		values, valid, err = readParquetPages(data, meta.int(4, parquetCodecNone), numValues, values, valid, plain)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if valid != nil && !slices.Contains(valid, false) {
+		valid = nil
+	}
+	return values, valid, nil
+}
+
+// readParquetPages appends the values of all pages of a column chunk to values
+// valid is nil for required columns, otherwise it is appended to as well
+func readParquetPages[S any](data []byte, codec int64, numValues int64, values []S, valid []bool, plain func(data []byte, n int) ([]S, error)) ([]S, []bool, error) {
+	var dictionary []S
+	for read := int64(0); read < numValues; {
+		header, k, err := readThrift(data)
+		if err != nil {
+			return nil, nil, fmt.Errorf("reading page header: %w", err)
+		}
+		data = data[k:]
+		size := header.int(3, -1)
+		uncompressedSize := header.int(2, -1)
+		if size < 0 || size > int64(len(data)) || uncompressedSize < 0 {
+			return nil, nil, errors.New("page is out of bounds of the column chunk")
+		}
+		page := data[:size]
+		data = data[size:]
+
+		switch header.int(1, -1) {
+		case parquetDictionaryPage:
+			if page, err = parquetDecompress(codec, page, uncompressedSize); err != nil {
+				return nil, nil, err
+			}
+			if dictionary, err = plain(page, int(header.strct(7).int(1, 0))); err != nil {
+				return nil, nil, err
+			}
+
+		case parquetDataPage:
+			if page, err = parquetDecompress(codec, page, uncompressedSize); err != nil {
+				return nil, nil, err
+			}
+			dh := header.strct(5)
+			n := dh.int(1, 0)
+			if n <= 0 || n > numValues-read {
+				return nil, nil, errors.New("data page holds more values than the column chunk")
+			}
+			var levels []byte
+			if valid != nil {
+				if len(page) < 4 || int64(binary.LittleEndian.Uint32(page)) > int64(len(page)-4) {
+					return nil, nil, errors.New("definition levels are out of bounds of the page")
+				}
+				end := 4 + int(binary.LittleEndian.Uint32(page))
+				levels, page = page[4:end], page[end:]
+			}
+			if values, valid, err = appendParquetPage(values, valid, levels, page, int(n), dh.int(2, -1), dictionary, plain); err != nil {
+				return nil, nil, err
+			}
+			read += n
+
+		case parquetDataPageV2:
+			dh := header.strct(8)
+			n := dh.int(1, 0)
+			levelsSize := dh.int(5, 0) + dh.int(6, 0)
+			if n <= 0 || n > numValues-read {
+				return nil, nil, errors.New("data page holds more values than the column chunk")
+			}
+			if dh.int(6, 0) != 0 {
+				return nil, nil, errors.New("repetition levels are not supported")
+			}
+			if dh.int(5, 0) < 0 || levelsSize > int64(len(page)) || levelsSize > uncompressedSize {
+				return nil, nil, errors.New("definition levels are out of bounds of the page")
+			}
+			levels := page[:levelsSize]
+			page = page[levelsSize:]
+			if dh.bool(7, true) {
+				if page, err = parquetDecompress(codec, page, uncompressedSize-levelsSize); err != nil {
+					return nil, nil, err
+				}
+			}
+			if valid == nil {
+				levels = nil
+			}
+			if values, valid, err = appendParquetPage(values, valid, levels, page, int(n), dh.int(4, -1), dictionary, plain); err != nil {
+				return nil, nil, err
+			}
+			read += n
+		}
+		// other pages like index pages are skipped
+	}
+	return values, valid, nil
+}
+
+// appendParquetPage appends the n values of a data page, levels are the definition levels of an optional column
+func appendParquetPage[S any](values []S, valid []bool, levels, data []byte, n int, encoding int64, dictionary []S, plain func(data []byte, n int) ([]S, error)) ([]S, []bool, error) {
+	present := n
+	var defined []uint32
+	if valid != nil {
+		var err error
+		if defined, err = decodeHybrid(levels, 1, n); err != nil {
+			return nil, nil, err
+		}
+		present = 0
+		for _, d := range defined {
+			present += int(d)
+		}
+	}
+
+	var page []S
+	switch {
+	case present == 0:
+		// a page of nulls has no values to decode
+	case encoding == parquetPlain:
+		var err error
+		if page, err = plain(data, present); err != nil {
+			return nil, nil, err
+		}
+	case encoding == parquetPlainDictionary || encoding == parquetRLEDictionary:
+		if dictionary == nil || len(data) == 0 {
+			return nil, nil, errors.New("dictionary encoded page without dictionary")
+		}
+		indices, err := decodeHybrid(data[1:], int(data[0]), present)
+		if err != nil {
+			return nil, nil, err
+		}
+		page = make([]S, present)
+		for j, idx := range indices {
+			if int(idx) >= len(dictionary) {
+				return nil, nil, errors.New("dictionary index is out of range")
+			}
+			page[j] = dictionary[idx]
+		}
+	default:
+		return nil, nil, fmt.Errorf("unsupported encoding %d", encoding)
+	}
+
+	if valid == nil {
+		return append(values, page...), nil, nil
+	}
+	var zero S
+	next := 0
+	for _, d := range defined {
+		if d == 1 {
+			values = append(values, page[next])
+			next++
+		} else {
+			values = append(values, zero)
+		}
+		valid = append(valid, d == 1)
+	}
+	return values, valid, nil
+}
+
+// parquetDecompress returns the uncompressed page
+func parquetDecompress(codec int64, page []byte, size int64) ([]byte, error) {
+	switch codec {
+	case parquetCodecNone:
+		return page, nil
+	case parquetCodecGzip:
+		zr, err := gzip.NewReader(bytes.NewReader(page))
+		if err != nil {
+			return nil, err
+		}
+		data, err := io.ReadAll(io.LimitReader(zr, size+1))
+		if err != nil {
+			return nil, err
+		}
+		if int64(len(data)) != size {
+			return nil, errors.New("uncompressed page size does not match the page header")
+		}
+		return data, nil
+	}
+	return nil, fmt.Errorf("unsupported compression codec %d", codec)
+}
+
+// decodePlainFixed decodes n plainly encoded fixed width values
+func decodePlainFixed[S Numeric](data []byte, n int) ([]S, error) {
+	var zero S
+	// n comes from the page header, dividing instead of multiplying cannot overflow
+	if n < 0 || n > len(data)/binary.Size(zero) {
+		return nil, errors.New("parquet page holds fewer values than its header counts")
+	}
+	values := make([]S, n)
+	if _, err := binary.Decode(data, binary.LittleEndian, values); err != nil {
+		return nil, errors.New("parquet page holds fewer values than its header counts")
+	}
+	return values, nil
+}
+
+// decodePlainInt96 decodes n legacy INT96 timestamps to nanoseconds since the unix epoch
+// each is the nanoseconds of the day followed by the julian day
+func decodePlainInt96(data []byte, n int) ([]int64, error) {
+	if n < 0 || n > len(data)/12 {
+		return nil, errors.New("parquet page holds fewer values than its header counts")
+	}
+	values := make([]int64, n)
+	for i := range values {
+		nanos := int64(binary.LittleEndian.Uint64(data[12*i:]))
+		day := int64(binary.LittleEndian.Uint32(data[12*i+8:]))
+		values[i] = (day-julianUnixEpoch)*int64(24*time.Hour) + nanos
+	}
+	return values, nil
+}
+
+// decodePlainStrings decodes n plainly encoded byte arrays as strings
+func decodePlainStrings(data []byte, n int) ([]string, error) {
+	// each byte array has a 4 byte length prefix
+	if n < 0 || n > len(data)/4 {
+		return nil, errors.New("parquet page holds fewer values than its header counts")
+	}
+	values := make([]string, n)
+	for i := range values {
+		if len(data) < 4 {
+			return nil, errors.New("parquet data is too short")
+		}
+		size := binary.LittleEndian.Uint32(data)
+		if uint64(size) > uint64(len(data)-4) {
+			return nil, errors.New("parquet byte array is out of bounds")
+		}
+		values[i] = string(data[4 : 4+size])
+		data = data[4+size:]
+	}
+	return values, nil
+}
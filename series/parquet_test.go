@@ -0,0 +1,431 @@
+package series
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+func parquetDataFrame() *DataFrame[string] {
+	index := []string{"a", "b", "c", "d", "e", "f"}
+	return NewDataFrame[string](
+		NewSeries("i8", []int8{-128, 0, 127, 1, 1, 1}, index),
+		NewSeries("u16", []uint16{0, 65535, 2, 2, 2, 2}, index),
+		NewSeries("i64", []int{1 << 40, -1, 0, 5, 5, 5}, index),
+		NewSeries("u64", []uint64{math.MaxUint64, 0, 1, 1, 1, 1}, index),
+		NewSeries("f32", []float32{0.5, -1, 0, 0, 0, 0}, index),
+		NewSeries("f64", []float64{math.NaN(), math.Inf(-1), 1e300, 2, 2, 2}, index),
+		NewSeries("flag", []bool{true, false, true, true, false, false}, index),
+		NewSeries("name", []string{"x", "", "ünïcode", "x", "x", "y"}, index),
+		NewSeries("at", []time.Time{
+			time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC),
+			time.Unix(0, 0).UTC(),
+			time.Date(1950, 6, 1, 0, 0, 0, 0, time.UTC),
+			time.Unix(1, 0).UTC(),
+			time.Unix(2, 0).UTC(),
+			time.Unix(3, 0).UTC(),
+		}, index),
+		NewCategoricalSeries("grade", []string{"b", "x", "a", "", "b", "b"}, index, []string{"a", "b"}, false),
+	)
+}
+
+func checkParquetDataFrame(t *testing.T, df *DataFrame[string]) {
+	t.Helper()
+	want := parquetDataFrame()
+	if !slices.Equal(df.Index(), want.Index()) {
+		t.Errorf("unexpected index %v", df.Index())
+	}
+	if !slices.Equal(GetColumn[int8](df, "i8").Values(), GetColumn[int8](want, "i8").Values()) {
+		t.Errorf("unexpected i8 %v", GetColumn[int8](df, "i8").Values())
+	}
+	if !slices.Equal(GetColumn[uint16](df, "u16").Values(), GetColumn[uint16](want, "u16").Values()) {
+		t.Errorf("unexpected u16 %v", GetColumn[uint16](df, "u16").Values())
+	}
+	if !slices.Equal(GetColumn[int64](df, "i64").Values(), []int64{1 << 40, -1, 0, 5, 5, 5}) {
+		t.Errorf("unexpected i64 %v", GetColumn[int64](df, "i64").Values())
+	}
+	if !slices.Equal(GetColumn[uint64](df, "u64").Values(), GetColumn[uint64](want, "u64").Values()) {
+		t.Errorf("unexpected u64 %v", GetColumn[uint64](df, "u64").Values())
+	}
+	if !slices.Equal(GetColumn[float32](df, "f32").Values(), GetColumn[float32](want, "f32").Values()) {
+		t.Errorf("unexpected f32 %v", GetColumn[float32](df, "f32").Values())
+	}
+	f64 := GetColumn[float64](df, "f64").Values()
+	if !math.IsNaN(f64[0]) || !slices.Equal(f64[1:], GetColumn[float64](want, "f64").Values()[1:]) {
+		t.Errorf("unexpected f64 %v", f64)
+	}
+	if !slices.Equal(GetColumn[bool](df, "flag").Values(), GetColumn[bool](want, "flag").Values()) {
+		t.Errorf("unexpected flag %v", GetColumn[bool](df, "flag").Values())
+	}
+	if !slices.Equal(GetColumn[string](df, "name").Values(), GetColumn[string](want, "name").Values()) {
+		t.Errorf("unexpected name %v", GetColumn[string](df, "name").Values())
+	}
+	times := GetColumn[time.Time](df, "at").Values()
+	for i, v := range GetColumn[time.Time](want, "at").Values() {
+		if !times[i].Equal(v) {
+			t.Errorf("expected time %v, got %v", v, times[i])
+		}
+	}
+	// missing categories are written as nulls and read back as ""
+	if !slices.Equal(GetColumn[string](df, "grade").Values(), []string{"b", "", "a", "", "b", "b"}) {
+		t.Errorf("unexpected grade %v", GetColumn[string](df, "grade").Values())
+	}
+}
+
+func TestParquet_RoundTrip(t *testing.T) {
+	for _, opts := range []ParquetWriteOptions{
+		{},
+		{Compression: ParquetGzip},
+		{Dictionary: true},
+		{RowGroupSize: 4},
+		{DataPageV2: true},
+		{Compression: ParquetGzip, Dictionary: true, RowGroupSize: 1, DataPageV2: true},
+	} {
+		var buf bytes.Buffer
+		if err := parquetDataFrame().WriteParquet(&buf, opts); err != nil {
+			t.Fatalf("%+v: %v", opts, err)
+		}
+		df, err := ReadDataFrameParquet[string](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		if err != nil {
+			t.Fatalf("%+v: %v", opts, err)
+		}
+		checkParquetDataFrame(t, df)
+	}
+
+	t.Run("NaN values share one dictionary entry", func(t *testing.T) {
+		values := slices.Repeat([]float64{math.NaN()}, 1000)
+		values[500] = 1
+		twos := slices.Repeat([]float64{2}, 1000)
+		twos[500] = 1
+		// with one entry for all NaN values the file is as large as with any other repeated value
+		var dictionary, other bytes.Buffer
+		if err := WriteParquet(&dictionary, NewIndexSeries("v", values), ParquetWriteOptions{Dictionary: true}); err != nil {
+			t.Fatal(err)
+		}
+		if err := WriteParquet(&other, NewIndexSeries("v", twos), ParquetWriteOptions{Dictionary: true}); err != nil {
+			t.Fatal(err)
+		}
+		if dictionary.Len() != other.Len() {
+			t.Errorf("expected %d bytes, got %d", other.Len(), dictionary.Len())
+		}
+		read, err := ReadParquet[float64, int](bytes.NewReader(dictionary.Bytes()), int64(dictionary.Len()), "v")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !equalNaN(read.Values(), values) {
+			t.Error("expected the values to survive the dictionary")
+		}
+	})
+}
+
+// the golden file pins the exact bytes written, interop/parquet_test.go checks that arrow-go reads it
+func TestParquet_Golden(t *testing.T) {
+	var buf bytes.Buffer
+	if err := parquetDataFrame().WriteParquet(&buf, ParquetWriteOptions{Dictionary: true, RowGroupSize: 4}); err != nil {
+		t.Fatal(err)
+	}
+	checkGolden(t, "dataframe.parquet", buf.Bytes())
+
+	f, err := os.Open(filepath.Join("testdata", "dataframe.parquet"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		t.Fatal(err)
+	}
+	df, err := ReadDataFrameParquet[string](f, info.Size())
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkParquetDataFrame(t, df)
+}
+
+func TestParquet_ArrowGo(t *testing.T) {
+	read := func(name string) *DataFrame[string] {
+		t.Helper()
+		data, err := os.ReadFile(filepath.Join("testdata", name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		df, err := ReadDataFrameParquet[string](bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return df
+	}
+
+	// written by arrow-go from the rows of arrowgo.arrows, see interop/parquet_test.go
+	t.Run("dictionary encoded row groups", func(t *testing.T) {
+		checkArrowGoDataFrame(t, read("arrowgo.parquet"))
+	})
+	t.Run("INT96 timestamps", func(t *testing.T) {
+		checkArrowGoDataFrame(t, read("arrowgo_int96.parquet"))
+	})
+
+	t.Run("converted types", func(t *testing.T) {
+		data, err := os.ReadFile(filepath.Join("testdata", "arrowgo_legacy.parquet"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		df, err := ReadDataFrameParquet[int](bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(df.Index(), []int{0, 1, 2, 3, 4}) {
+			t.Errorf("unexpected index %v", df.Index())
+		}
+		millis := []time.Time{time.UnixMilli(-1500), time.UnixMilli(0), time.UnixMilli(1704164645123), time.UnixMilli(1704164645123), time.UnixMilli(4102444800000)}
+		if got := GetColumn[time.Time](df, "ts_ms").Values(); !slices.EqualFunc(got, millis, time.Time.Equal) {
+			t.Errorf("unexpected ts_ms %v", got)
+		}
+		micros := []time.Time{time.UnixMicro(1704164645123456), {}, time.UnixMicro(-1), time.UnixMicro(1704164645123456), {}}
+		if got := GetColumn[time.Time](df, "ts_us").Values(); !slices.EqualFunc(got, micros, time.Time.Equal) {
+			t.Errorf("unexpected ts_us %v", got)
+		}
+		if got := GetColumn[string](df, "name").Values(); !slices.Equal(got, []string{"x", "", "ünïcode", "x", ""}) {
+			t.Errorf("unexpected name %v", got)
+		}
+		if got := GetColumn[int8](df, "i8").Values(); !slices.Equal(got, []int8{-128, 127, 0, -1, -1}) {
+			t.Errorf("unexpected i8 %v", got)
+		}
+		if got := GetColumn[uint8](df, "u8").Values(); !slices.Equal(got, []uint8{0, 255, 1, 255, 255}) {
+			t.Errorf("unexpected u8 %v", got)
+		}
+	})
+}
+
+func TestParquet_Projection(t *testing.T) {
+	var buf bytes.Buffer
+	if err := parquetDataFrame().WriteParquet(&buf, ParquetWriteOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	r := bytes.NewReader(buf.Bytes())
+
+	df, err := ReadDataFrameParquet[string](r, r.Size(), "name", "i8")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(df.Columns(), []string{"name", "i8"}) {
+		t.Errorf("expected columns name and i8, got %v", df.Columns())
+	}
+	if !slices.Equal(df.Index(), []string{"a", "b", "c", "d", "e", "f"}) {
+		t.Errorf("unexpected index %v", df.Index())
+	}
+
+	if _, err := ReadDataFrameParquet[string](r, r.Size(), "missing"); err == nil {
+		t.Error("expected error for unknown column")
+	}
+
+	s, err := ReadParquet[float64, string](r, r.Size(), "u16")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Name() != "u16" || !slices.Equal(s.Values(), []float64{0, 65535, 2, 2, 2, 2}) {
+		t.Errorf("unexpected series %v", s)
+	}
+
+	ns, err := ReadNumericParquet[int, string](r, r.Size(), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ns.Name() != "i8" || ns.Sum() != 2 {
+		t.Errorf("expected first column i8 with sum 2, got %s with sum %d", ns.Name(), ns.Sum())
+	}
+}
+
+func TestParquet_Series(t *testing.T) {
+	var buf bytes.Buffer
+	s := NewIndexSeries("temperature", []float64{21.5, 22, math.NaN(), 19.25})
+	if err := WriteParquet(&buf, s, ParquetWriteOptions{Compression: ParquetGzip}); err != nil {
+		t.Fatal(err)
+	}
+	r := bytes.NewReader(buf.Bytes())
+
+	read, err := ReadNumericParquet[float64, int](r, r.Size(), "temperature")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if read.Len() != 4 || read.At(1) != 22 || !math.IsNaN(read.At(2)) || !slices.Equal(read.Index(), []int{0, 1, 2, 3}) {
+		t.Errorf("unexpected series %v", read)
+	}
+
+	if _, err := ReadParquet[string, int](r, r.Size(), "temperature"); err == nil {
+		t.Error("expected error reading floats as strings")
+	}
+}
+
+func TestParquet_ConvertedTypes(t *testing.T) {
+	var buf bytes.Buffer
+	columns := []parquetColumn{
+		{name: "millis", physical: parquetInt64, element: thriftStruct{{6, int32(parquetTimestampMillis)}}, values: []int64{1500, -1}},
+		{name: "u32", physical: parquetInt32, element: thriftStruct{{6, int32(parquetUint8 + 2)}}, values: []int32{-1, 7}},
+		{name: "opt", physical: parquetInt32, values: []int32{4, 0}, valid: []bool{true, false}},
+	}
+	if err := writeParquetFile(&buf, columns, 2, ParquetWriteOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	r := bytes.NewReader(buf.Bytes())
+	df, err := ReadDataFrameParquet[int](r, r.Size())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	millis := GetColumn[time.Time](df, "millis").Values()
+	if !millis[0].Equal(time.UnixMilli(1500)) || !millis[1].Equal(time.UnixMilli(-1)) {
+		t.Errorf("unexpected timestamps %v", millis)
+	}
+	if !slices.Equal(GetColumn[uint32](df, "u32").Values(), []uint32{math.MaxUint32, 7}) {
+		t.Errorf("unexpected u32 %v", GetColumn[uint32](df, "u32").Values())
+	}
+	if !slices.Equal(GetColumn[int32](df, "opt").Values(), []int32{4, 0}) {
+		t.Errorf("unexpected opt %v", GetColumn[int32](df, "opt").Values())
+	}
+}
+
+func TestDecodePlainInt96(t *testing.T) {
+	// 2000-01-01 12:00:00 UTC is julian day 2451545 and 12 hours into the day
+	data := make([]byte, 12)
+	nanos := uint64(12 * time.Hour)
+	for i := range 8 {
+		data[i] = byte(nanos >> (8 * i))
+	}
+	day := uint32(2451545)
+	for i := range 4 {
+		data[8+i] = byte(day >> (8 * i))
+	}
+
+	values, err := decodePlainInt96(data, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := time.Date(2000, 1, 1, 12, 0, 0, 0, time.UTC)
+	if got := parquetTimes(values, parquetNanos)[0]; !got.Equal(want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestHybrid(t *testing.T) {
+	t.Run("bit-packs like the specification", func(t *testing.T) {
+		// the example of the Parquet encoding specification, 0 to 7 with bit width 3
+		got := appendHybrid(nil, []uint32{0, 1, 2, 3, 4, 5, 6, 7}, 3)
+		want := []byte{0x03, 0x88, 0xC6, 0xFA}
+		if !bytes.Equal(got, want) {
+			t.Errorf("expected % x, got % x", want, got)
+		}
+	})
+
+	t.Run("run length encodes repeated values", func(t *testing.T) {
+		got := appendHybrid(nil, slices.Repeat([]uint32{300}, 100), 9)
+		want := []byte{200, 1, 0x2C, 0x01}
+		if !bytes.Equal(got, want) {
+			t.Errorf("expected % x, got % x", want, got)
+		}
+	})
+
+	t.Run("decodes what it encodes", func(t *testing.T) {
+		rng := rand.New(rand.NewSource(1))
+		for _, bitWidth := range []int{1, 2, 5, 8, 13, 32} {
+			values := make([]uint32, 1000)
+			for i := range values {
+				if rng.Intn(3) == 0 {
+					values[i] = uint32(rng.Int63()) & (1<<bitWidth - 1)
+				} else if i > 0 {
+					values[i] = values[i-1]
+				}
+			}
+			decoded, err := decodeHybrid(appendHybrid(nil, values, bitWidth), bitWidth, len(values))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(decoded, values) {
+				t.Errorf("bit width %d: decoded values differ", bitWidth)
+			}
+		}
+	})
+
+	t.Run("rejects run lengths which overflow", func(t *testing.T) {
+		// 2^60+1 groups of 16 bytes wrap around to 16 bytes when multiplied
+		data := binary.AppendUvarint(nil, (1<<60+1)<<1|1)
+		data = append(data, make([]byte, 16)...)
+		if _, err := decodeHybrid(data, 16, 100); err == nil {
+			t.Error("expected error for an overflowing bit-packed run")
+		}
+
+		data = binary.AppendUvarint(nil, math.MaxUint64)
+		if _, err := decodeHybrid(data, 1, 100); err == nil {
+			t.Error("expected error for the largest bit-packed run")
+		}
+	})
+
+	t.Run("rejects plain value counts which overflow", func(t *testing.T) {
+		// 12 and 4 times these counts wrap around to a few bytes
+		data := make([]byte, 64)
+		if _, err := decodePlainInt96(data, 0x1555555555555556); err == nil {
+			t.Error("expected error for an overflowing INT96 count")
+		}
+		if _, err := decodePlainStrings(data, 1<<62+1); err == nil {
+			t.Error("expected error for an overflowing byte array count")
+		}
+		if _, err := decodePlainFixed[int64](data, 1<<61+1); err == nil {
+			t.Error("expected error for an overflowing INT64 count")
+		}
+	})
+}
+
+func FuzzDecodeHybrid(f *testing.F) {
+	f.Add([]byte{0x03, 0x88, 0xC6, 0xFA}, 3, 8)
+	f.Add([]byte{200, 1, 0x2C, 0x01}, 9, 100)
+	f.Add(binary.AppendUvarint(nil, (1<<60+1)<<1|1), 16, 100)
+	f.Fuzz(func(t *testing.T, data []byte, bitWidth int, n int) {
+		if bitWidth < 0 || n < 0 || n > 1<<16 {
+			return
+		}
+		values, err := decodeHybrid(data, bitWidth, n)
+		if err == nil && len(values) != n {
+			t.Errorf("expected %d values, got %d", n, len(values))
+		}
+	})
+}
+
+func TestParquet_Errors(t *testing.T) {
+	var buf bytes.Buffer
+	if err := parquetDataFrame().WriteParquet(&buf, ParquetWriteOptions{Dictionary: true, Compression: ParquetGzip}); err != nil {
+		t.Fatal(err)
+	}
+	file := buf.Bytes()
+
+	for _, n := range []int{0, 4, 11, len(file) / 2, len(file) - 1} {
+		r := bytes.NewReader(file[:n])
+		if _, err := ReadDataFrameParquet[string](r, r.Size()); err == nil {
+			t.Errorf("expected error for file truncated to %d bytes", n)
+		}
+	}
+
+	// cutting the column data keeps the footer intact but breaks the pages
+	corrupted := slices.Concat(file[:4], file[len(file)/2:])
+	r := bytes.NewReader(corrupted)
+	if _, err := ReadDataFrameParquet[string](r, r.Size()); err == nil {
+		t.Error("expected error for corrupted column data")
+	}
+
+	// flipping bytes must never panic
+	for i := range file {
+		corrupted := slices.Clone(file)
+		corrupted[i] ^= 0xA5
+		r := bytes.NewReader(corrupted)
+		_, _ = ReadDataFrameParquet[string](r, r.Size())
+	}
+
+	type point struct{ x, y int }
+	if err := WriteParquet(&bytes.Buffer{}, NewIndexSeries("p", []point{{1, 2}}), ParquetWriteOptions{}); err == nil {
+		t.Error("expected error for struct values")
+	}
+}
@@ -0,0 +1,165 @@
+package series
+
+import (
+	"fmt"
+	"unsafe"
+)
+
+// The functions in this file combine NumericSeries of two different value types.
+// Both inputs are converted into the result type T before the operation, which is only
+// allowed if T can hold every value of the promoted type of the inputs.
+// The promoted type follows NumPy's rules:
+//   - two types of the same kind (signed, unsigned, float) promote to the wider one
+//   - a signed and an unsigned integer promote to the smallest signed integer holding both,
+//     uint64 with any signed integer promotes to float64 as no integer holds both
+//   - an integer and a float promote to the smallest float holding the integer exactly,
+//     float32 for 8 and 16 bit integers and float64 otherwise
+//
+// int and uint count as int64 and uint64 on 64 bit platforms.
+
+// numericKind describes a Numeric type for the promotion rules
+type numericKind struct {
+	float  bool
+	signed bool
+	bits   int
+}
+
+// String returns the name of the Go type with this kind
+func (k numericKind) String() string {
+	switch {
+	case k.float:
+		return fmt.Sprintf("float%d", k.bits)
+	case k.signed:
+		return fmt.Sprintf("int%d", k.bits)
+	default:
+		return fmt.Sprintf("uint%d", k.bits)
+	}
+}
+
+// kindOf returns the numericKind of T
+func kindOf[T Numeric]() numericKind {
+	var zero T
+	return numericKind{
+		float:  isFloat[T](),
+		signed: zero-1 < 0,
+		bits:   int(unsafe.Sizeof(zero)) * 8,
+	}
+}
+
+// promote returns the kind both a and b are promoted to
+func promote(a, b numericKind) numericKind {
+	switch {
+	case a.float && b.float:
+		return numericKind{float: true, signed: true, bits: max(a.bits, b.bits)}
+	case a.float || b.float:
+		f, i := a, b
+		if b.float {
+			f, i = b, a
+		}
+		if f.bits == 32 && i.bits <= 16 {
+			return f
+		}
+		return numericKind{float: true, signed: true, bits: 64}
+	case a.signed == b.signed:
+		return numericKind{signed: a.signed, bits: max(a.bits, b.bits)}
+	}
+
+	s, u := a, b
+	if b.signed {
+		s, u = b, a
+	}
+	switch {
+	case s.bits > u.bits:
+		return s
+	case u.bits < 64:
+		return numericKind{signed: true, bits: u.bits * 2}
+	default:
+		return numericKind{float: true, signed: true, bits: 64}
+	}
+}
+
+// canHold checks if every value of kind from can be converted into kind to without loss
+func canHold(to, from numericKind) bool {
+	switch {
+	case from.float:
+		return to.float && to.bits >= from.bits
+	case to.float:
+		// a float holds integers up to its mantissa exactly
+		return (to.bits == 32 && from.bits <= 16) || (to.bits == 64 && from.bits <= 32)
+	case from.signed:
+		return to.signed && to.bits >= from.bits
+	case to.signed:
+		return to.bits > from.bits
+	default:
+		return to.bits >= from.bits
+	}
+}
+
+// PromotedType returns the name of the type two NumericSeries with value types A and B promote to
+// see the rules at the top of this file
+func PromotedType[A Numeric, B Numeric]() string {
+	return promote(kindOf[A](), kindOf[B]()).String()
+}
+
+// mixedOperation converts both Series into T and executes op element-wise
+// panics if T can't hold the promoted type of A and B
+func mixedOperation[T Numeric, A Numeric, B Numeric, R comparable](a *NumericSeries[A, R], b *NumericSeries[B, R], op func(x, y T) T, name string) *NumericSeries[T, R] {
+	if a.Len() != b.Len() {
+		panic("series must be of the same length to perform operation")
+	}
+
+	promoted := promote(kindOf[A](), kindOf[B]())
+	if result := kindOf[T](); result != promoted && !canHold(result, promoted) {
+		panic(fmt.Sprintf("%v can't hold the promoted type %v", result, promoted))
+	}
+
+	if name == "" {
+		name = a.name + "_op_" + b.name
+	}
+
+	values := make([]T, a.Len())
+	for i := range values {
+		values[i] = op(T(a.values[i]), T(b.values[i]))
+	}
+	return NewNumericSeries(name, values, a.index)
+}
+
+// AddAs adds two NumericSeries of different types element-wise and returns the result as T
+// T must be able to hold the promoted type, e.g. AddAs[int64] for an int32 and an uint16 Series
+// use an empty string for name to get the default name
+func AddAs[T Numeric, A Numeric, B Numeric, R comparable](a *NumericSeries[A, R], b *NumericSeries[B, R], name string) *NumericSeries[T, R] {
+	return mixedOperation(a, b, func(x, y T) T { return x + y }, name)
+}
+
+// SubtractAs subtracts two NumericSeries of different types element-wise and returns the result as T
+// T must be able to hold the promoted type
+// use an empty string for name to get the default name
+func SubtractAs[T Numeric, A Numeric, B Numeric, R comparable](a *NumericSeries[A, R], b *NumericSeries[B, R], name string) *NumericSeries[T, R] {
+	return mixedOperation(a, b, func(x, y T) T { return x - y }, name)
+}
+
+// MultiplyAs multiplies two NumericSeries of different types element-wise and returns the result as T
+// T must be able to hold the promoted type
+// use an empty string for name to get the default name
+func MultiplyAs[T Numeric, A Numeric, B Numeric, R comparable](a *NumericSeries[A, R], b *NumericSeries[B, R], name string) *NumericSeries[T, R] {
+	return mixedOperation(a, b, func(x, y T) T { return x * y }, name)
+}
+
+// TrueDivide divides two NumericSeries of any types element-wise and always returns float64
+// there is no integer division, so 1 / 2 is 0.5 and division by zero gives ±Inf or NaN instead of panicking
+// use an empty string for name to get the default name
+func TrueDivide[A Numeric, B Numeric, R comparable](a *NumericSeries[A, R], b *NumericSeries[B, R], name string) *NumericSeries[float64, R] {
+	if a.Len() != b.Len() {
+		panic("series must be of the same length to perform operation")
+	}
+
+	if name == "" {
+		name = a.name + "_op_" + b.name
+	}
+
+	values := make([]float64, a.Len())
+	for i := range values {
+		values[i] = float64(a.values[i]) / float64(b.values[i])
+	}
+	return NewNumericSeries(name, values, a.index)
+}
@@ -0,0 +1,136 @@
+package series
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPromotedType(t *testing.T) {
+	t.Run("same kind promotes to the wider type", func(t *testing.T) {
+		if p := PromotedType[int8, int32](); p != "int32" {
+			t.Errorf("expected int32, got %s", p)
+		}
+		if p := PromotedType[uint16, uint8](); p != "uint16" {
+			t.Errorf("expected uint16, got %s", p)
+		}
+		if p := PromotedType[float32, float64](); p != "float64" {
+			t.Errorf("expected float64, got %s", p)
+		}
+	})
+
+	t.Run("signed and unsigned promote to a wider signed type", func(t *testing.T) {
+		if p := PromotedType[int8, uint8](); p != "int16" {
+			t.Errorf("expected int16, got %s", p)
+		}
+		if p := PromotedType[uint16, int64](); p != "int64" {
+			t.Errorf("expected int64, got %s", p)
+		}
+		if p := PromotedType[int, uint64](); p != "float64" {
+			t.Errorf("expected float64, got %s", p)
+		}
+	})
+
+	t.Run("integers and floats promote to a float", func(t *testing.T) {
+		if p := PromotedType[int16, float32](); p != "float32" {
+			t.Errorf("expected float32, got %s", p)
+		}
+		if p := PromotedType[float32, int32](); p != "float64" {
+			t.Errorf("expected float64, got %s", p)
+		}
+		if p := PromotedType[int, float64](); p != "float64" {
+			t.Errorf("expected float64, got %s", p)
+		}
+	})
+}
+
+func TestAddAs(t *testing.T) {
+	t.Run("adds series of different types", func(t *testing.T) {
+		a := NewIndexNumericSeries("a", []int32{1, -2, 3})
+		b := NewIndexNumericSeries("b", []uint16{65535, 2, 3})
+
+		result := AddAs[int64](a, b, "")
+		expected := []int64{65536, 0, 6}
+
+		for i := range expected {
+			if result.At(i) != expected[i] {
+				t.Errorf("expected %d at position %d, got %d", expected[i], i, result.At(i))
+			}
+		}
+		if result.Name() != "a_op_b" {
+			t.Errorf("expected default name 'a_op_b', got %s", result.Name())
+		}
+	})
+
+	t.Run("adds integer and float series", func(t *testing.T) {
+		a := NewIndexNumericSeries("a", []int{1, 2})
+		b := NewIndexNumericSeries("b", []float64{0.5, 0.25})
+
+		result := AddAs[float64](a, b, "sum")
+		if result.At(0) != 1.5 || result.At(1) != 2.25 {
+			t.Errorf("unexpected values %v", result.Values())
+		}
+	})
+
+	t.Run("panics when result type is too narrow", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("expected panic for narrow result type")
+			}
+		}()
+		a := NewIndexNumericSeries("a", []int8{1})
+		b := NewIndexNumericSeries("b", []uint8{1})
+		AddAs[int8](a, b, "")
+	})
+
+	t.Run("panics with different lengths", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("expected panic for different lengths")
+			}
+		}()
+		a := NewIndexNumericSeries("a", []int{1, 2})
+		b := NewIndexNumericSeries("b", []float64{1})
+		AddAs[float64](a, b, "")
+	})
+}
+
+func TestSubtractAndMultiplyAs(t *testing.T) {
+	a := NewIndexNumericSeries("a", []uint8{1, 200})
+	b := NewIndexNumericSeries("b", []int8{3, -100})
+
+	diff := SubtractAs[int16](a, b, "")
+	if diff.At(0) != -2 || diff.At(1) != 300 {
+		t.Errorf("unexpected differences %v", diff.Values())
+	}
+
+	product := MultiplyAs[int32](a, b, "")
+	if product.At(0) != 3 || product.At(1) != -20000 {
+		t.Errorf("unexpected products %v", product.Values())
+	}
+}
+
+func TestTrueDivide(t *testing.T) {
+	t.Run("divides integer series into floats", func(t *testing.T) {
+		a := NewIndexNumericSeries("a", []int{1, 7, -3})
+		b := NewIndexNumericSeries("b", []int{2, 2, 4})
+
+		result := TrueDivide(a, b, "")
+		expected := []float64{0.5, 3.5, -0.75}
+
+		for i := range expected {
+			if result.At(i) != expected[i] {
+				t.Errorf("expected %f at position %d, got %f", expected[i], i, result.At(i))
+			}
+		}
+	})
+
+	t.Run("does not panic on division by zero", func(t *testing.T) {
+		a := NewIndexNumericSeries("a", []int{1, -1, 0})
+		b := NewIndexNumericSeries("b", []uint8{0, 0, 0})
+
+		result := TrueDivide(a, b, "")
+		if !math.IsInf(result.At(0), 1) || !math.IsInf(result.At(1), -1) || !math.IsNaN(result.At(2)) {
+			t.Errorf("unexpected values %v", result.Values())
+		}
+	})
+}
@@ -0,0 +1,788 @@
+package series
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// A query is a condition over the columns of a DataFrame or the values of a Series, for example
+//
+//	age > 30 and name in ("Bob", "Alice")
+//	not isnull(price) and price * quantity between 100 and 200
+//
+// it knows
+//   - numbers, "strings" or 'strings' with backslash escapes, true and false
+//   - columns by name, `quoted` if the name is not an identifier, and index for the labels
+//   - arithmetic with + - * / % and unary -
+//   - comparisons with == != < <= > >=, times can be compared with strings like "2024-01-31"
+//   - x [not] in (literal, ...) and x [not] between low and high, which includes both bounds
+//   - isnull(x) which is true for NaN and missing categorical values
+//   - not, and, or in this order of precedence, keywords are case insensitive
+//
+// all numbers are compared as float64 and any comparison with a missing value except != is false
+
+// QueryError is a syntax or type error in a query
+type QueryError struct {
+	Query string
+	// Pos is the byte offset in the query the error occurred at
+	Pos int
+	Msg string
+}
+
+// Error returns the message with the 1-based column of the error
+func (e *QueryError) Error() string {
+	return fmt.Sprintf("query column %d: %s", utf8.RuneCountInString(e.Query[:e.Pos])+1, e.Msg)
+}
+
+// QueryMask evaluates the query for every row of the DataFrame
+func (df *DataFrame[R]) QueryMask(query string) (*Series[bool, R], error) {
+	return queryMask(query, df.index, func(name string) (Column[R], bool) {
+		if pos := df.columnPos(name); pos >= 0 {
+			return df.columns[pos], true
+		}
+		return nil, false
+	})
+}
+
+// Query returns the rows of the DataFrame for which the query is true
+func (df *DataFrame[R]) Query(query string) (*DataFrame[R], error) {
+	mask, err := df.QueryMask(query)
+	if err != nil {
+		return nil, err
+	}
+	if !slices.Contains(mask.values, true) {
+		return nil, fmt.Errorf("query %q matches no rows", query)
+	}
+	return df.Filter(mask), nil
+}
+
+// QueryMask evaluates the query for every value of the Series
+// the values are referred to by the name of the Series or as value
+func (s *Series[T, R]) QueryMask(query string) (*Series[bool, R], error) {
+	return queryMask(query, s.index, func(name string) (Column[R], bool) {
+		return s, name == s.name || name == "value"
+	})
+}
+
+// Query returns the values of the Series for which the query is true
+func (s *Series[T, R]) Query(query string) (*Series[T, R], error) {
+	mask, err := s.QueryMask(query)
+	if err != nil {
+		return nil, err
+	}
+	if !slices.Contains(mask.values, true) {
+		return nil, fmt.Errorf("query %q matches no values", query)
+	}
+	return s.Filter(mask), nil
+}
+
+// queryMask parses and compiles the query and evaluates it for every row
+func queryMask[R comparable](query string, index []R, lookup func(name string) (Column[R], bool)) (*Series[bool, R], error) {
+	node, err := parseQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	c := &queryCompiler[R]{query: query, index: index, lookup: lookup}
+	v, err := c.compile(node)
+	if err != nil {
+		return nil, err
+	}
+	if v.kind != queryBool {
+		return nil, c.errorf(node, "query is a %v but must be a condition", v.kind)
+	}
+
+	mask := make([]bool, len(index))
+	for i := range mask {
+		mask[i] = v.truth(i)
+	}
+	return NewSeries(query, mask, index), nil
+}
+
+// queryTokenKind is the kind of a token of a query
+type queryTokenKind int
+
+const (
+	tokenEnd queryTokenKind = iota
+	tokenIdent
+	tokenQuoted // a `quoted` column name which is never a keyword
+	tokenNumber
+	tokenString
+	tokenSymbol
+)
+
+// queryToken is a token of a query with its byte offset
+type queryToken struct {
+	kind queryTokenKind
+	text string
+	pos  int
+}
+
+// querySymbols are the operators and punctuation of the language, longer ones first
+var querySymbols = []string{"==", "!=", "<=", ">=", "<", ">", "+", "-", "*", "/", "%", "(", ")", ","}
+
+// lexQuery splits the query into tokens
+func lexQuery(query string) ([]queryToken, error) {
+	var tokens []queryToken
+	pos := 0
+	for {
+		for pos < len(query) {
+			r, size := utf8.DecodeRuneInString(query[pos:])
+			if !unicode.IsSpace(r) {
+				break
+			}
+			pos += size
+		}
+		if pos == len(query) {
+			return append(tokens, queryToken{kind: tokenEnd, pos: pos}), nil
+		}
+
+		start := pos
+		r, _ := utf8.DecodeRuneInString(query[pos:])
+		switch {
+		case r == '_' || unicode.IsLetter(r):
+			for pos < len(query) {
+				r, size := utf8.DecodeRuneInString(query[pos:])
+				if r != '_' && r != '.' && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+					break
+				}
+				pos += size
+			}
+			tokens = append(tokens, queryToken{kind: tokenIdent, text: query[start:pos], pos: start})
+		case r == '.' || (r >= '0' && r <= '9'):
+			for pos < len(query) && (query[pos] == '.' || (query[pos] >= '0' && query[pos] <= '9')) {
+				pos++
+			}
+			if pos < len(query) && (query[pos] == 'e' || query[pos] == 'E') {
+				pos++
+				if pos < len(query) && (query[pos] == '+' || query[pos] == '-') {
+					pos++
+				}
+				for pos < len(query) && query[pos] >= '0' && query[pos] <= '9' {
+					pos++
+				}
+			}
+			if _, err := strconv.ParseFloat(query[start:pos], 64); err != nil {
+				return nil, &QueryError{Query: query, Pos: start, Msg: fmt.Sprintf("invalid number %q", query[start:pos])}
+			}
+			tokens = append(tokens, queryToken{kind: tokenNumber, text: query[start:pos], pos: start})
+		case r == '"' || r == '\'' || r == '`':
+			text, end, ok := unquoteQuery(query, start)
+			kind, what := tokenString, "string"
+			if r == '`' {
+				kind, what = tokenQuoted, "column name"
+			}
+			if !ok {
+				return nil, &QueryError{Query: query, Pos: start, Msg: "unterminated " + what}
+			}
+			tokens = append(tokens, queryToken{kind: kind, text: text, pos: start})
+			pos = end
+		default:
+			i := slices.IndexFunc(querySymbols, func(s string) bool { return strings.HasPrefix(query[pos:], s) })
+			if i < 0 {
+				return nil, &QueryError{Query: query, Pos: start, Msg: fmt.Sprintf("unexpected character %q", r)}
+			}
+			pos += len(querySymbols[i])
+			tokens = append(tokens, queryToken{kind: tokenSymbol, text: querySymbols[i], pos: start})
+		}
+	}
+}
+
+// unquoteQuery reads the quoted text starting at pos and returns it with the offset after the closing quote
+// a backslash escapes the quote, a backslash, n and t
+func unquoteQuery(query string, pos int) (string, int, bool) {
+	quote := query[pos]
+	var sb strings.Builder
+	for i := pos + 1; i < len(query); i++ {
+		switch query[i] {
+		case quote:
+			return sb.String(), i + 1, true
+		case '\\':
+			if i+1 == len(query) {
+				return "", 0, false
+			}
+			i++
+			switch query[i] {
+			case 'n':
+				sb.WriteByte('\n')
+			case 't':
+				sb.WriteByte('\t')
+			default:
+				sb.WriteByte(query[i])
+			}
+		default:
+			sb.WriteByte(query[i])
+		}
+	}
+	return "", 0, false
+}
+
+// queryNode is a node of a parsed query
+type queryNode struct {
+	op   string // the operator, keyword or the kind of a leaf: ident, number, string, bool
+	pos  int
+	text string // name of an ident, text of a string
+	num  float64
+	args []*queryNode
+}
+
+// queryParser is a recursive descent parser of queries
+type queryParser struct {
+	query  string
+	tokens []queryToken
+	next   int
+}
+
+// parseQuery parses the whole query
+func parseQuery(query string) (*queryNode, error) {
+	tokens, err := lexQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	p := &queryParser{query: query, tokens: tokens}
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokenEnd {
+		return nil, p.unexpected(t, "end of query")
+	}
+	return node, nil
+}
+
+func (p *queryParser) peek() queryToken {
+	return p.tokens[p.next]
+}
+
+// keyword returns if the next token is the case insensitive keyword and consumes it if it is
+func (p *queryParser) keyword(word string) bool {
+	t := p.peek()
+	if t.kind == tokenIdent && strings.EqualFold(t.text, word) {
+		p.next++
+		return true
+	}
+	return false
+}
+
+// symbol returns if the next token is the symbol and consumes it if it is
+func (p *queryParser) symbol(s string) bool {
+	if t := p.peek(); t.kind == tokenSymbol && t.text == s {
+		p.next++
+		return true
+	}
+	return false
+}
+
+// expect consumes the symbol or fails
+func (p *queryParser) expect(s string) error {
+	if !p.symbol(s) {
+		return p.unexpected(p.peek(), fmt.Sprintf("%q", s))
+	}
+	return nil
+}
+
+// unexpected returns an error for a token which is not what the parser expected
+func (p *queryParser) unexpected(t queryToken, expected string) error {
+	found := fmt.Sprintf("%q", t.text)
+	switch t.kind {
+	case tokenEnd:
+		found = "end of query"
+	case tokenString:
+		found = "string " + strconv.Quote(t.text)
+	}
+	return &QueryError{Query: p.query, Pos: t.pos, Msg: fmt.Sprintf("expected %s but found %s", expected, found)}
+}
+
+func (p *queryParser) parseOr() (*queryNode, error) {
+	return p.parseBinary(p.parseAnd, "or")
+}
+
+func (p *queryParser) parseAnd() (*queryNode, error) {
+	return p.parseBinary(p.parseNot, "and")
+}
+
+// parseBinary parses operands joined by the left associative keyword
+func (p *queryParser) parseBinary(operand func() (*queryNode, error), word string) (*queryNode, error) {
+	left, err := operand()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		pos := p.peek().pos
+		if !p.keyword(word) {
+			return left, nil
+		}
+		right, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		left = &queryNode{op: word, pos: pos, args: []*queryNode{left, right}}
+	}
+}
+
+func (p *queryParser) parseNot() (*queryNode, error) {
+	pos := p.peek().pos
+	if p.keyword("not") {
+		arg, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &queryNode{op: "not", pos: pos, args: []*queryNode{arg}}, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *queryParser) parseComparison() (*queryNode, error) {
+	left, err := p.parseAdditive()
+	if err != nil {
+		return nil, err
+	}
+
+	t := p.peek()
+	if t.kind == tokenSymbol && slices.Contains([]string{"==", "!=", "<", "<=", ">", ">="}, t.text) {
+		p.next++
+		right, err := p.parseAdditive()
+		if err != nil {
+			return nil, err
+		}
+		return &queryNode{op: t.text, pos: t.pos, args: []*queryNode{left, right}}, nil
+	}
+
+	negate := p.keyword("not")
+	var node *queryNode
+	switch {
+	case p.keyword("in"):
+		node = &queryNode{op: "in", pos: t.pos, args: []*queryNode{left}}
+		if err := p.expect("("); err != nil {
+			return nil, err
+		}
+		for {
+			item, err := p.parseUnary()
+			if err != nil {
+				return nil, err
+			}
+			node.args = append(node.args, item)
+			if !p.symbol(",") {
+				break
+			}
+		}
+		if err := p.expect(")"); err != nil {
+			return nil, err
+		}
+	case p.keyword("between"):
+		low, err := p.parseAdditive()
+		if err != nil {
+			return nil, err
+		}
+		if !p.keyword("and") {
+			return nil, p.unexpected(p.peek(), `"and"`)
+		}
+		high, err := p.parseAdditive()
+		if err != nil {
+			return nil, err
+		}
+		node = &queryNode{op: "between", pos: t.pos, args: []*queryNode{left, low, high}}
+	case negate:
+		return nil, p.unexpected(p.peek(), `"in" or "between"`)
+	default:
+		return left, nil
+	}
+
+	if negate {
+		node = &queryNode{op: "not", pos: t.pos, args: []*queryNode{node}}
+	}
+	return node, nil
+}
+
+func (p *queryParser) parseAdditive() (*queryNode, error) {
+	return p.parseArithmetic(p.parseMultiplicative, "+", "-")
+}
+
+func (p *queryParser) parseMultiplicative() (*queryNode, error) {
+	return p.parseArithmetic(p.parseUnary, "*", "/", "%")
+}
+
+// parseArithmetic parses operands joined by the left associative symbols
+func (p *queryParser) parseArithmetic(operand func() (*queryNode, error), symbols ...string) (*queryNode, error) {
+	left, err := operand()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		t := p.peek()
+		if t.kind != tokenSymbol || !slices.Contains(symbols, t.text) {
+			return left, nil
+		}
+		p.next++
+		right, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		left = &queryNode{op: t.text, pos: t.pos, args: []*queryNode{left, right}}
+	}
+}
+
+func (p *queryParser) parseUnary() (*queryNode, error) {
+	t := p.peek()
+	if p.symbol("-") {
+		arg, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		if arg.op == "number" {
+			return &queryNode{op: "number", pos: t.pos, num: -arg.num}, nil
+		}
+		return &queryNode{op: "neg", pos: t.pos, args: []*queryNode{arg}}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *queryParser) parsePrimary() (*queryNode, error) {
+	t := p.peek()
+	switch t.kind {
+	case tokenNumber:
+		p.next++
+		v, _ := strconv.ParseFloat(t.text, 64)
+		return &queryNode{op: "number", pos: t.pos, num: v}, nil
+	case tokenString:
+		p.next++
+		return &queryNode{op: "string", pos: t.pos, text: t.text}, nil
+	case tokenQuoted:
+		p.next++
+		return &queryNode{op: "ident", pos: t.pos, text: t.text}, nil
+	case tokenIdent:
+		switch strings.ToLower(t.text) {
+		case "true", "false":
+			p.next++
+			return &queryNode{op: "bool", pos: t.pos, text: strings.ToLower(t.text)}, nil
+		case "isnull":
+			p.next++
+			if err := p.expect("("); err != nil {
+				return nil, err
+			}
+			arg, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(")"); err != nil {
+				return nil, err
+			}
+			return &queryNode{op: "isnull", pos: t.pos, args: []*queryNode{arg}}, nil
+		case "and", "or", "not", "in", "between":
+			return nil, p.unexpected(t, "a value")
+		}
+		p.next++
+		return &queryNode{op: "ident", pos: t.pos, text: t.text}, nil
+	case tokenSymbol:
+		if p.symbol("(") {
+			node, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			return node, p.expect(")")
+		}
+	}
+	return nil, p.unexpected(t, "a value")
+}
+
+// queryKind is the type of the values of a query expression
+type queryKind int
+
+const (
+	queryNumber queryKind = iota
+	queryString
+	queryBool
+	queryTime
+)
+
+func (k queryKind) String() string {
+	return [...]string{"number", "string", "bool", "time"}[k]
+}
+
+// queryValue is a compiled query expression, only the function of its kind is set
+type queryValue struct {
+	kind    queryKind
+	literal bool // the value is the same for every row
+	number  func(i int) float64
+	text    func(i int) (string, bool) // false for a missing value
+	truth   func(i int) bool
+	time    func(i int) time.Time
+}
+
+// queryCompiler turns a parsed query into functions computing the value of a row
+type queryCompiler[R comparable] struct {
+	query  string
+	index  []R
+	lookup func(name string) (Column[R], bool)
+}
+
+func (c *queryCompiler[R]) errorf(node *queryNode, format string, args ...any) error {
+	return &QueryError{Query: c.query, Pos: node.pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+func (c *queryCompiler[R]) compile(node *queryNode) (queryValue, error) {
+	switch node.op {
+	case "number":
+		v := node.num
+		return queryValue{kind: queryNumber, literal: true, number: func(int) float64 { return v }}, nil
+	case "string":
+		v := node.text
+		return queryValue{kind: queryString, literal: true, text: func(int) (string, bool) { return v, true }}, nil
+	case "bool":
+		v := node.text == "true"
+		return queryValue{kind: queryBool, literal: true, truth: func(int) bool { return v }}, nil
+	case "ident":
+		return c.column(node)
+	case "isnull":
+		return c.compileIsNull(node)
+	case "in":
+		return c.compileIn(node)
+	case "between":
+		return c.compileBetween(node)
+	}
+
+	args := make([]queryValue, len(node.args))
+	for i, arg := range node.args {
+		var err error
+		if args[i], err = c.compile(arg); err != nil {
+			return args[i], err
+		}
+	}
+	switch node.op {
+	case "and", "or", "not":
+		for i, arg := range args {
+			if arg.kind != queryBool {
+				return arg, c.errorf(node.args[i], "%q needs conditions but got a %v", node.op, arg.kind)
+			}
+		}
+		a := args[0].truth
+		if node.op == "not" {
+			return queryValue{kind: queryBool, truth: func(i int) bool { return !a(i) }}, nil
+		}
+		b := args[1].truth
+		if node.op == "and" {
+			return queryValue{kind: queryBool, truth: func(i int) bool { return a(i) && b(i) }}, nil
+		}
+		return queryValue{kind: queryBool, truth: func(i int) bool { return a(i) || b(i) }}, nil
+	case "==", "!=", "<", "<=", ">", ">=":
+		truth, err := c.compare(node, node.op, node.args[0], args[0], node.args[1], args[1])
+		return queryValue{kind: queryBool, truth: truth}, err
+	}
+	return c.compileArithmetic(node, args)
+}
+
+// column returns the values of a column or of the labels if it is called index
+func (c *queryCompiler[R]) column(node *queryNode) (queryValue, error) {
+	col, ok := c.lookup(node.text)
+	if !ok && node.text == "index" {
+		col, ok = NewSeries("index", c.index, c.index), true
+	}
+	if !ok {
+		return queryValue{}, c.errorf(node, "unknown column %q", node.text)
+	}
+
+	if cs, ok := col.(*CategoricalSeries[R]); ok {
+		return queryValue{kind: queryString, text: func(i int) (string, bool) {
+			if cs.codes[i] == missingCode {
+				return "", false
+			}
+			return cs.categories[cs.codes[i]], true
+		}}, nil
+	}
+	values := col.(interface{ rawValues() any }).rawValues()
+	if number, ok := numberAt(values); ok {
+		return queryValue{kind: queryNumber, number: number}, nil
+	}
+	switch v := values.(type) {
+	case []string:
+		return queryValue{kind: queryString, text: func(i int) (string, bool) { return v[i], true }}, nil
+	case []bool:
+		return queryValue{kind: queryBool, truth: func(i int) bool { return v[i] }}, nil
+	case []time.Time:
+		return queryValue{kind: queryTime, time: func(i int) time.Time { return v[i] }}, nil
+	}
+	return queryValue{}, c.errorf(node, "column %q has unsupported values of type %T", node.text, values)
+}
+
+func (c *queryCompiler[R]) compileArithmetic(node *queryNode, args []queryValue) (queryValue, error) {
+	for i, arg := range args {
+		if arg.kind != queryNumber {
+			op := node.op
+			if op == "neg" {
+				op = "-"
+			}
+			return arg, c.errorf(node.args[i], "%q needs numbers but got a %v", op, arg.kind)
+		}
+	}
+	a := args[0].number
+	var number func(i int) float64
+	switch node.op {
+	case "neg":
+		number = func(i int) float64 { return -a(i) }
+	case "+":
+		b := args[1].number
+		number = func(i int) float64 { return a(i) + b(i) }
+	case "-":
+		b := args[1].number
+		number = func(i int) float64 { return a(i) - b(i) }
+	case "*":
+		b := args[1].number
+		number = func(i int) float64 { return a(i) * b(i) }
+	case "/":
+		b := args[1].number
+		number = func(i int) float64 { return a(i) / b(i) }
+	case "%":
+		b := args[1].number
+		number = func(i int) float64 { return math.Mod(a(i), b(i)) }
+	}
+	return queryValue{kind: queryNumber, number: number}, nil
+}
+
+func (c *queryCompiler[R]) compileIsNull(node *queryNode) (queryValue, error) {
+	arg, err := c.compile(node.args[0])
+	if err != nil {
+		return arg, err
+	}
+	switch arg.kind {
+	case queryNumber:
+		return queryValue{kind: queryBool, truth: func(i int) bool { return math.IsNaN(arg.number(i)) }}, nil
+	case queryString:
+		return queryValue{kind: queryBool, truth: func(i int) bool {
+			_, ok := arg.text(i)
+			return !ok
+		}}, nil
+	}
+	return queryValue{kind: queryBool, truth: func(int) bool { return false }}, nil
+}
+
+func (c *queryCompiler[R]) compileIn(node *queryNode) (queryValue, error) {
+	left, err := c.compile(node.args[0])
+	if err != nil {
+		return left, err
+	}
+	var tests []func(i int) bool
+	for _, item := range node.args[1:] {
+		v, err := c.compile(item)
+		if err != nil {
+			return v, err
+		}
+		if !v.literal {
+			return v, c.errorf(item, "the values of %q must be literals", "in")
+		}
+		test, err := c.compare(item, "==", node.args[0], left, item, v)
+		if err != nil {
+			return v, err
+		}
+		tests = append(tests, test)
+	}
+	return queryValue{kind: queryBool, truth: func(i int) bool {
+		for _, test := range tests {
+			if test(i) {
+				return true
+			}
+		}
+		return false
+	}}, nil
+}
+
+func (c *queryCompiler[R]) compileBetween(node *queryNode) (queryValue, error) {
+	args := make([]queryValue, 3)
+	for i, arg := range node.args {
+		var err error
+		if args[i], err = c.compile(arg); err != nil {
+			return args[i], err
+		}
+	}
+	low, err := c.compare(node, ">=", node.args[0], args[0], node.args[1], args[1])
+	if err != nil {
+		return args[0], err
+	}
+	high, err := c.compare(node, "<=", node.args[0], args[0], node.args[2], args[2])
+	if err != nil {
+		return args[0], err
+	}
+	return queryValue{kind: queryBool, truth: func(i int) bool { return low(i) && high(i) }}, nil
+}
+
+// compare compiles a comparison, a time can be compared with a string literal holding a time
+func (c *queryCompiler[R]) compare(node *queryNode, op string, leftNode *queryNode, left queryValue, rightNode *queryNode, right queryValue) (func(i int) bool, error) {
+	var err error
+	if left.kind == queryTime && right.kind == queryString && right.literal {
+		right, err = c.parseTime(rightNode, right)
+	} else if left.kind == queryString && left.literal && right.kind == queryTime {
+		left, err = c.parseTime(leftNode, left)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if left.kind != right.kind {
+		return nil, c.errorf(node, "cannot compare %v with %v", left.kind, right.kind)
+	}
+
+	var result func(cmp int) bool
+	switch op {
+	case "==":
+		result = func(cmp int) bool { return cmp == 0 }
+	case "!=":
+		result = func(cmp int) bool { return cmp != 0 }
+	case "<":
+		result = func(cmp int) bool { return cmp < 0 }
+	case "<=":
+		result = func(cmp int) bool { return cmp <= 0 }
+	case ">":
+		result = func(cmp int) bool { return cmp > 0 }
+	case ">=":
+		result = func(cmp int) bool { return cmp >= 0 }
+	}
+
+	switch left.kind {
+	case queryNumber:
+		a, b := left.number, right.number
+		return func(i int) bool {
+			x, y := a(i), b(i)
+			if math.IsNaN(x) || math.IsNaN(y) {
+				return op == "!="
+			}
+			return result(cmp.Compare(x, y))
+		}, nil
+	case queryString:
+		a, b := left.text, right.text
+		return func(i int) bool {
+			x, okX := a(i)
+			y, okY := b(i)
+			if !okX || !okY {
+				return op == "!="
+			}
+			return result(strings.Compare(x, y))
+		}, nil
+	case queryTime:
+		a, b := left.time, right.time
+		return func(i int) bool { return result(a(i).Compare(b(i))) }, nil
+	}
+
+	if op != "==" && op != "!=" {
+		return nil, c.errorf(node, "cannot order bools with %q", op)
+	}
+	a, b := left.truth, right.truth
+	return func(i int) bool { return (a(i) == b(i)) == (op == "==") }, nil
+}
+
+// queryTimeLayouts are the layouts a string compared with a time may have
+var queryTimeLayouts = []string{time.RFC3339Nano, "2006-01-02 15:04:05", "2006-01-02T15:04:05", "2006-01-02"}
+
+// parseTime turns a string literal into a time literal, times without a zone are UTC
+func (c *queryCompiler[R]) parseTime(node *queryNode, v queryValue) (queryValue, error) {
+	text, _ := v.text(0)
+	for _, layout := range queryTimeLayouts {
+		if t, err := time.Parse(layout, text); err == nil {
+			return queryValue{kind: queryTime, literal: true, time: func(int) time.Time { return t }}, nil
+		}
+	}
+	return v, c.errorf(node, "cannot parse %q as time", text)
+}
@@ -0,0 +1,134 @@
+package series
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestQueryMask(t *testing.T) {
+	df := NewDataFrame[int](
+		NewIndexSeries("name", []string{"Bob", "Alice", "Carol", "Dave"}),
+		NewIndexSeries("age", []int{35, 28, 41, 30}),
+		NewIndexSeries("score", []float64{1.5, math.NaN(), 3, -2}),
+		NewIndexSeries("active", []bool{true, false, true, true}),
+		NewIndexSeries("joined", []time.Time{
+			time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+			time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC),
+			time.Date(2022, 3, 15, 12, 0, 0, 0, time.UTC),
+			time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		}),
+		ToCategorical(NewIndexSeries("team", []string{"red", "", "blue", "red"}), []string{"red", "blue"}, false),
+		NewIndexSeries("first name", []string{"B", "A", "C", "D"}),
+	)
+	for query, want := range map[string][]bool{
+		`age > 30 and name in ("Bob", "Alice")`:            {true, false, false, false},
+		`age >= 30 or not active`:                          {true, true, true, true},
+		`NOT (age > 30) AND active`:                        {false, false, false, true},
+		`age between 28 and 35`:                            {true, true, false, true},
+		`age not between 29 and 40`:                        {false, true, true, false},
+		`name not in ('Bob', "Dave")`:                      {false, true, true, false},
+		`age in (28, 41, -1)`:                              {false, true, true, false},
+		`isnull(score)`:                                    {false, true, false, false},
+		`not isnull(team) and team != "blue"`:              {true, false, false, true},
+		`team == "red" or team != "red"`:                   {true, true, true, true},
+		`score != 3`:                                       {true, true, false, true},
+		`score < 2`:                                        {true, false, false, true},
+		`age * 2 - 10 / 2 > 60 % 7 + 60`:                   {true, false, true, false},
+		`-age < -30 and -(score) > -2`:                     {true, false, false, false},
+		`joined >= "2021-06-01" and joined < "2022-12-31"`: {false, true, true, false},
+		`joined between "2020-01-01T00:00:00Z" and "2021-06-01 00:00:00"`: {true, true, false, false},
+		`active == true and index > 0`:                                    {false, false, true, true},
+		"`first name` == 'C' or name == 'Dav\\'e'":                        {false, false, true, false},
+		`1.5e1 < age and age < 3.2E+1`:                                    {false, true, false, true},
+		`name >= "Bob" and name < "D"`:                                    {true, false, true, false},
+	} {
+		mask, err := df.QueryMask(query)
+		if err != nil {
+			t.Errorf("%s: %v", query, err)
+			continue
+		}
+		if !slices.Equal(mask.Values(), want) || !slices.Equal(mask.Index(), df.Index()) {
+			t.Errorf("%s: expected %v, got %v", query, want, mask.Values())
+		}
+	}
+}
+
+func TestQuery(t *testing.T) {
+	people := NewDataFrame[int](
+		NewIndexSeries("name", []string{"Bob", "Alice", "Carol", "Dave"}),
+		NewIndexSeries("age", []int{35, 28, 41, 30}),
+		NewIndexSeries("active", []bool{true, false, true, true}),
+	)
+	df, err := people.Query(`active and age < 40`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(df.Index(), []int{0, 3}) || GetColumn[string](df, "name").At(1) != "Dave" {
+		t.Errorf("unexpected rows\n%v", df)
+	}
+	if _, err := people.Query(`age > 100`); err == nil {
+		t.Error("expected error for a query without matches")
+	}
+
+	s := NewSeries("price", []float64{3, 12, 7}, []string{"a", "b", "c"})
+	filtered, err := s.Query(`price > 5 and value < 10 or index == "a"`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(filtered.Index(), []string{"a", "c"}) {
+		t.Errorf("unexpected values %v", filtered)
+	}
+}
+
+func TestQuery_Errors(t *testing.T) {
+	df := NewDataFrame[int](
+		NewIndexSeries("name", []string{"Bob", "Alice"}),
+		NewIndexSeries("age", []int{35, 28}),
+		NewIndexSeries("active", []bool{true, false}),
+		NewIndexSeries("joined", []time.Time{time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)}),
+	)
+	for query, pos := range map[string]int{
+		`age >`:                      5,
+		`age > 30 and`:               12,
+		`age > 30 age`:               9,
+		`(age > 30`:                  9,
+		`age ! 30`:                   4,
+		`name == "Bob`:               8,
+		"`name == 1":                 0,
+		`height > 1`:                 0,
+		`name > 3`:                   5,
+		`age + name > 3`:             6,
+		`age`:                        0,
+		`age and active`:             0,
+		`active < true`:              7,
+		`age in (1, age)`:            11,
+		`age not 5`:                  8,
+		`joined > "yesterday"`:       9,
+		`1..2 > age`:                 0,
+		`age in ()`:                  8,
+		`isnull(age`:                 10,
+		`ünïcode > 1 and age > 1 or`: 28, // byte offset of the end
+	} {
+		_, err := df.QueryMask(query)
+		var qe *QueryError
+		if !errors.As(err, &qe) {
+			t.Errorf("%s: expected QueryError, got %v", query, err)
+			continue
+		}
+		if qe.Pos != pos {
+			t.Errorf("%s: expected error at %d, got %d: %v", query, pos, qe.Pos, qe)
+		}
+	}
+
+	_, err := df.QueryMask(`ünï > 1`)
+	if err == nil || err.Error() != `query column 1: unknown column "ünï"` {
+		t.Errorf("unexpected error %v", err)
+	}
+	_, err = df.QueryMask(`age > 1 or ö`)
+	if err == nil || err.Error() != `query column 12: unknown column "ö"` {
+		t.Errorf("unexpected error %v", err)
+	}
+}
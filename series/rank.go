@@ -0,0 +1,120 @@
+package series
+
+import (
+	"cmp"
+	"math"
+	"slices"
+)
+
+// RankMethod tells Rank which rank to give to equal values
+type RankMethod int
+
+const (
+	// RankAverage gives equal values the average of their ranks
+	RankAverage RankMethod = iota
+	// RankMin gives equal values the lowest of their ranks
+	RankMin
+	// RankMax gives equal values the highest of their ranks
+	RankMax
+	// RankFirst gives equal values increasing ranks in the order they appear in the Series
+	RankFirst
+	// RankDense is like RankMin but the rank only increases by one between groups of equal values
+	RankDense
+)
+
+// NaRank tells Rank how to rank NaN values
+type NaRank int
+
+const (
+	// NaKeep gives NaN values the rank NaN
+	NaKeep NaRank = iota
+	// NaTop gives NaN values the lowest ranks
+	NaTop
+	// NaBottom gives NaN values the highest ranks
+	NaBottom
+)
+
+// RankOptions controls how Rank ranks the values
+// the zero value ranks ascending with RankAverage and NaKeep
+type RankOptions struct {
+	Method     RankMethod
+	Descending bool
+	NaOption   NaRank
+	// Pct returns the ranks as a fraction of the number of ranked values
+	Pct bool
+}
+
+// Rank returns the rank of every value in the Series, starting at 1
+// the result has the same labels and name as the Series
+func Rank[T cmp.Ordered, R comparable](s *Series[T, R], opts RankOptions) *NumericSeries[float64, R] {
+	asc := !opts.Descending
+	na := NaLast
+	if opts.NaOption == NaTop {
+		na = NaFirst
+	}
+
+	positions := make([]int, 0, s.Len())
+	for i, v := range s.values {
+		if opts.NaOption == NaKeep && isNaN(v) {
+			continue
+		}
+		positions = append(positions, i)
+	}
+
+	compare := func(i, j int) int {
+		return compareNA(s.values[i], s.values[j], asc, na)
+	}
+	slices.SortStableFunc(positions, compare)
+
+	ranks := make([]float64, s.Len())
+	for i := range ranks {
+		ranks[i] = math.NaN()
+	}
+
+	dense := 0
+	for start := 0; start < len(positions); {
+		end := start + 1
+		for end < len(positions) && compare(positions[start], positions[end]) == 0 {
+			end++
+		}
+		dense++
+
+		for k := start; k < end; k++ {
+			var rank float64
+			switch opts.Method {
+			case RankAverage:
+				rank = float64(start+1+end) / 2
+			case RankMin:
+				rank = float64(start + 1)
+			case RankMax:
+				rank = float64(end)
+			case RankFirst:
+				rank = float64(k + 1)
+			case RankDense:
+				rank = float64(dense)
+			default:
+				panic("unknown rank method")
+			}
+			ranks[positions[k]] = rank
+		}
+		start = end
+	}
+
+	if opts.Pct {
+		total := float64(len(positions))
+		if opts.Method == RankDense {
+			total = float64(dense)
+		}
+		for i := range ranks {
+			ranks[i] /= total
+		}
+	}
+
+	return NewNumericSeries(s.name, ranks, s.index)
+}
+
+// Rank returns the rank of every value in the Series, starting at 1
+// see the top level Rank function for the options
+func (ns *NumericSeries[T, R]) Rank(opts RankOptions) *NumericSeries[float64, R] {
+	return Rank(ns.Series, opts)
+}
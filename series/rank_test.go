@@ -0,0 +1,94 @@
+package series
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRank(t *testing.T) {
+	values := []int{10, 30, 20, 30, 10, 40}
+
+	tests := []struct {
+		name     string
+		opts     RankOptions
+		expected []float64
+	}{
+		{"average", RankOptions{Method: RankAverage}, []float64{1.5, 4.5, 3, 4.5, 1.5, 6}},
+		{"min", RankOptions{Method: RankMin}, []float64{1, 4, 3, 4, 1, 6}},
+		{"max", RankOptions{Method: RankMax}, []float64{2, 5, 3, 5, 2, 6}},
+		{"first", RankOptions{Method: RankFirst}, []float64{1, 4, 3, 5, 2, 6}},
+		{"dense", RankOptions{Method: RankDense}, []float64{1, 3, 2, 3, 1, 4}},
+		{"min descending", RankOptions{Method: RankMin, Descending: true}, []float64{5, 2, 4, 2, 5, 1}},
+		{"dense pct", RankOptions{Method: RankDense, Pct: true}, []float64{0.25, 0.75, 0.5, 0.75, 0.25, 1}},
+		{"first pct", RankOptions{Method: RankFirst, Pct: true}, []float64{1.0 / 6, 4.0 / 6, 3.0 / 6, 5.0 / 6, 2.0 / 6, 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ns := NewIndexNumericSeries("score", values)
+			result := ns.Rank(tt.opts)
+
+			for i, exp := range tt.expected {
+				if math.Abs(result.At(i)-exp) > 1e-9 {
+					t.Errorf("expected rank %v at position %d, got %v", exp, i, result.At(i))
+				}
+			}
+		})
+	}
+
+	t.Run("preserves labels and name", func(t *testing.T) {
+		ns := NewNumericSeries("score", []int{3, 1, 2}, []string{"a", "b", "c"})
+		result := ns.Rank(RankOptions{})
+
+		if result.Name() != "score" {
+			t.Errorf("expected name 'score', got %s", result.Name())
+		}
+		if result.Get("a") != 3 || result.Get("b") != 1 || result.Get("c") != 2 {
+			t.Errorf("unexpected ranks %v", result.Values())
+		}
+	})
+
+	t.Run("ranks strings", func(t *testing.T) {
+		s := NewIndexSeries("names", []string{"carol", "alice", "bob"})
+		result := Rank(s, RankOptions{})
+
+		if result.At(0) != 3 || result.At(1) != 1 || result.At(2) != 2 {
+			t.Errorf("unexpected ranks %v", result.Values())
+		}
+	})
+}
+
+func TestRank_NaN(t *testing.T) {
+	nan := math.NaN()
+	values := []float64{2, nan, 1, nan}
+
+	t.Run("keeps NaN", func(t *testing.T) {
+		result := NewIndexNumericSeries("x", values).Rank(RankOptions{Pct: true})
+
+		if result.At(0) != 1 || !math.IsNaN(result.At(1)) || result.At(2) != 0.5 || !math.IsNaN(result.At(3)) {
+			t.Errorf("unexpected ranks %v", result.Values())
+		}
+	})
+
+	t.Run("ranks NaN top", func(t *testing.T) {
+		result := NewIndexNumericSeries("x", values).Rank(RankOptions{NaOption: NaTop})
+
+		expected := []float64{4, 1.5, 3, 1.5}
+		for i, exp := range expected {
+			if result.At(i) != exp {
+				t.Errorf("expected rank %v at position %d, got %v", exp, i, result.At(i))
+			}
+		}
+	})
+
+	t.Run("ranks NaN bottom descending", func(t *testing.T) {
+		result := NewIndexNumericSeries("x", values).Rank(RankOptions{NaOption: NaBottom, Descending: true, Method: RankMin})
+
+		expected := []float64{1, 3, 2, 3}
+		for i, exp := range expected {
+			if result.At(i) != exp {
+				t.Errorf("expected rank %v at position %d, got %v", exp, i, result.At(i))
+			}
+		}
+	})
+}
@@ -0,0 +1,357 @@
+package series
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+)
+
+// Aggregation combines the values of a PivotTable cell into one value, NaN values are ignored
+type Aggregation int
+
+const (
+	// AggSum adds the values
+	AggSum Aggregation = iota
+	// AggMean averages the values
+	AggMean
+	// AggCount counts the values
+	AggCount
+	// AggMin takes the smallest value
+	AggMin
+	// AggMax takes the largest value
+	AggMax
+)
+
+// aggregate combines the values, the sum and count of no values are 0, anything else is NaN
+func (a Aggregation) aggregate(values []float64) float64 {
+	var sum float64
+	count := 0
+	extreme := math.NaN()
+	for _, v := range values {
+		if math.IsNaN(v) {
+			continue
+		}
+		sum += v
+		count++
+		if count == 1 || a == AggMin && v < extreme || a == AggMax && v > extreme {
+			extreme = v
+		}
+	}
+
+	switch a {
+	case AggSum:
+		return sum
+	case AggMean:
+		if count == 0 {
+			return math.NaN()
+		}
+		return sum / float64(count)
+	case AggCount:
+		return float64(count)
+	case AggMin, AggMax:
+		return extreme
+	}
+	panic(fmt.Sprintf("unknown aggregation %d", a))
+}
+
+// PivotTableOptions are the options of PivotTable
+type PivotTableOptions[I comparable] struct {
+	// Aggregation combines the values of a cell
+	Aggregation Aggregation
+	// Margins adds a row and a column which aggregate all values of each column and row
+	Margins bool
+	// MarginsLabel is the label of the margins row, it must not be the label of another row
+	MarginsLabel I
+	// MarginsName is the name of the margins column, "All" is used if empty
+	MarginsName string
+}
+
+// Pivot reshapes long data into a wide DataFrame
+// the distinct values of the index column become the labels and the distinct values of the columns column the column names,
+// both in order of first appearance, rows where either is missing are ignored
+// every cell holds the value of the values column of the one row with its label and column, missing cells are NaN or the zero value
+func Pivot[T comparable, I comparable, R comparable](df *DataFrame[R], index, columns, values string) (*DataFrame[I], error) {
+	valueColumn, err := typedColumn[T](df, values)
+	if err != nil {
+		return nil, err
+	}
+	p, err := newPivot[I](df, index, columns)
+	if err != nil {
+		return nil, err
+	}
+
+	wide, dup := spread(p.labels, p.names, p.rows, p.columns, valueColumn.values)
+	if dup >= 0 {
+		return nil, fmt.Errorf("more than one value for label %v and column %q", p.labels[p.rows[dup]], p.names[p.columns[dup]])
+	}
+	return wide, nil
+}
+
+// PivotTable reshapes long data into a wide DataFrame like Pivot but aggregates the numeric values of all rows of a cell
+// cells without rows are NaN, the margins aggregate the values of all rows of a row or column and not the cells
+func PivotTable[I comparable, R comparable](df *DataFrame[R], index, columns, values string, opts PivotTableOptions[I]) (*DataFrame[I], error) {
+	pos := df.columnPos(values)
+	if pos < 0 {
+		return nil, fmt.Errorf("no column named %q", values)
+	}
+	raw, ok := df.columns[pos].(interface{ rawValues() any })
+	if !ok {
+		return nil, fmt.Errorf("column %q does not hold numbers", values)
+	}
+	valueAt, ok := numberAt(raw.rawValues())
+	if !ok {
+		return nil, fmt.Errorf("column %q does not hold numbers", values)
+	}
+	p, err := newPivot[I](df, index, columns)
+	if err != nil {
+		return nil, err
+	}
+
+	labels, names := p.labels, p.names
+	if opts.Margins {
+		if opts.MarginsName == "" {
+			opts.MarginsName = "All"
+		}
+		if slices.Contains(labels, opts.MarginsLabel) {
+			return nil, fmt.Errorf("margins label %v is the label of another row", opts.MarginsLabel)
+		}
+		if slices.Contains(names, opts.MarginsName) {
+			return nil, fmt.Errorf("margins name %q is the name of another column", opts.MarginsName)
+		}
+		labels = append(slices.Clone(labels), opts.MarginsLabel)
+		names = append(slices.Clone(names), opts.MarginsName)
+	}
+
+	// the margins are the last row and column so every value is added to its cell and the margins of its row and column
+	groups := make([][]float64, len(names)*len(labels))
+	seen := make([]bool, len(groups))
+	for i := range df.Len() {
+		r, c := p.rows[i], p.columns[i]
+		if r < 0 || c < 0 {
+			continue
+		}
+		cells := []int{c*len(labels) + r}
+		if opts.Margins {
+			cells = append(cells, c*len(labels)+len(labels)-1, (len(names)-1)*len(labels)+r, len(groups)-1)
+		}
+		for _, cell := range cells {
+			groups[cell] = append(groups[cell], valueAt(i))
+			seen[cell] = true
+		}
+	}
+
+	result := make([]Column[I], len(names))
+	for c, name := range names {
+		cells := make([]float64, len(labels))
+		for r := range cells {
+			cell := c*len(labels) + r
+			cells[r] = math.NaN()
+			if seen[cell] {
+				cells[r] = opts.Aggregation.aggregate(groups[cell])
+			}
+		}
+		result[c] = NewSeries(name, cells, labels)
+	}
+	return NewDataFrame(result...), nil
+}
+
+// pivot holds the labels and column names of a Pivot and the position of every row among them, -1 if it is missing
+type pivot[I comparable] struct {
+	labels  []I
+	names   []string
+	rows    []int
+	columns []int
+}
+
+// newPivot finds the labels and column names of a Pivot
+func newPivot[I comparable, R comparable](df *DataFrame[R], index, columns string) (*pivot[I], error) {
+	indexColumn, err := typedColumn[I](df, index)
+	if err != nil {
+		return nil, err
+	}
+	pos := df.columnPos(columns)
+	if pos < 0 {
+		return nil, fmt.Errorf("no column named %q", columns)
+	}
+	columnsColumn := df.columns[pos]
+
+	p := &pivot[I]{}
+	p.names, p.columns = firstSeen(df.Len(), func(i int) (string, bool) {
+		v, ok := keyAt(columnsColumn, i)
+		return fmt.Sprint(v), ok && !isNaN(indexColumn.values[i])
+	})
+	p.labels, p.rows = firstSeen(df.Len(), func(i int) (I, bool) {
+		return indexColumn.values[i], p.columns[i] >= 0
+	})
+	if len(p.labels) == 0 {
+		return nil, errors.New("the pivot has no rows")
+	}
+	return p, nil
+}
+
+// firstSeen returns the distinct keys in order of first appearance and the position of every key among them
+// keys for which at returns false are missing and get the position -1
+func firstSeen[K comparable](n int, at func(i int) (K, bool)) ([]K, []int) {
+	keys, ids, _ := firstSeenCtx(context.Background(), n, at)
+	return keys, ids
+}
+
+// firstSeenCtx is firstSeen but stops and returns ctx.Err() when ctx is cancelled
+func firstSeenCtx[K comparable](ctx context.Context, n int, at func(i int) (K, bool)) ([]K, []int, error) {
+	var keys []K
+	lookup := make(map[K]int)
+	ids := make([]int, n)
+	for i := range ids {
+		if i%checkEvery == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, nil, err
+			}
+		}
+		k, ok := at(i)
+		if !ok {
+			ids[i] = -1
+			continue
+		}
+		id, found := lookup[k]
+		if !found {
+			id = len(keys)
+			lookup[k] = id
+			keys = append(keys, k)
+		}
+		ids[i] = id
+	}
+	return keys, ids, nil
+}
+
+// typedColumn returns the column with the given name as a typed Series like GetColumn but returns errors
+func typedColumn[T comparable, R comparable](df *DataFrame[R], name string) (*Series[T, R], error) {
+	pos := df.columnPos(name)
+	if pos < 0 {
+		return nil, fmt.Errorf("no column named %q", name)
+	}
+	s, ok := df.columns[pos].(*Series[T, R])
+	if !ok {
+		return nil, fmt.Errorf("column %q does not hold values of type %T", name, *new(T))
+	}
+	return s, nil
+}
+
+// Melt reshapes a wide DataFrame into long data
+// every value of the valueVars columns becomes a row holding the idVars columns of its row,
+// a column "variable" with the name of its column and a column "value" with the value
+// the rows of the first value column come first, valueVars are all columns except idVars if empty
+func Melt[T comparable, R comparable](df *DataFrame[R], idVars, valueVars []string) (*DataFrame[int], error) {
+	for _, name := range idVars {
+		if !df.HasColumn(name) {
+			return nil, fmt.Errorf("no column named %q", name)
+		}
+		if name == "variable" || name == "value" {
+			return nil, fmt.Errorf("id column %q has the name of a result column", name)
+		}
+	}
+	if len(valueVars) == 0 {
+		for _, name := range df.Columns() {
+			if !slices.Contains(idVars, name) {
+				valueVars = append(valueVars, name)
+			}
+		}
+		if len(valueVars) == 0 {
+			return nil, errors.New("no value columns to melt")
+		}
+	}
+
+	n := df.Len() * len(valueVars)
+	positions := make([]int, 0, n)
+	variables := make([]string, 0, n)
+	values := make([]T, 0, n)
+	for _, name := range valueVars {
+		s, err := typedColumn[T](df, name)
+		if err != nil {
+			return nil, err
+		}
+		positions = append(positions, rangePositions(0, df.Len())...)
+		for range df.Len() {
+			variables = append(variables, name)
+		}
+		values = append(values, s.values...)
+	}
+
+	columns := make([]Column[int], 0, len(idVars)+2)
+	for _, name := range idVars {
+		columns = append(columns, df.Column(name).gatherPositional(positions, nil))
+	}
+	columns = append(columns, NewIndexSeries("variable", variables), NewIndexSeries("value", values))
+	return NewDataFrame(columns...), nil
+}
+
+// Stack turns the columns of a DataFrame into the inner level of the labels of a Series
+// every value is labeled by its row label as the Key and its column name as the Label, ordered row by row
+// all columns must hold values of type T
+func Stack[T comparable, R comparable](df *DataFrame[R]) (*Series[T, KeyedLabel[R, string]], error) {
+	columns := make([]*Series[T, R], len(df.columns))
+	for i, c := range df.columns {
+		s, ok := c.(*Series[T, R])
+		if !ok {
+			return nil, fmt.Errorf("column %q does not hold values of type %T", c.Name(), *new(T))
+		}
+		columns[i] = s
+	}
+
+	n := df.Len() * len(columns)
+	values := make([]T, 0, n)
+	index := make([]KeyedLabel[R, string], 0, n)
+	for i, label := range df.index {
+		for _, s := range columns {
+			values = append(values, s.values[i])
+			index = append(index, KeyedLabel[R, string]{Key: label, Label: s.name})
+		}
+	}
+	return NewSeries("", values, index), nil
+}
+
+// Unstack turns the inner level of the labels of a Series into the columns of a DataFrame, it reverses Stack
+// the Keys become the labels and the Labels the column names, both in order of first appearance
+// missing cells are NaN or the zero value, a label must not be found twice
+func Unstack[T comparable, R comparable](s *Series[T, KeyedLabel[R, string]]) (*DataFrame[R], error) {
+	labels, rows := firstSeen(s.Len(), func(i int) (R, bool) { return s.index[i].Key, true })
+	names, columns := firstSeen(s.Len(), func(i int) (string, bool) { return s.index[i].Label, true })
+
+	wide, dup := spread(labels, names, rows, columns, s.values)
+	if dup >= 0 {
+		return nil, fmt.Errorf("label %v is found more than once", s.index[dup])
+	}
+	return wide, nil
+}
+
+// spread places every value at its row and column of a wide DataFrame, missing cells are NaN or the zero value
+// values at row or column -1 are ignored, it returns the position of the first value for an already filled cell or -1
+func spread[T comparable, I comparable](labels []I, names []string, rows, columns []int, values []T) (*DataFrame[I], int) {
+	missing := missingValue[T]()
+	cells := make([][]T, len(names))
+	for c := range cells {
+		cells[c] = make([]T, len(labels))
+		for r := range cells[c] {
+			cells[c][r] = missing
+		}
+	}
+	filled := make([]bool, len(names)*len(labels))
+	for i, v := range values {
+		r, c := rows[i], columns[i]
+		if r < 0 || c < 0 {
+			continue
+		}
+		if filled[c*len(labels)+r] {
+			return nil, i
+		}
+		filled[c*len(labels)+r] = true
+		cells[c][r] = v
+	}
+
+	result := make([]Column[I], len(names))
+	for c, name := range names {
+		result[c] = NewSeries(name, cells[c], labels)
+	}
+	return NewDataFrame(result...), -1
+}
@@ -0,0 +1,178 @@
+package series
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestPivot(t *testing.T) {
+	weather := NewDataFrame[int](
+		NewIndexSeries("date", []string{"mon", "mon", "tue", "tue", "wed", "wed"}),
+		ToCategorical(NewIndexSeries("city", []string{"Oslo", "Rome", "Oslo", "Rome", "Rome", ""}), []string{"Oslo", "Rome"}, false),
+		NewIndexSeries("temp", []float64{3, 18, 5, 20, math.NaN(), 7}),
+		NewIndexSeries("rain", []int{1, 0, 4, 0, 2, 9}),
+	)
+	df, err := Pivot[int, string](weather, "date", "city", "rain")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(df.Index(), []string{"mon", "tue", "wed"}) || !slices.Equal(df.Columns(), []string{"Oslo", "Rome"}) {
+		t.Fatalf("unexpected shape\n%v", df)
+	}
+	if oslo := GetColumn[int](df, "Oslo").Values(); !slices.Equal(oslo, []int{1, 4, 0}) {
+		t.Errorf("unexpected Oslo %v", oslo)
+	}
+	if rome := GetColumn[int](df, "Rome").Values(); !slices.Equal(rome, []int{0, 0, 2}) {
+		t.Errorf("unexpected Rome %v", rome)
+	}
+
+	temps, err := Pivot[float64, string](weather, "date", "city", "temp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if oslo := GetColumn[float64](temps, "Oslo").Values(); !equalNaN(oslo, []float64{3, 5, math.NaN()}) {
+		t.Errorf("unexpected Oslo %v", oslo)
+	}
+
+	for name, pivot := range map[string]func() error{
+		"duplicate entries": func() error {
+			_, err := Pivot[int, float64](weather.AddColumn(NewIndexSeries("one", []float64{1, 1, 1, 1, 1, 1})), "one", "city", "rain")
+			return err
+		},
+		"wrong value type": func() error {
+			_, err := Pivot[float64, string](weather, "date", "city", "rain")
+			return err
+		},
+		"missing column": func() error {
+			_, err := Pivot[int, string](weather, "date", "country", "rain")
+			return err
+		},
+	} {
+		if pivot() == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestPivotTable(t *testing.T) {
+	df := NewDataFrame[int](
+		NewIndexSeries("date", []string{"mon", "mon", "tue", "tue", "wed", "wed"}),
+		ToCategorical(NewIndexSeries("city", []string{"Oslo", "Rome", "Oslo", "Rome", "Rome", ""}), []string{"Oslo", "Rome"}, false),
+		NewIndexSeries("temp", []float64{3, 18, 5, 20, math.NaN(), 7}),
+		NewIndexSeries("rain", []int{1, 0, 4, 0, 2, 9}),
+		NewIndexSeries("season", []string{"a", "a", "a", "b", "b", "b"}),
+	)
+	for agg, want := range map[Aggregation]struct {
+		oslo, rome, all []float64
+	}{
+		AggSum:   {[]float64{8, math.NaN(), 8}, []float64{18, 20, 38}, []float64{26, 20, 46}},
+		AggMean:  {[]float64{4, math.NaN(), 4}, []float64{18, 20, 19}, []float64{26.0 / 3, 20, 11.5}},
+		AggCount: {[]float64{2, math.NaN(), 2}, []float64{1, 1, 2}, []float64{3, 1, 4}},
+		AggMin:   {[]float64{3, math.NaN(), 3}, []float64{18, 20, 18}, []float64{3, 20, 3}},
+		AggMax:   {[]float64{5, math.NaN(), 5}, []float64{18, 20, 20}, []float64{18, 20, 20}},
+	} {
+		table, err := PivotTable(df, "season", "city", "temp", PivotTableOptions[string]{Aggregation: agg, Margins: true, MarginsLabel: "total"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(table.Index(), []string{"a", "b", "total"}) || !slices.Equal(table.Columns(), []string{"Oslo", "Rome", "All"}) {
+			t.Fatalf("%d: unexpected shape\n%v", agg, table)
+		}
+		for name, want := range map[string][]float64{"Oslo": want.oslo, "Rome": want.rome, "All": want.all} {
+			if got := GetColumn[float64](table, name).Values(); !equalNaN(got, want) {
+				t.Errorf("%d: expected %s %v, got %v", agg, name, want, got)
+			}
+		}
+	}
+
+	table, err := PivotTable(df, "date", "season", "rain", PivotTableOptions[string]{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a := GetColumn[float64](table, "a").Values(); !equalNaN(a, []float64{1, 4, math.NaN()}) {
+		t.Errorf("unexpected a %v", a)
+	}
+
+	if _, err := PivotTable(df, "date", "city", "season", PivotTableOptions[string]{}); err == nil {
+		t.Error("expected error for text values")
+	}
+	if _, err := PivotTable(df, "date", "city", "rain", PivotTableOptions[string]{Margins: true, MarginsLabel: "mon"}); err == nil {
+		t.Error("expected error for a margins label of another row")
+	}
+}
+
+func TestMelt(t *testing.T) {
+	wide := NewDataFrame[int](
+		NewIndexSeries("id", []string{"a", "b"}),
+		NewIndexSeries("x", []float64{1, 2}),
+		NewIndexSeries("y", []float64{3, 4}),
+	)
+	long, err := Melt[float64](wide, []string{"id"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(long.Columns(), []string{"id", "variable", "value"}) {
+		t.Errorf("unexpected columns %v", long.Columns())
+	}
+	if id := GetColumn[string](long, "id").Values(); !slices.Equal(id, []string{"a", "b", "a", "b"}) {
+		t.Errorf("unexpected id %v", id)
+	}
+	if v := GetColumn[string](long, "variable").Values(); !slices.Equal(v, []string{"x", "x", "y", "y"}) {
+		t.Errorf("unexpected variable %v", v)
+	}
+	if v := GetColumn[float64](long, "value").Values(); !slices.Equal(v, []float64{1, 2, 3, 4}) {
+		t.Errorf("unexpected value %v", v)
+	}
+
+	back, err := Pivot[float64, string](long, "id", "variable", "value")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(GetColumn[float64](back, "y").Values(), []float64{3, 4}) {
+		t.Errorf("unexpected round trip\n%v", back)
+	}
+
+	if _, err := Melt[float64](wide, nil, nil); err == nil {
+		t.Error("expected error for a text value column")
+	}
+	if _, err := Melt[float64](wide, []string{"id", "x", "y"}, nil); err == nil {
+		t.Error("expected error without value columns")
+	}
+}
+
+func TestStack(t *testing.T) {
+	index := []string{"r1", "r2"}
+	df := NewDataFrame(
+		Column[string](NewSeries("a", []int{1, 2}, index)),
+		Column[string](NewSeries("b", []int{3, 4}, index)),
+	)
+	s, err := Stack[int](df)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(s.Values(), []int{1, 3, 2, 4}) {
+		t.Errorf("unexpected values %v", s.Values())
+	}
+	if s.index[1] != (KeyedLabel[string, string]{Key: "r1", Label: "b"}) {
+		t.Errorf("unexpected index %v", s.Index())
+	}
+
+	back, err := Unstack(s.Tail(3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(back.Index(), []string{"r1", "r2"}) || !slices.Equal(back.Columns(), []string{"b", "a"}) {
+		t.Fatalf("unexpected shape\n%v", back)
+	}
+	if b, a := GetColumn[int](back, "b").Values(), GetColumn[int](back, "a").Values(); !slices.Equal(b, []int{3, 4}) || !slices.Equal(a, []int{0, 2}) {
+		t.Errorf("unexpected values\n%v", back)
+	}
+
+	if _, err := Stack[float64](df); err == nil {
+		t.Error("expected error for columns of another type")
+	}
+	if _, err := Unstack(Concat(ConcatOptions{}, s, s)); err == nil {
+		t.Error("expected error for duplicate labels")
+	}
+}
@@ -0,0 +1,266 @@
+package series
+
+import (
+	"math"
+	"sync"
+)
+
+// Entry is a single labeled value pushed into a RingSeries
+type Entry[T Numeric, R comparable] struct {
+	Label R
+	Value T
+}
+
+// RingSeries is a streaming NumericSeries with a fixed capacity
+// once it is full every Push evicts the oldest value
+// Sum, Mean, Min, Max and Variance are kept up to date on every Push so reading them costs O(1)
+// NaN values are stored but ignored by the aggregates
+// it is safe to use from several goroutines
+type RingSeries[T Numeric, R comparable] struct {
+	mu sync.RWMutex
+
+	name   string
+	values []T
+	index  []R
+	// next is the sequence number of the next value, the value with sequence number seq
+	// is stored at seq % capacity and the oldest stored one is next - size
+	next uint64
+	size int
+
+	count int // number of values which are not NaN
+	sum   T
+	mean  float64
+	m2    float64 // sum of squared differences from the mean
+	// evictions counts the evicted values which are not NaN, the aggregates are recomputed
+	// from the window every capacity evictions so rounding errors cannot pile up
+	evictions uint64
+
+	// minSeqs and maxSeqs hold sequence numbers whose values increase (decrease) from front to back
+	// the front is the min (max) of the window
+	minSeqs []uint64
+	maxSeqs []uint64
+}
+
+// NewRingSeries creates a new empty RingSeries which holds up to capacity values
+func NewRingSeries[T Numeric, R comparable](name string, capacity int) *RingSeries[T, R] {
+	if capacity <= 0 {
+		panic("capacity must be positive")
+	}
+
+	return &RingSeries[T, R]{
+		name:   name,
+		values: make([]T, capacity),
+		index:  make([]R, capacity),
+	}
+}
+
+// Push appends a value, evicting the oldest one if the RingSeries is full
+func (rs *RingSeries[T, R]) Push(label R, value T) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	rs.push(label, value)
+}
+
+// Consume pushes every Entry received from ch until ch is closed
+func (rs *RingSeries[T, R]) Consume(ch <-chan Entry[T, R]) {
+	for e := range ch {
+		rs.Push(e.Label, e.Value)
+	}
+}
+
+// push appends a value, the caller must hold the write lock
+func (rs *RingSeries[T, R]) push(label R, value T) {
+	capacity := len(rs.values)
+	if rs.size == capacity {
+		rs.evict()
+	}
+
+	seq := rs.next
+	rs.values[seq%uint64(capacity)] = value
+	rs.index[seq%uint64(capacity)] = label
+	rs.next++
+	rs.size++
+
+	if isNaN(value) {
+		return
+	}
+
+	rs.sum += value
+	rs.count++
+	x := float64(value)
+	delta := x - rs.mean
+	rs.mean += delta / float64(rs.count)
+	rs.m2 += delta * (x - rs.mean)
+
+	for len(rs.minSeqs) > 0 && rs.at(rs.minSeqs[len(rs.minSeqs)-1]) >= value {
+		rs.minSeqs = rs.minSeqs[:len(rs.minSeqs)-1]
+	}
+	rs.minSeqs = append(rs.minSeqs, seq)
+
+	for len(rs.maxSeqs) > 0 && rs.at(rs.maxSeqs[len(rs.maxSeqs)-1]) <= value {
+		rs.maxSeqs = rs.maxSeqs[:len(rs.maxSeqs)-1]
+	}
+	rs.maxSeqs = append(rs.maxSeqs, seq)
+}
+
+// evict removes the oldest value from the aggregates, the caller must hold the write lock
+func (rs *RingSeries[T, R]) evict() {
+	seq := rs.next - uint64(rs.size)
+	value := rs.at(seq)
+	rs.size--
+
+	if isNaN(value) {
+		return
+	}
+
+	sum := rs.sum
+	rs.sum -= value
+	rs.count--
+	rs.evictions++
+	x := float64(value)
+	switch {
+	case rs.count == 0:
+		rs.sum, rs.mean, rs.m2 = 0, 0, 0
+	case math.IsInf(x, 0) || rs.evictions%uint64(len(rs.values)) == 0:
+		// removing an infinity leaves NaN behind
+		rs.recompute()
+	default:
+		// reverse of the update in push
+		oldMean := rs.mean
+		rs.mean = (oldMean*float64(rs.count+1) - x) / float64(rs.count)
+		delta := (x - oldMean) * (x - rs.mean)
+		rs.m2 -= delta
+		// a value much larger than the ones left behind takes their digits with it
+		if cancels(float64(sum), x) || cancels(oldMean*float64(rs.count+1), x) || cancels(rs.m2+delta, delta) {
+			rs.recompute()
+		}
+	}
+
+	if len(rs.minSeqs) > 0 && rs.minSeqs[0] == seq {
+		rs.minSeqs = rs.minSeqs[1:]
+	}
+	if len(rs.maxSeqs) > 0 && rs.maxSeqs[0] == seq {
+		rs.maxSeqs = rs.maxSeqs[1:]
+	}
+}
+
+// recompute sets sum, mean and m2 from the values currently held, the caller must hold the write lock
+func (rs *RingSeries[T, R]) recompute() {
+	rs.sum, rs.mean, rs.m2 = 0, 0, 0
+	count := 0
+	for seq := rs.next - uint64(rs.size); seq < rs.next; seq++ {
+		value := rs.at(seq)
+		if isNaN(value) {
+			continue
+		}
+		rs.sum += value
+		count++
+		x := float64(value)
+		delta := x - rs.mean
+		rs.mean += delta / float64(count)
+		rs.m2 += delta * (x - rs.mean)
+	}
+}
+
+// cancels reports whether a - b loses more than half of the digits of a and b
+func cancels(a, b float64) bool {
+	return (a != 0 || b != 0) && math.Abs(a-b) <= 0x1p-26*max(math.Abs(a), math.Abs(b))
+}
+
+// at returns the value with the given sequence number
+func (rs *RingSeries[T, R]) at(seq uint64) T {
+	return rs.values[seq%uint64(len(rs.values))]
+}
+
+// Len returns the number of values currently held
+func (rs *RingSeries[T, R]) Len() int {
+	rs.mu.RLock()
+	defer rs.mu.RUnlock()
+	return rs.size
+}
+
+// Cap returns the maximum number of values the RingSeries holds
+func (rs *RingSeries[T, R]) Cap() int {
+	return len(rs.values)
+}
+
+// Name return the name of the RingSeries
+func (rs *RingSeries[T, R]) Name() string {
+	rs.mu.RLock()
+	defer rs.mu.RUnlock()
+	return rs.name
+}
+
+// Sum returns the sum of the values currently held
+func (rs *RingSeries[T, R]) Sum() T {
+	rs.mu.RLock()
+	defer rs.mu.RUnlock()
+	return rs.sum
+}
+
+// Mean returns the mean of the values currently held
+func (rs *RingSeries[T, R]) Mean() float64 {
+	rs.mu.RLock()
+	defer rs.mu.RUnlock()
+	if rs.count == 0 {
+		panic("cannot get mean of empty series")
+	}
+	return rs.mean
+}
+
+// Min returns the smallest value currently held
+func (rs *RingSeries[T, R]) Min() T {
+	rs.mu.RLock()
+	defer rs.mu.RUnlock()
+	if len(rs.minSeqs) == 0 {
+		panic("cannot get min of empty series")
+	}
+	return rs.at(rs.minSeqs[0])
+}
+
+// Max returns the largest value currently held
+func (rs *RingSeries[T, R]) Max() T {
+	rs.mu.RLock()
+	defer rs.mu.RUnlock()
+	if len(rs.maxSeqs) == 0 {
+		panic("cannot get max of empty series")
+	}
+	return rs.at(rs.maxSeqs[0])
+}
+
+// Variance returns the variance of the values currently held
+// dof is degrees of freedom, typically 0 for population(complete set) and 1 for sample(uncomplete set)
+func (rs *RingSeries[T, R]) Variance(dof int) float64 {
+	rs.mu.RLock()
+	defer rs.mu.RUnlock()
+	if rs.count == 0 {
+		panic("cannot get variance of empty series")
+	}
+	if dof < 0 {
+		panic("degrees of freedom must be non-negative")
+	}
+	// removing values can leave a tiny negative rounding error
+	return max(rs.m2, 0) / float64(rs.count-dof)
+}
+
+// StdDev returns the standard deviation of the values currently held
+// dof is degrees of freedom, typically 0 for population(complete set) and 1 for sample(uncomplete set)
+func (rs *RingSeries[T, R]) StdDev(dof int) float64 {
+	return math.Sqrt(rs.Variance(dof))
+}
+
+// Snapshot copies the values currently held from oldest to newest into a NumericSeries
+func (rs *RingSeries[T, R]) Snapshot() *NumericSeries[T, R] {
+	rs.mu.RLock()
+	defer rs.mu.RUnlock()
+
+	values := make([]T, rs.size)
+	index := make([]R, rs.size)
+	capacity := uint64(len(rs.values))
+	oldest := rs.next - uint64(rs.size)
+	for i := range rs.size {
+		values[i] = rs.values[(oldest+uint64(i))%capacity]
+		index[i] = rs.index[(oldest+uint64(i))%capacity]
+	}
+	return NewNumericSeries(rs.name, values, index)
+}
@@ -0,0 +1,150 @@
+package series
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestRingSeries_Push(t *testing.T) {
+	t.Run("evicts the oldest values", func(t *testing.T) {
+		rs := NewRingSeries[int, string]("stream", 3)
+		for i, label := range []string{"a", "b", "c", "d", "e"} {
+			rs.Push(label, i+1)
+		}
+
+		snapshot := rs.Snapshot()
+		if !slices.Equal(snapshot.Values(), []int{3, 4, 5}) {
+			t.Errorf("unexpected values %v", snapshot.Values())
+		}
+		if !slices.Equal(snapshot.Index(), []string{"c", "d", "e"}) {
+			t.Errorf("unexpected labels %v", snapshot.Index())
+		}
+		if rs.Len() != 3 || rs.Cap() != 3 {
+			t.Errorf("expected length and capacity 3, got %d and %d", rs.Len(), rs.Cap())
+		}
+	})
+
+	t.Run("keeps aggregates up to date", func(t *testing.T) {
+		rs := NewRingSeries[int, int]("stream", 3)
+		for i, v := range []int{5, 1, 4, 2, 8} {
+			rs.Push(i, v)
+		}
+
+		// window is 4, 2, 8
+		if rs.Sum() != 14 || rs.Min() != 2 || rs.Max() != 8 {
+			t.Errorf("unexpected sum %d, min %d or max %d", rs.Sum(), rs.Min(), rs.Max())
+		}
+		if math.Abs(rs.Mean()-14.0/3) > 1e-12 {
+			t.Errorf("unexpected mean %f", rs.Mean())
+		}
+	})
+
+	t.Run("panics with non-positive capacity", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("expected panic for capacity 0")
+			}
+		}()
+		NewRingSeries[int, int]("stream", 0)
+	})
+
+	t.Run("panics reading min of empty series", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("expected panic for empty series")
+			}
+		}()
+		NewRingSeries[int, int]("stream", 2).Min()
+	})
+}
+
+func TestRingSeries_MatchesSnapshot(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	rs := NewRingSeries[float64, int]("random", 50)
+
+	for i := range 1000 {
+		rs.Push(i, rng.NormFloat64()*100+20)
+		if i%37 != 0 {
+			continue
+		}
+
+		snapshot := rs.Snapshot()
+		if math.Abs(rs.Sum()-snapshot.Sum()) > 1e-6 {
+			t.Fatalf("push %d: expected sum %f, got %f", i, snapshot.Sum(), rs.Sum())
+		}
+		if math.Abs(rs.Mean()-snapshot.Mean()) > 1e-9 {
+			t.Fatalf("push %d: expected mean %f, got %f", i, snapshot.Mean(), rs.Mean())
+		}
+		if rs.Min() != snapshot.Min() || rs.Max() != snapshot.Max() {
+			t.Fatalf("push %d: expected min %f and max %f, got %f and %f", i, snapshot.Min(), snapshot.Max(), rs.Min(), rs.Max())
+		}
+		if snapshot.Len() > 1 && math.Abs(rs.StdDev(1)-snapshot.StdDev(1)) > 1e-6 {
+			t.Fatalf("push %d: expected std dev %f, got %f", i, snapshot.StdDev(1), rs.StdDev(1))
+		}
+	}
+}
+
+func TestRingSeries_NaN(t *testing.T) {
+	rs := NewRingSeries[float64, int]("stream", 3)
+	rs.Push(0, 1)
+	rs.Push(1, math.NaN())
+	rs.Push(2, 3)
+
+	if rs.Sum() != 4 || rs.Mean() != 2 || rs.Min() != 1 || rs.Max() != 3 {
+		t.Error("NaN should be ignored by the aggregates")
+	}
+
+	rs.Push(3, 5)
+	rs.Push(4, 7)
+	if rs.Sum() != 15 || rs.Min() != 3 {
+		t.Errorf("unexpected sum %f or min %f after evicting NaN", rs.Sum(), rs.Min())
+	}
+	if rs.Variance(0) != 8.0/3 {
+		t.Errorf("unexpected variance %f", rs.Variance(0))
+	}
+}
+
+func TestRingSeries_EvictsLargeValues(t *testing.T) {
+	for _, first := range []float64{math.Inf(1), math.Inf(-1), 1e16} {
+		rs := NewRingSeries[float64, int]("stream", 2)
+		rs.Push(0, first)
+		rs.Push(1, 1)
+		rs.Push(2, 1)
+		rs.Push(3, 1)
+
+		if rs.Sum() != 2 || rs.Mean() != 1 || rs.Variance(0) != 0 {
+			t.Errorf("after evicting %g: unexpected sum %f, mean %f or variance %f", first, rs.Sum(), rs.Mean(), rs.Variance(0))
+		}
+	}
+
+	rs := NewRingSeries[float64, int]("stream", 1)
+	rs.Push(0, math.Inf(1))
+	rs.Push(1, 2)
+	if rs.Sum() != 2 || rs.Mean() != 2 {
+		t.Errorf("unexpected sum %f or mean %f after evicting the only value", rs.Sum(), rs.Mean())
+	}
+}
+
+func TestRingSeries_Consume(t *testing.T) {
+	rs := NewRingSeries[int, int]("stream", 10)
+	ch := make(chan Entry[int, int])
+
+	var wg sync.WaitGroup
+	wg.Go(func() {
+		rs.Consume(ch)
+	})
+
+	for i := range 25 {
+		ch <- Entry[int, int]{Label: i, Value: i}
+		_ = rs.Len()
+	}
+	close(ch)
+	wg.Wait()
+
+	if rs.Len() != 10 || rs.Min() != 15 || rs.Max() != 24 {
+		t.Errorf("unexpected values %v", rs.Snapshot().Values())
+	}
+}
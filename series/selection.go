@@ -0,0 +1,131 @@
+package series
+
+import (
+	"container/heap"
+	"slices"
+)
+
+// Keep tells NLargest and NSmallest what to do with ties at the boundary
+type Keep int
+
+const (
+	// KeepFirst keeps the tied values which come first in the Series
+	KeepFirst Keep = iota
+	// KeepLast keeps the tied values which come last in the Series
+	KeepLast
+	// KeepAll keeps all tied values even if the result gets longer than n
+	KeepAll
+)
+
+// NLargest returns the n largest values of the Series ordered from largest to smallest
+// values which are equal keep their order in the Series and NaN values are ignored
+// panics if all values are NaN since the result would be empty
+// uses a heap of size n so it runs in O(len * log n) instead of sorting the whole Series
+func (ns *NumericSeries[T, R]) NLargest(n int, keep Keep) *NumericSeries[T, R] {
+	return ns.selectN(n, keep, func(a, b T) bool { return a > b })
+}
+
+// NSmallest returns the n smallest values of the Series ordered from smallest to largest
+// values which are equal keep their order in the Series and NaN values are ignored
+// panics if all values are NaN since the result would be empty
+// uses a heap of size n so it runs in O(len * log n) instead of sorting the whole Series
+func (ns *NumericSeries[T, R]) NSmallest(n int, keep Keep) *NumericSeries[T, R] {
+	return ns.selectN(n, keep, func(a, b T) bool { return a < b })
+}
+
+// TopK returns the k largest values of the Series, it is the same as NLargest(k, KeepFirst)
+func (ns *NumericSeries[T, R]) TopK(k int) *NumericSeries[T, R] {
+	return ns.NLargest(k, KeepFirst)
+}
+
+// selectN returns the n best values, a value a is better than b if before(a, b) is true
+func (ns *NumericSeries[T, R]) selectN(n int, keep Keep, before func(a, b T) bool) *NumericSeries[T, R] {
+	if n <= 0 {
+		panic("n must be positive")
+	}
+
+	// better decides which of two positions is kept, ties are broken by position
+	better := func(i, j int) bool {
+		a, b := ns.values[i], ns.values[j]
+		if a != b {
+			return before(a, b)
+		}
+		if keep == KeepLast {
+			return i > j
+		}
+		return i < j
+	}
+
+	// the worst kept position is at the top of the heap so it can be replaced
+	h := &positionHeap{less: func(i, j int) bool { return better(j, i) }}
+	for i, v := range ns.values {
+		if isNaN(v) {
+			continue
+		}
+		if h.Len() < n {
+			heap.Push(h, i)
+		} else if better(i, h.positions[0]) {
+			h.positions[0] = i
+			heap.Fix(h, 0)
+		}
+	}
+
+	if h.Len() == 0 {
+		panic("cannot select from a Series with only NaN values")
+	}
+
+	positions := h.positions
+	if keep == KeepAll && h.Len() == n {
+		threshold := ns.values[h.positions[0]]
+		kept := make(map[int]bool, len(positions))
+		for _, p := range positions {
+			kept[p] = true
+		}
+		for i, v := range ns.values {
+			if v == threshold && !kept[i] {
+				positions = append(positions, i)
+			}
+		}
+	}
+
+	slices.SortFunc(positions, func(i, j int) int {
+		a, b := ns.values[i], ns.values[j]
+		switch {
+		case a != b && before(a, b):
+			return -1
+		case a != b:
+			return 1
+		}
+		return i - j
+	})
+
+	return &NumericSeries[T, R]{Series: ns.takePositions(positions)}
+}
+
+// positionHeap is a heap of positions ordered by less
+type positionHeap struct {
+	positions []int
+	less      func(i, j int) bool
+}
+
+func (h *positionHeap) Len() int {
+	return len(h.positions)
+}
+
+func (h *positionHeap) Less(i, j int) bool {
+	return h.less(h.positions[i], h.positions[j])
+}
+
+func (h *positionHeap) Swap(i, j int) {
+	h.positions[i], h.positions[j] = h.positions[j], h.positions[i]
+}
+
+func (h *positionHeap) Push(x any) {
+	h.positions = append(h.positions, x.(int))
+}
+
+func (h *positionHeap) Pop() any {
+	last := h.positions[len(h.positions)-1]
+	h.positions = h.positions[:len(h.positions)-1]
+	return last
+}
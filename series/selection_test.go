@@ -0,0 +1,168 @@
+package series
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestNLargest(t *testing.T) {
+	t.Run("returns the n largest values in order", func(t *testing.T) {
+		values := []int{5, 1, 9, 3, 7}
+		index := []string{"a", "b", "c", "d", "e"}
+		ns := NewNumericSeries("test", values, index)
+
+		result := ns.NLargest(3, KeepFirst)
+		expectedValues := []int{9, 7, 5}
+		expectedIndex := []string{"c", "e", "a"}
+
+		for i := range expectedValues {
+			label, value := result.AtIndex(i)
+			if value != expectedValues[i] || label != expectedIndex[i] {
+				t.Errorf("expected %s: %d at position %d, got %s: %d", expectedIndex[i], expectedValues[i], i, label, value)
+			}
+		}
+	})
+
+	t.Run("keeps first ties", func(t *testing.T) {
+		ns := NewIndexNumericSeries("test", []int{3, 5, 3, 3, 1})
+
+		result := ns.NLargest(2, KeepFirst)
+		idx := result.Index()
+		if idx[0] != 1 || idx[1] != 0 {
+			t.Errorf("expected labels [1 0], got %v", idx)
+		}
+	})
+
+	t.Run("keeps last ties", func(t *testing.T) {
+		ns := NewIndexNumericSeries("test", []int{3, 5, 3, 3, 1})
+
+		result := ns.NLargest(2, KeepLast)
+		idx := result.Index()
+		if idx[0] != 1 || idx[1] != 3 {
+			t.Errorf("expected labels [1 3], got %v", idx)
+		}
+	})
+
+	t.Run("keeps all ties", func(t *testing.T) {
+		ns := NewIndexNumericSeries("test", []int{3, 5, 3, 3, 1})
+
+		result := ns.NLargest(2, KeepAll)
+		expectedIndex := []int{1, 0, 2, 3}
+		idx := result.Index()
+		if !slices.Equal(idx, expectedIndex) {
+			t.Errorf("expected labels %v, got %v", expectedIndex, idx)
+		}
+	})
+
+	t.Run("returns whole series when n exceeds length", func(t *testing.T) {
+		ns := NewIndexNumericSeries("test", []int{1, 2})
+
+		result := ns.NLargest(10, KeepAll)
+		if result.Len() != 2 {
+			t.Errorf("expected length 2, got %d", result.Len())
+		}
+	})
+
+	t.Run("ignores NaN values", func(t *testing.T) {
+		ns := NewIndexNumericSeries("test", []float64{math.NaN(), 1, 2})
+
+		result := ns.NLargest(3, KeepFirst)
+		if result.Len() != 2 || result.At(0) != 2 {
+			t.Errorf("unexpected result %v", result.Values())
+		}
+	})
+
+	t.Run("panics if all values are NaN", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r != "cannot select from a Series with only NaN values" {
+				t.Errorf("unexpected panic %v", r)
+			}
+		}()
+		ns := NewIndexNumericSeries("test", []float64{math.NaN(), math.NaN()})
+
+		ns.NSmallest(1, KeepAll)
+	})
+
+	t.Run("matches full sort on random data", func(t *testing.T) {
+		rng := rand.New(rand.NewSource(1))
+		values := make([]int, 1000)
+		for i := range values {
+			values[i] = rng.Intn(100)
+		}
+		ns := NewIndexNumericSeries("random", values)
+
+		result := ns.NLargest(25, KeepFirst)
+		expected := SortByValue(ns.Series, false).Head(25)
+
+		if !slices.Equal(result.Values(), expected.Values()) {
+			t.Errorf("expected values %v, got %v", expected.Values(), result.Values())
+		}
+		if !slices.Equal(result.Index(), expected.Index()) {
+			t.Errorf("expected labels %v, got %v", expected.Index(), result.Index())
+		}
+	})
+
+	t.Run("panics with non-positive n", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("expected panic for n = 0")
+			}
+		}()
+		ns := NewIndexNumericSeries("test", []int{1, 2})
+		ns.NLargest(0, KeepFirst)
+	})
+}
+
+func TestNSmallest(t *testing.T) {
+	t.Run("returns the n smallest values in order", func(t *testing.T) {
+		ns := NewIndexNumericSeries("test", []float64{5.5, 1.5, 9.5, 3.5})
+
+		result := ns.NSmallest(2, KeepFirst)
+		if result.At(0) != 1.5 || result.At(1) != 3.5 {
+			t.Errorf("unexpected result %v", result.Values())
+		}
+	})
+
+	t.Run("keeps last ties", func(t *testing.T) {
+		ns := NewIndexNumericSeries("test", []int{2, 1, 2, 2})
+
+		result := ns.NSmallest(2, KeepLast)
+		idx := result.Index()
+		if idx[0] != 1 || idx[1] != 3 {
+			t.Errorf("expected labels [1 3], got %v", idx)
+		}
+	})
+}
+
+func TestTopK(t *testing.T) {
+	ns := NewIndexNumericSeries("test", []uint8{4, 8, 1, 8})
+
+	result := ns.TopK(2)
+	idx := result.Index()
+	if idx[0] != 1 || idx[1] != 3 {
+		t.Errorf("expected labels [1 3], got %v", idx)
+	}
+}
+
+func BenchmarkNLargest(b *testing.B) {
+	rng := rand.New(rand.NewSource(1))
+	values := make([]float64, 1_000_000)
+	for i := range values {
+		values[i] = rng.Float64()
+	}
+	ns := NewIndexNumericSeries("random", values)
+
+	b.Run("NLargest", func(b *testing.B) {
+		for b.Loop() {
+			ns.NLargest(10, KeepFirst)
+		}
+	})
+
+	b.Run("SortByValue", func(b *testing.B) {
+		for b.Loop() {
+			SortByValue(ns.Series, false).Head(10)
+		}
+	})
+}
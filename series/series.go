@@ -1,9 +1,7 @@
 package series
 
 import (
-	"cmp"
 	"fmt"
-	"slices"
 	"strings"
 )
 
@@ -193,74 +191,6 @@ func SetIndex[T comparable, R comparable, S comparable](s *Series[T, R], newInde
 	return NewSeries[T, S](s.name, s.values, newIndex)
 }
 
-// SortByIndex sorts the Series by its labels
-// Returns a new sorted Series, the original Series is not modified
-// asc: true for ascending order, false for descending order
-func SortByIndex[T comparable, R cmp.Ordered](s *Series[T, R], asc bool) *Series[T, R] {
-	// Create pairs of index and value
-	type pair struct {
-		label R
-		value T
-	}
-
-	pairs := make([]pair, s.Len())
-	for i := range s.Len() {
-		pairs[i] = pair{label: s.index[i], value: s.values[i]}
-	}
-
-	// Sort pairs by label
-	slices.SortFunc(pairs, func(a, b pair) int {
-		if asc {
-			return cmp.Compare(a.label, b.label)
-		}
-		return cmp.Compare(b.label, a.label)
-	})
-
-	// Extract sorted values and index
-	sortedValues := make([]T, s.Len())
-	sortedIndex := make([]R, s.Len())
-	for i, p := range pairs {
-		sortedValues[i] = p.value
-		sortedIndex[i] = p.label
-	}
-
-	return NewSeries(s.name, sortedValues, sortedIndex)
-}
-
-// SortByValue sorts the Series by its values
-// Returns a new sorted Series, the original Series is not modified
-// asc: true for ascending order, false for descending order
-func SortByValue[T cmp.Ordered, R comparable](s *Series[T, R], asc bool) *Series[T, R] {
-	// Create pairs of index and value
-	type pair struct {
-		label R
-		value T
-	}
-
-	pairs := make([]pair, s.Len())
-	for i := range s.Len() {
-		pairs[i] = pair{label: s.index[i], value: s.values[i]}
-	}
-
-	// Sort pairs by value
-	slices.SortFunc(pairs, func(a, b pair) int {
-		if asc {
-			return cmp.Compare(a.value, b.value)
-		}
-		return cmp.Compare(b.value, a.value)
-	})
-
-	// Extract sorted values and index
-	sortedValues := make([]T, s.Len())
-	sortedIndex := make([]R, s.Len())
-	for i, p := range pairs {
-		sortedValues[i] = p.value
-		sortedIndex[i] = p.label
-	}
-
-	return NewSeries(s.name, sortedValues, sortedIndex)
-}
-
 // IsIn checks if the given value is in the Series
 func (s *Series[T, R]) IsIn(find T) bool {
 	for i := range s.values {
@@ -281,3 +211,28 @@ func (s *Series[T, R]) Copy() *Series[T, R] {
 
 	return NewSeries(s.name, copiedValues, copiedIndex)
 }
+
+// Filter returns a new Series with only the values where the mask is true
+// the mask is applied by position and must have the same length as the Series
+func (s *Series[T, R]) Filter(mask *Series[bool, R]) *Series[T, R] {
+	if mask.Len() != s.Len() {
+		panic("mask must have the same length as the series")
+	}
+
+	var positions []int
+	for i, keep := range mask.values {
+		if keep {
+			positions = append(positions, i)
+		}
+	}
+	return s.takePositions(positions)
+}
+
+// mapValues applies f to every value and returns the results as a new Series with the same name and labels
+func mapValues[T comparable, U comparable, R comparable](s *Series[T, R], f func(v T) U) *Series[U, R] {
+	values := make([]U, s.Len())
+	for i, v := range s.values {
+		values[i] = f(v)
+	}
+	return NewSeries(s.name, values, s.index)
+}
@@ -3,7 +3,6 @@ package series
 import (
 	"fmt"
 	"math"
-	"reflect"
 )
 
 type Numeric interface {
@@ -130,6 +129,15 @@ func (ns *NumericSeries[T, R]) Operation(other *NumericSeries[T, R], op func(a,
 	return NewNumericSeries[T, R](name, resultValues, ns.index)
 }
 
+// Map applies f to every value and returns the results as a new Series
+func (ns *NumericSeries[T, R]) Map(f func(v T) T) *NumericSeries[T, R] {
+	mapped := make([]T, ns.Len())
+	for i, v := range ns.values {
+		mapped[i] = f(v)
+	}
+	return NewNumericSeries[T, R](ns.name, mapped, ns.index)
+}
+
 // Add adds two NumericSeries element-wise
 // use an empty string for name to get the default name
 func (ns *NumericSeries[T, R]) Add(other *NumericSeries[T, R], name string) *NumericSeries[T, R] {
@@ -158,37 +166,64 @@ func (ns *NumericSeries[T, R]) Multiply(other *NumericSeries[T, R], name string)
 }
 
 // Divide divides two NumericSeries element-wise
+// integer series do integer division and panic on division by zero, float series follow IEEE 754
 // use an empty string for name to get the default name
 func (ns *NumericSeries[T, R]) Divide(other *NumericSeries[T, R], name string) *NumericSeries[T, R] {
+	float := isFloat[T]()
 	dividefunc := func(a, b T) T {
+		if !float && b == 0 {
+			panic("integer division by zero")
+		}
 		return a / b
 	}
 	return ns.Operation(other, dividefunc, name)
 }
 
 // Mod does modulus of two NumericSeries element-wise
+// the result has the sign of the dividend like Go's % and math.Mod
+// integer series panic on division by zero, float series return NaN
 // use an empty string for name to get the default name
 func (ns *NumericSeries[T, R]) Mod(other *NumericSeries[T, R], name string) *NumericSeries[T, R] {
-	var zero T
-	switch any(zero).(type) {
-	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
-		// ok, continue
-	default:
-		panic("modulus operation only supported for whole number types")
-	}
-
+	float := isFloat[T]()
 	modfunc := func(a, b T) T {
-		// Use reflection to perform modulus
-		aVal := reflect.ValueOf(a)
-		bVal := reflect.ValueOf(b)
-
-		result := aVal.Int() % bVal.Int()
-		return reflect.ValueOf(result).Convert(reflect.TypeOf(a)).Interface().(T)
+		if float {
+			return T(math.Mod(float64(a), float64(b)))
+		}
+		if b == 0 {
+			panic("integer division by zero")
+		}
+		// a % b is not allowed for Numeric, but integer division truncates just like %
+		return a - (a/b)*b
 	}
 	return ns.Operation(other, modfunc, name)
 }
 
+// FloorDiv divides two NumericSeries element-wise and rounds the result down
+// unlike Divide the result of an integer series is rounded towards negative infinity, not zero
+// integer series panic on division by zero, float series follow IEEE 754
+// use an empty string for name to get the default name
+func (ns *NumericSeries[T, R]) FloorDiv(other *NumericSeries[T, R], name string) *NumericSeries[T, R] {
+	float := isFloat[T]()
+	floordivfunc := func(a, b T) T {
+		if float {
+			return T(math.Floor(float64(a) / float64(b)))
+		}
+		if b == 0 {
+			panic("integer division by zero")
+		}
+		quotient := a / b
+		// the remainder has the sign of a, if it differs from the sign of b the quotient was rounded up
+		if remainder := a - quotient*b; remainder != 0 && (remainder < 0) != (b < 0) {
+			quotient--
+		}
+		return quotient
+	}
+	return ns.Operation(other, floordivfunc, name)
+}
+
 // Pow raises each element of the Series to the given power
+// the result is converted back into T, so fractional results of integer series are truncated
+// use PowFloat to keep them
 func (ns *NumericSeries[T, R]) Pow(power float64, name string) *NumericSeries[T, R] {
 	if name == "" {
 		name = fmt.Sprintf("%v_pow(%v)", ns.name, power)
@@ -203,6 +238,20 @@ func (ns *NumericSeries[T, R]) Pow(power float64, name string) *NumericSeries[T,
 	return NewNumericSeries[T, R](name, powValues, ns.index)
 }
 
+// PowFloat raises each element of the Series to the given power and returns the results as float64
+func (ns *NumericSeries[T, R]) PowFloat(power float64, name string) *NumericSeries[float64, R] {
+	if name == "" {
+		name = fmt.Sprintf("%v_pow(%v)", ns.name, power)
+	}
+
+	powValues := make([]float64, ns.Len())
+	for i, v := range ns.values {
+		powValues[i] = math.Pow(float64(v), power)
+	}
+
+	return NewNumericSeries[float64, R](name, powValues, ns.index)
+}
+
 // ArgMax returns the index position of the largest value in the Series
 func (ns *NumericSeries[T, R]) ArgMax() R {
 	if ns.Len() == 0 {
@@ -325,3 +374,10 @@ func (ns *NumericSeries[T, R]) Correlation(other *NumericSeries[T, R]) float64 {
 
 	return 0.0
 }
+
+// isFloat checks if T is a floating point type
+// converting 0.5 into an integer type truncates it to 0
+func isFloat[T Numeric]() bool {
+	half := 0.5
+	return T(half) != 0
+}
@@ -845,6 +845,31 @@ func TestDivide(t *testing.T) {
 	})
 }
 
+func TestDivide_ByZero(t *testing.T) {
+	t.Run("panics with integer division by zero", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("expected panic for division by zero")
+			}
+		}()
+
+		ns1 := NewIndexNumericSeries("series1", []int{1, 2})
+		ns2 := NewIndexNumericSeries("series2", []int{1, 0})
+
+		ns1.Divide(ns2, "")
+	})
+
+	t.Run("returns infinity for float division by zero", func(t *testing.T) {
+		ns1 := NewIndexNumericSeries("series1", []float64{1})
+		ns2 := NewIndexNumericSeries("series2", []float64{0})
+
+		result := ns1.Divide(ns2, "")
+		if !math.IsInf(result.At(0), 1) {
+			t.Errorf("expected +Inf, got %f", result.At(0))
+		}
+	})
+}
+
 func TestMod(t *testing.T) {
 	t.Run("calculates modulus of two integer series", func(t *testing.T) {
 		values1 := []int{10, 17, 23}
@@ -918,17 +943,73 @@ func TestMod(t *testing.T) {
 		}
 	})
 
-	t.Run("panics with float series", func(t *testing.T) {
+	t.Run("calculates modulus of float series", func(t *testing.T) {
+		values1 := []float64{10.5, 20.5, -7.5}
+		values2 := []float64{3.0, 5.0, 2.0}
+		ns1 := NewIndexNumericSeries("series1", values1)
+		ns2 := NewIndexNumericSeries("series2", values2)
+
+		result := ns1.Mod(ns2, "")
+		expected := []float64{1.5, 0.5, -1.5}
+
+		for i := range expected {
+			if result.At(i) != expected[i] {
+				t.Errorf("expected %f at position %d, got %f", expected[i], i, result.At(i))
+			}
+		}
+	})
+
+	t.Run("handles unsigned series", func(t *testing.T) {
+		values1 := []uint64{10, 17, 1 << 63}
+		values2 := []uint64{3, 5, 7}
+		ns1 := NewIndexNumericSeries("series1", values1)
+		ns2 := NewIndexNumericSeries("series2", values2)
+
+		result := ns1.Mod(ns2, "")
+		expected := []uint64{1, 2, (1 << 63) % 7}
+
+		for i := range expected {
+			if result.At(i) != expected[i] {
+				t.Errorf("expected %d at position %d, got %d", expected[i], i, result.At(i))
+			}
+		}
+	})
+
+	t.Run("keeps the sign of the dividend", func(t *testing.T) {
+		values1 := []int8{-7, 7, -7}
+		values2 := []int8{3, -3, -3}
+		ns1 := NewIndexNumericSeries("series1", values1)
+		ns2 := NewIndexNumericSeries("series2", values2)
+
+		result := ns1.Mod(ns2, "")
+		expected := []int8{-1, 1, -1}
+
+		for i := range expected {
+			if result.At(i) != expected[i] {
+				t.Errorf("expected %d at position %d, got %d", expected[i], i, result.At(i))
+			}
+		}
+	})
+
+	t.Run("returns NaN for float modulus by zero", func(t *testing.T) {
+		ns1 := NewIndexNumericSeries("series1", []float64{1.5})
+		ns2 := NewIndexNumericSeries("series2", []float64{0})
+
+		result := ns1.Mod(ns2, "")
+		if !math.IsNaN(result.At(0)) {
+			t.Errorf("expected NaN, got %f", result.At(0))
+		}
+	})
+
+	t.Run("panics with integer modulus by zero", func(t *testing.T) {
 		defer func() {
 			if r := recover(); r == nil {
-				t.Error("expected panic for float series")
+				t.Error("expected panic for modulus by zero")
 			}
 		}()
 
-		values1 := []float64{10.5, 20.5, 30.5}
-		values2 := []float64{3.0, 5.0, 7.0}
-		ns1 := NewIndexNumericSeries("series1", values1)
-		ns2 := NewIndexNumericSeries("series2", values2)
+		ns1 := NewIndexNumericSeries("series1", []uint{1, 2})
+		ns2 := NewIndexNumericSeries("series2", []uint{1, 0})
 
 		ns1.Mod(ns2, "")
 	})
@@ -1057,6 +1138,88 @@ func TestPow(t *testing.T) {
 	})
 }
 
+func TestFloorDiv(t *testing.T) {
+	t.Run("rounds integer division towards negative infinity", func(t *testing.T) {
+		values1 := []int{7, -7, 7, -7, 6}
+		values2 := []int{2, 2, -2, -2, 3}
+		ns1 := NewIndexNumericSeries("series1", values1)
+		ns2 := NewIndexNumericSeries("series2", values2)
+
+		result := ns1.FloorDiv(ns2, "")
+		expected := []int{3, -4, -4, 3, 2}
+
+		for i := range expected {
+			if result.At(i) != expected[i] {
+				t.Errorf("expected %d at position %d, got %d", expected[i], i, result.At(i))
+			}
+		}
+	})
+
+	t.Run("floors float division", func(t *testing.T) {
+		values1 := []float64{7.5, -7.5}
+		values2 := []float64{2, 2}
+		ns1 := NewIndexNumericSeries("series1", values1)
+		ns2 := NewIndexNumericSeries("series2", values2)
+
+		result := ns1.FloorDiv(ns2, "")
+		expected := []float64{3, -4}
+
+		for i := range expected {
+			if result.At(i) != expected[i] {
+				t.Errorf("expected %f at position %d, got %f", expected[i], i, result.At(i))
+			}
+		}
+	})
+
+	t.Run("handles unsigned series", func(t *testing.T) {
+		ns1 := NewIndexNumericSeries("series1", []uint8{255, 7})
+		ns2 := NewIndexNumericSeries("series2", []uint8{2, 7})
+
+		result := ns1.FloorDiv(ns2, "")
+		if result.At(0) != 127 || result.At(1) != 1 {
+			t.Errorf("unexpected values %v", result.Values())
+		}
+	})
+
+	t.Run("panics with integer division by zero", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("expected panic for division by zero")
+			}
+		}()
+
+		ns1 := NewIndexNumericSeries("series1", []int{1})
+		ns2 := NewIndexNumericSeries("series2", []int{0})
+
+		ns1.FloorDiv(ns2, "")
+	})
+}
+
+func TestPowFloat(t *testing.T) {
+	t.Run("keeps fractional results of integer series", func(t *testing.T) {
+		values := []int{4, 2, 10}
+		ns := NewIndexNumericSeries("test", values)
+
+		result := ns.PowFloat(-1.0, "")
+		expected := []float64{0.25, 0.5, 0.1}
+
+		for i := range expected {
+			if math.Abs(result.At(i)-expected[i]) > 0.0001 {
+				t.Errorf("expected %f at position %d, got %f", expected[i], i, result.At(i))
+			}
+		}
+	})
+
+	t.Run("uses default name when empty string provided", func(t *testing.T) {
+		ns := NewIndexNumericSeries("mytest", []int{2})
+
+		result := ns.PowFloat(0.5, "")
+		if result.Name() != "mytest_pow(0.5)" {
+			t.Errorf("expected name 'mytest_pow(0.5)', got %s", result.Name())
+		}
+	})
+}
+
 func TestNumericSeries_CoVariance(t *testing.T) {
 	t.Run("correctly computes covariance between two integer series", func(t *testing.T) {
 		values1 := []int{1, 2, 3, 4, 5}
@@ -738,3 +738,25 @@ func TestCopy(t *testing.T) {
 		}
 	})
 }
+
+func TestFilter(t *testing.T) {
+	t.Run("keeps values where mask is true", func(t *testing.T) {
+		s := NewSeries("test", []int{1, 2, 3}, []string{"a", "b", "c"})
+		mask := NewSeries("mask", []bool{true, false, true}, []string{"a", "b", "c"})
+
+		filtered := s.Filter(mask)
+		if filtered.Len() != 2 || filtered.Get("c") != 3 {
+			t.Errorf("unexpected values %v", filtered.Values())
+		}
+	})
+
+	t.Run("panics with mismatched length", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("expected panic for mismatched length")
+			}
+		}()
+		s := NewIndexSeries("test", []int{1, 2, 3})
+		s.Filter(NewIndexSeries("mask", []bool{true}))
+	})
+}
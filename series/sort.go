@@ -0,0 +1,225 @@
+package series
+
+import (
+	"cmp"
+	"context"
+	"slices"
+)
+
+// NaPosition tells the sorting functions where to put NaN values
+type NaPosition int
+
+const (
+	// NaLast puts NaN values at the end regardless of the sort direction
+	NaLast NaPosition = iota
+	// NaFirst puts NaN values at the beginning regardless of the sort direction
+	NaFirst
+)
+
+// SortByIndex sorts the Series by its labels
+// Returns a new sorted Series, the original Series is not modified
+// asc: true for ascending order, false for descending order
+// the sort is stable, values with equal labels keep their order
+func SortByIndex[T comparable, R cmp.Ordered](s *Series[T, R], asc bool) *Series[T, R] {
+	positions := sortedPositions(s.Len(), func(i, j int) int {
+		return compareNA(s.index[i], s.index[j], asc, NaLast)
+	})
+	return s.takePositions(positions)
+}
+
+// SortByValue sorts the Series by its values
+// Returns a new sorted Series, the original Series is not modified
+// asc: true for ascending order, false for descending order
+// the sort is stable and NaN values are put last
+func SortByValue[T cmp.Ordered, R comparable](s *Series[T, R], asc bool) *Series[T, R] {
+	return SortByValueNA(s, asc, NaLast)
+}
+
+// SortByValueNA sorts the Series by its values like SortByValue
+// na decides whether NaN values are put first or last
+func SortByValueNA[T cmp.Ordered, R comparable](s *Series[T, R], asc bool, na NaPosition) *Series[T, R] {
+	return s.takePositions(ArgSortNA(s, asc, na))
+}
+
+// SortByValueAndIndex sorts the Series by its values and breaks ties by the labels
+// both are sorted in the same direction and NaN values are put last
+func SortByValueAndIndex[T cmp.Ordered, R cmp.Ordered](s *Series[T, R], asc bool) *Series[T, R] {
+	positions := sortedPositions(s.Len(), func(i, j int) int {
+		if c := compareNA(s.values[i], s.values[j], asc, NaLast); c != 0 {
+			return c
+		}
+		return compareNA(s.index[i], s.index[j], asc, NaLast)
+	})
+	return s.takePositions(positions)
+}
+
+// SortBy sorts the Series by its values using the given compare function
+// compare returns a negative number if a comes before b, a positive number if b comes before a and 0 otherwise
+// the sort is stable, values comparing equal keep their order
+func (s *Series[T, R]) SortBy(compare func(a, b T) int) *Series[T, R] {
+	positions := sortedPositions(s.Len(), func(i, j int) int {
+		return compare(s.values[i], s.values[j])
+	})
+	return s.takePositions(positions)
+}
+
+// ArgSort returns the positions which would sort the Series by its values
+// the sort is stable and NaN values are put last
+func ArgSort[T cmp.Ordered, R comparable](s *Series[T, R], asc bool) []int {
+	return ArgSortNA(s, asc, NaLast)
+}
+
+// ArgSortNA returns the positions which would sort the Series by its values
+// na decides whether NaN values are put first or last
+func ArgSortNA[T cmp.Ordered, R comparable](s *Series[T, R], asc bool, na NaPosition) []int {
+	return sortedPositions(s.Len(), func(i, j int) int {
+		return compareNA(s.values[i], s.values[j], asc, na)
+	})
+}
+
+// SortByIndexCtx is SortByIndex but stops and returns ctx.Err() when ctx is cancelled
+func SortByIndexCtx[T comparable, R cmp.Ordered](ctx context.Context, s *Series[T, R], asc bool) (*Series[T, R], error) {
+	positions, err := sortedPositionsCtx(ctx, s.Len(), func(i, j int) int {
+		return compareNA(s.index[i], s.index[j], asc, NaLast)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.takePositions(positions), nil
+}
+
+// SortByValueCtx is SortByValue but stops and returns ctx.Err() when ctx is cancelled
+func SortByValueCtx[T cmp.Ordered, R comparable](ctx context.Context, s *Series[T, R], asc bool) (*Series[T, R], error) {
+	positions, err := ArgSortCtx(ctx, s, asc)
+	if err != nil {
+		return nil, err
+	}
+	return s.takePositions(positions), nil
+}
+
+// SortByCtx is SortBy but stops and returns ctx.Err() when ctx is cancelled
+func (s *Series[T, R]) SortByCtx(ctx context.Context, compare func(a, b T) int) (*Series[T, R], error) {
+	positions, err := sortedPositionsCtx(ctx, s.Len(), func(i, j int) int {
+		return compare(s.values[i], s.values[j])
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.takePositions(positions), nil
+}
+
+// ArgSortCtx is ArgSort but stops and returns ctx.Err() when ctx is cancelled
+func ArgSortCtx[T cmp.Ordered, R comparable](ctx context.Context, s *Series[T, R], asc bool) ([]int, error) {
+	return sortedPositionsCtx(ctx, s.Len(), func(i, j int) int {
+		return compareNA(s.values[i], s.values[j], asc, NaLast)
+	})
+}
+
+// sortedPositions returns the positions 0..n-1 stable sorted by compare
+func sortedPositions(n int, compare func(i, j int) int) []int {
+	positions := rangePositions(0, n)
+	slices.SortStableFunc(positions, compare)
+	return positions
+}
+
+// checkEvery is the number of positions sortedPositionsCtx handles between two checks of the context
+const checkEvery = 1 << 14
+
+// sortedPositionsCtx returns the positions 0..n-1 stable sorted by compare like sortedPositions
+// it is a bottom-up merge sort so it can check ctx between the merges
+func sortedPositionsCtx(ctx context.Context, n int, compare func(i, j int) int) ([]int, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	positions := rangePositions(0, n)
+	buf := make([]int, n)
+
+	work := 0
+	checkCtx := func(done int) error {
+		work += done
+		if work < checkEvery {
+			return nil
+		}
+		work = 0
+		return ctx.Err()
+	}
+
+	// insertion sort short runs, merging them one by one would be slower
+	const run = 32
+	for start := 0; start < n; start += run {
+		insertionSort(positions[start:min(start+run, n)], compare)
+		if err := checkCtx(run); err != nil {
+			return nil, err
+		}
+	}
+
+	for width := run; width < n; width *= 2 {
+		for start := 0; start < n; start += 2 * width {
+			mid := min(start+width, n)
+			end := min(start+2*width, n)
+			merge(positions[start:mid], positions[mid:end], buf[start:end], compare)
+			if err := checkCtx(end - start); err != nil {
+				return nil, err
+			}
+		}
+		positions, buf = buf, positions
+	}
+
+	return positions, nil
+}
+
+// insertionSort stable sorts the positions by compare
+func insertionSort(positions []int, compare func(i, j int) int) {
+	for i := 1; i < len(positions); i++ {
+		for j := i; j > 0 && compare(positions[j-1], positions[j]) > 0; j-- {
+			positions[j-1], positions[j] = positions[j], positions[j-1]
+		}
+	}
+}
+
+// merge merges the sorted left and right into out, on ties left comes first to keep the sort stable
+func merge(left, right, out []int, compare func(i, j int) int) {
+	i, j, k := 0, 0, 0
+	for i < len(left) && j < len(right) {
+		if compare(left[i], right[j]) <= 0 {
+			out[k] = left[i]
+			i++
+		} else {
+			out[k] = right[j]
+			j++
+		}
+		k++
+	}
+	k += copy(out[k:], left[i:])
+	copy(out[k:], right[j:])
+}
+
+// compareNA compares a and b in the given direction and puts NaN values at na
+func compareNA[T cmp.Ordered](a, b T, asc bool, na NaPosition) int {
+	aNaN, bNaN := isNaN(a), isNaN(b)
+	switch {
+	case aNaN && bNaN:
+		return 0
+	case aNaN:
+		if na == NaFirst {
+			return -1
+		}
+		return 1
+	case bNaN:
+		if na == NaFirst {
+			return 1
+		}
+		return -1
+	}
+
+	if asc {
+		return cmp.Compare(a, b)
+	}
+	return cmp.Compare(b, a)
+}
+
+// isNaN checks if v is a floating point NaN, the only value which is not equal to itself
+func isNaN[T comparable](v T) bool {
+	return v != v
+}
@@ -0,0 +1,260 @@
+package series
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestSortByValue_Stable(t *testing.T) {
+	t.Run("keeps order of equal values", func(t *testing.T) {
+		values := []int{2, 1, 2, 1, 2, 1}
+		index := []string{"a", "b", "c", "d", "e", "f"}
+		s := NewSeries("test", values, index)
+
+		sorted := SortByValue(s, true)
+		expectedIndex := []string{"b", "d", "f", "a", "c", "e"}
+
+		idx := sorted.Index()
+		for i, exp := range expectedIndex {
+			if idx[i] != exp {
+				t.Errorf("expected label %s at position %d, got %s", exp, i, idx[i])
+			}
+		}
+	})
+
+	t.Run("keeps order of equal values descending", func(t *testing.T) {
+		values := []int{1, 2, 1, 2}
+		index := []string{"a", "b", "c", "d"}
+		s := NewSeries("test", values, index)
+
+		sorted := SortByValue(s, false)
+		expectedIndex := []string{"b", "d", "a", "c"}
+
+		idx := sorted.Index()
+		for i, exp := range expectedIndex {
+			if idx[i] != exp {
+				t.Errorf("expected label %s at position %d, got %s", exp, i, idx[i])
+			}
+		}
+	})
+}
+
+func TestSortByIndex_Stable(t *testing.T) {
+	values := []int{1, 2, 3, 4}
+	index := []string{"b", "a", "b", "a"}
+	s := NewSeries("test", values, index)
+
+	sorted := SortByIndex(s, true)
+	expectedValues := []int{2, 4, 1, 3}
+
+	vals := sorted.Values()
+	for i, exp := range expectedValues {
+		if vals[i] != exp {
+			t.Errorf("expected value %d at position %d, got %d", exp, i, vals[i])
+		}
+	}
+}
+
+func TestSortByValueNA(t *testing.T) {
+	nan := math.NaN()
+
+	t.Run("puts NaN last by default", func(t *testing.T) {
+		s := NewIndexSeries("test", []float64{3, nan, 1, 2})
+
+		for _, asc := range []bool{true, false} {
+			sorted := SortByValue(s, asc)
+			if !math.IsNaN(sorted.At(3)) {
+				t.Errorf("expected NaN at the end for asc=%v, got %v", asc, sorted.Values())
+			}
+		}
+	})
+
+	t.Run("puts NaN first", func(t *testing.T) {
+		s := NewIndexSeries("test", []float64{3, nan, 1, nan})
+
+		sorted := SortByValueNA(s, true, NaFirst)
+		vals := sorted.Values()
+		if !math.IsNaN(vals[0]) || !math.IsNaN(vals[1]) || vals[2] != 1 || vals[3] != 3 {
+			t.Errorf("unexpected order %v", vals)
+		}
+		idx := sorted.Index()
+		if idx[0] != 1 || idx[1] != 3 {
+			t.Errorf("expected NaN labels in original order, got %v", idx)
+		}
+	})
+}
+
+func TestSortByValueAndIndex(t *testing.T) {
+	values := []int{2, 1, 2, 1}
+	index := []string{"d", "c", "a", "b"}
+	s := NewSeries("test", values, index)
+
+	t.Run("breaks ties by label ascending", func(t *testing.T) {
+		sorted := SortByValueAndIndex(s, true)
+		expectedIndex := []string{"b", "c", "a", "d"}
+
+		idx := sorted.Index()
+		for i, exp := range expectedIndex {
+			if idx[i] != exp {
+				t.Errorf("expected label %s at position %d, got %s", exp, i, idx[i])
+			}
+		}
+	})
+
+	t.Run("breaks ties by label descending", func(t *testing.T) {
+		sorted := SortByValueAndIndex(s, false)
+		expectedIndex := []string{"d", "a", "c", "b"}
+
+		idx := sorted.Index()
+		for i, exp := range expectedIndex {
+			if idx[i] != exp {
+				t.Errorf("expected label %s at position %d, got %s", exp, i, idx[i])
+			}
+		}
+	})
+}
+
+func TestSortBy(t *testing.T) {
+	type point struct{ x, y int }
+
+	t.Run("sorts comparable values with custom compare", func(t *testing.T) {
+		values := []point{{3, 1}, {1, 2}, {2, 0}}
+		s := NewIndexSeries("points", values)
+
+		sorted := s.SortBy(func(a, b point) int {
+			return a.y - b.y
+		})
+
+		expectedIndex := []int{2, 0, 1}
+		idx := sorted.Index()
+		for i, exp := range expectedIndex {
+			if idx[i] != exp {
+				t.Errorf("expected label %d at position %d, got %d", exp, i, idx[i])
+			}
+		}
+	})
+
+	t.Run("is stable", func(t *testing.T) {
+		values := []string{"b", "A", "a", "B"}
+		s := NewIndexSeries("letters", values)
+
+		sorted := s.SortBy(func(a, b string) int {
+			return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+		})
+
+		expected := []string{"A", "a", "b", "B"}
+		for i, exp := range expected {
+			if sorted.At(i) != exp {
+				t.Errorf("expected %s at position %d, got %s", exp, i, sorted.At(i))
+			}
+		}
+	})
+
+	t.Run("original series is not modified", func(t *testing.T) {
+		s := NewIndexSeries("test", []int{3, 1, 2})
+		s.SortBy(func(a, b int) int { return a - b })
+
+		if s.At(0) != 3 {
+			t.Error("original series should not be modified")
+		}
+	})
+}
+
+func TestArgSort(t *testing.T) {
+	t.Run("returns sorting positions", func(t *testing.T) {
+		s := NewSeries("test", []int{30, 10, 20}, []string{"a", "b", "c"})
+
+		positions := ArgSort(s, true)
+		expected := []int{1, 2, 0}
+		for i, exp := range expected {
+			if positions[i] != exp {
+				t.Errorf("expected position %d at %d, got %d", exp, i, positions[i])
+			}
+		}
+	})
+
+	t.Run("returns descending positions with NaN first", func(t *testing.T) {
+		s := NewIndexSeries("test", []float64{1, math.NaN(), 2})
+
+		positions := ArgSortNA(s, false, NaFirst)
+		expected := []int{1, 2, 0}
+		for i, exp := range expected {
+			if positions[i] != exp {
+				t.Errorf("expected position %d at %d, got %d", exp, i, positions[i])
+			}
+		}
+	})
+}
+
+func TestSortCtx(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+	values := make([]int, 100_000)
+	index := make([]int, len(values))
+	for i := range values {
+		values[i] = rng.Intn(1000)
+		index[i] = rng.Intn(1000)
+	}
+	s := NewSeries("random", values, index)
+
+	t.Run("matches the sort without context", func(t *testing.T) {
+		for _, asc := range []bool{true, false} {
+			sorted, err := SortByValueCtx(context.Background(), s, asc)
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			expected := SortByValue(s, asc)
+			if !slices.Equal(sorted.Values(), expected.Values()) || !slices.Equal(sorted.Index(), expected.Index()) {
+				t.Errorf("sort by value with asc=%v differs from SortByValue", asc)
+			}
+
+			sorted, err = SortByIndexCtx(context.Background(), s, asc)
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			expected = SortByIndex(s, asc)
+			if !slices.Equal(sorted.Values(), expected.Values()) || !slices.Equal(sorted.Index(), expected.Index()) {
+				t.Errorf("sort by index with asc=%v differs from SortByIndex", asc)
+			}
+		}
+
+		positions, err := ArgSortCtx(context.Background(), s, true)
+		if err != nil || !slices.Equal(positions, ArgSort(s, true)) {
+			t.Error("arg sort differs from ArgSort")
+		}
+	})
+
+	t.Run("returns error of cancelled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		sorted, err := SortByValueCtx(ctx, s, true)
+		if !errors.Is(err, context.Canceled) || sorted != nil {
+			t.Errorf("expected context.Canceled, got %v", err)
+		}
+	})
+
+	t.Run("stops when cancelled while sorting", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		comparisons := 0
+		_, err := s.SortByCtx(ctx, func(a, b int) int {
+			comparisons++
+			if comparisons == 1000 {
+				cancel()
+			}
+			return a - b
+		})
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected context.Canceled, got %v", err)
+		}
+		if comparisons > 500_000 {
+			t.Errorf("expected sort to stop early, made %d comparisons", comparisons)
+		}
+	})
+}
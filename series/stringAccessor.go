@@ -0,0 +1,174 @@
+package series
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// StringAccessor offers vectorized text operations on a string Series
+// every operation returns a new Series with the name and labels of the original
+type StringAccessor[R comparable] struct {
+	s *Series[string, R]
+}
+
+// Str returns the StringAccessor of the given Series
+func Str[R comparable](s *Series[string, R]) *StringAccessor[R] {
+	return &StringAccessor[R]{s: s}
+}
+
+// Lower returns the values in lower case
+func (sa *StringAccessor[R]) Lower() *Series[string, R] {
+	return mapValues(sa.s, strings.ToLower)
+}
+
+// Upper returns the values in upper case
+func (sa *StringAccessor[R]) Upper() *Series[string, R] {
+	return mapValues(sa.s, strings.ToUpper)
+}
+
+// Strip returns the values without leading and trailing white space
+func (sa *StringAccessor[R]) Strip() *Series[string, R] {
+	return mapValues(sa.s, strings.TrimSpace)
+}
+
+// Len returns the number of characters (runes) of every value
+func (sa *StringAccessor[R]) Len() *Series[int, R] {
+	return mapValues(sa.s, utf8.RuneCountInString)
+}
+
+// Contains returns a bool mask which is true where the value contains substr
+func (sa *StringAccessor[R]) Contains(substr string) *Series[bool, R] {
+	return mapValues(sa.s, func(v string) bool { return strings.Contains(v, substr) })
+}
+
+// StartsWith returns a bool mask which is true where the value starts with prefix
+func (sa *StringAccessor[R]) StartsWith(prefix string) *Series[bool, R] {
+	return mapValues(sa.s, func(v string) bool { return strings.HasPrefix(v, prefix) })
+}
+
+// EndsWith returns a bool mask which is true where the value ends with suffix
+func (sa *StringAccessor[R]) EndsWith(suffix string) *Series[bool, R] {
+	return mapValues(sa.s, func(v string) bool { return strings.HasSuffix(v, suffix) })
+}
+
+// Replace returns the values with all occurrences of old replaced by new
+func (sa *StringAccessor[R]) Replace(old, new string) *Series[string, R] {
+	return mapValues(sa.s, func(v string) string { return strings.ReplaceAll(v, old, new) })
+}
+
+// Match returns a bool mask which is true where the regular expression matches the value
+// panics if pattern is not a valid regular expression
+func (sa *StringAccessor[R]) Match(pattern string) *Series[bool, R] {
+	re := regexp.MustCompile(pattern)
+	return mapValues(sa.s, re.MatchString)
+}
+
+// Extract returns the first capture group of the first match of the regular expression
+// or the whole match if the pattern has no group, values without a match get ""
+func (sa *StringAccessor[R]) Extract(pattern string) *Series[string, R] {
+	re := regexp.MustCompile(pattern)
+	group := min(re.NumSubexp(), 1)
+	return mapValues(sa.s, func(v string) string {
+		match := re.FindStringSubmatch(v)
+		if match == nil {
+			return ""
+		}
+		return match[group]
+	})
+}
+
+// Findall returns all matches of the regular expression for every value in order of the Series
+// slices can't be the values of a Series so the matches are returned as a slice per value
+func (sa *StringAccessor[R]) Findall(pattern string) [][]string {
+	re := regexp.MustCompile(pattern)
+	matches := make([][]string, sa.s.Len())
+	for i, v := range sa.s.values {
+		matches[i] = re.FindAllString(v, -1)
+	}
+	return matches
+}
+
+// Split splits every value around sep and expands the parts into a DataFrame
+// the columns are named 0, 1, ... and values with fewer parts get "" in the remaining columns
+func (sa *StringAccessor[R]) Split(sep string) *DataFrame[R] {
+	parts := make([][]string, sa.s.Len())
+	width := 0
+	for i, v := range sa.s.values {
+		parts[i] = strings.Split(v, sep)
+		width = max(width, len(parts[i]))
+	}
+
+	columns := make([]Column[R], width)
+	for c := range columns {
+		values := make([]string, len(parts))
+		for i, p := range parts {
+			if c < len(p) {
+				values[i] = p[c]
+			}
+		}
+		columns[c] = NewSeries(strconv.Itoa(c), values, sa.s.index)
+	}
+	return NewDataFrame(columns...)
+}
+
+// PadSide tells Pad where to add the fill characters
+type PadSide int
+
+const (
+	// PadLeft adds the fill characters in front of the value
+	PadLeft PadSide = iota
+	// PadRight adds the fill characters after the value
+	PadRight
+	// PadBoth adds the fill characters on both sides, the extra one goes to the right
+	PadBoth
+)
+
+// Pad returns the values filled up with fill until they are width characters long
+// values which are already long enough are not changed
+func (sa *StringAccessor[R]) Pad(width int, side PadSide, fill rune) *Series[string, R] {
+	return mapValues(sa.s, func(v string) string {
+		missing := width - utf8.RuneCountInString(v)
+		if missing <= 0 {
+			return v
+		}
+
+		f := string(fill)
+		switch side {
+		case PadLeft:
+			return strings.Repeat(f, missing) + v
+		case PadRight:
+			return v + strings.Repeat(f, missing)
+		default:
+			left := missing / 2
+			return strings.Repeat(f, left) + v + strings.Repeat(f, missing-left)
+		}
+	})
+}
+
+// Cat concatenates the values element-wise with the values of the other Series using sep
+// all Series must have the same length
+func (sa *StringAccessor[R]) Cat(sep string, others ...*Series[string, R]) *Series[string, R] {
+	for _, o := range others {
+		if o.Len() != sa.s.Len() {
+			panic("series must be of the same length to concatenate")
+		}
+	}
+
+	values := make([]string, sa.s.Len())
+	parts := make([]string, len(others)+1)
+	for i, v := range sa.s.values {
+		parts[0] = v
+		for j, o := range others {
+			parts[j+1] = o.values[i]
+		}
+		values[i] = strings.Join(parts, sep)
+	}
+	return NewSeries(sa.s.name, values, sa.s.index)
+}
+
+// Join concatenates all values of the Series into one string using sep
+func (sa *StringAccessor[R]) Join(sep string) string {
+	return strings.Join(sa.s.values, sep)
+}
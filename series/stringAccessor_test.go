@@ -0,0 +1,138 @@
+package series
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestStr_Case(t *testing.T) {
+	s := NewSeries("names", []string{" Alice ", "BOB", "carol"}, []string{"a", "b", "c"})
+
+	if !slices.Equal(Str(s).Lower().Values(), []string{" alice ", "bob", "carol"}) {
+		t.Errorf("unexpected lower values %v", Str(s).Lower().Values())
+	}
+	if !slices.Equal(Str(s).Upper().Values(), []string{" ALICE ", "BOB", "CAROL"}) {
+		t.Errorf("unexpected upper values %v", Str(s).Upper().Values())
+	}
+
+	stripped := Str(s).Strip()
+	if stripped.Get("a") != "Alice" {
+		t.Errorf("expected 'Alice', got %q", stripped.Get("a"))
+	}
+	if stripped.Name() != "names" {
+		t.Errorf("expected name 'names', got %s", stripped.Name())
+	}
+}
+
+func TestStr_Masks(t *testing.T) {
+	s := NewIndexSeries("files", []string{"report.csv", "data.parquet", "notes.txt"})
+
+	if !slices.Equal(Str(s).Contains("a").Values(), []bool{false, true, false}) {
+		t.Errorf("unexpected contains mask %v", Str(s).Contains("a").Values())
+	}
+	if !slices.Equal(Str(s).StartsWith("re").Values(), []bool{true, false, false}) {
+		t.Errorf("unexpected starts with mask %v", Str(s).StartsWith("re").Values())
+	}
+	if !slices.Equal(Str(s).EndsWith(".txt").Values(), []bool{false, false, true}) {
+		t.Errorf("unexpected ends with mask %v", Str(s).EndsWith(".txt").Values())
+	}
+
+	filtered := s.Filter(Str(s).Match(`\.(csv|txt)$`))
+	if !slices.Equal(filtered.Values(), []string{"report.csv", "notes.txt"}) {
+		t.Errorf("unexpected filtered values %v", filtered.Values())
+	}
+}
+
+func TestStr_Replace(t *testing.T) {
+	s := NewIndexSeries("x", []string{"a-b-c", "d"})
+
+	result := Str(s).Replace("-", "_")
+	if !slices.Equal(result.Values(), []string{"a_b_c", "d"}) {
+		t.Errorf("unexpected values %v", result.Values())
+	}
+}
+
+func TestStr_Regex(t *testing.T) {
+	s := NewIndexSeries("x", []string{"id=12 id=7", "none", "id=3"})
+
+	t.Run("extracts the first group", func(t *testing.T) {
+		result := Str(s).Extract(`id=(\d+)`)
+		if !slices.Equal(result.Values(), []string{"12", "", "3"}) {
+			t.Errorf("unexpected values %v", result.Values())
+		}
+	})
+
+	t.Run("extracts the whole match without group", func(t *testing.T) {
+		result := Str(s).Extract(`\d+`)
+		if !slices.Equal(result.Values(), []string{"12", "", "3"}) {
+			t.Errorf("unexpected values %v", result.Values())
+		}
+	})
+
+	t.Run("finds all matches", func(t *testing.T) {
+		result := Str(s).Findall(`\d+`)
+		if !slices.Equal(result[0], []string{"12", "7"}) || result[1] != nil {
+			t.Errorf("unexpected matches %v", result)
+		}
+	})
+
+	t.Run("panics with invalid pattern", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("expected panic for invalid pattern")
+			}
+		}()
+		Str(s).Match(`(`)
+	})
+}
+
+func TestStr_Split(t *testing.T) {
+	s := NewSeries("path", []string{"a/b/c", "d/e", "f"}, []string{"x", "y", "z"})
+
+	df := Str(s).Split("/")
+	if !slices.Equal(df.Columns(), []string{"0", "1", "2"}) {
+		t.Errorf("unexpected columns %v", df.Columns())
+	}
+
+	second := GetColumn[string](df, "1")
+	if second.Get("y") != "e" || second.Get("z") != "" {
+		t.Errorf("unexpected values %v", second.Values())
+	}
+}
+
+func TestStr_LenAndPad(t *testing.T) {
+	s := NewIndexSeries("x", []string{"7", "ab", "größe"})
+
+	if !slices.Equal(Str(s).Len().Values(), []int{1, 2, 5}) {
+		t.Errorf("unexpected lengths %v", Str(s).Len().Values())
+	}
+
+	left := Str(s).Pad(3, PadLeft, '0')
+	if !slices.Equal(left.Values(), []string{"007", "0ab", "größe"}) {
+		t.Errorf("unexpected values %v", left.Values())
+	}
+
+	both := Str(s).Pad(4, PadBoth, '*')
+	if !slices.Equal(both.Values(), []string{"*7**", "*ab*", "größe"}) {
+		t.Errorf("unexpected values %v", both.Values())
+	}
+
+	right := Str(s).Pad(2, PadRight, '.')
+	if right.At(0) != "7." {
+		t.Errorf("expected '7.', got %s", right.At(0))
+	}
+}
+
+func TestStr_Cat(t *testing.T) {
+	first := NewIndexSeries("first", []string{"Ada", "Alan"})
+	last := NewIndexSeries("last", []string{"Lovelace", "Turing"})
+
+	full := Str(first).Cat(" ", last)
+	if !slices.Equal(full.Values(), []string{"Ada Lovelace", "Alan Turing"}) {
+		t.Errorf("unexpected values %v", full.Values())
+	}
+
+	if Str(first).Join(",") != "Ada,Alan" {
+		t.Errorf("unexpected join %s", Str(first).Join(","))
+	}
+}
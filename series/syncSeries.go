@@ -0,0 +1,121 @@
+package series
+
+import (
+	"slices"
+	"sync"
+)
+
+// SyncSeries is a Series which can be shared between goroutines
+// reads take a read lock, Append, Prepend and SetName take the write lock
+type SyncSeries[T comparable, R comparable] struct {
+	mu sync.RWMutex
+	s  *Series[T, R]
+}
+
+// NewSyncSeries creates a new SyncSeries holding a copy of the given Series
+// the copy makes sure nobody changes the data without taking the lock
+func NewSyncSeries[T comparable, R comparable](s *Series[T, R]) *SyncSeries[T, R] {
+	return &SyncSeries[T, R]{s: s.Copy()}
+}
+
+// Snapshot returns the current state as a regular Series without copying the data
+// it stays valid and unchanged no matter what is appended or prepended later,
+// because appends only write behind the end of the snapshot and prepends allocate new slices
+func (ss *SyncSeries[T, R]) Snapshot() *Series[T, R] {
+	ss.mu.RLock()
+	defer ss.mu.RUnlock()
+
+	return &Series[T, R]{
+		name:   ss.s.name,
+		values: slices.Clip(ss.s.values),
+		index:  slices.Clip(ss.s.index),
+	}
+}
+
+// Len return the length of the value slice
+func (ss *SyncSeries[T, R]) Len() int {
+	ss.mu.RLock()
+	defer ss.mu.RUnlock()
+	return ss.s.Len()
+}
+
+// Name return the name of the Series
+func (ss *SyncSeries[T, R]) Name() string {
+	ss.mu.RLock()
+	defer ss.mu.RUnlock()
+	return ss.s.Name()
+}
+
+// SetName sets the Name of the Series
+func (ss *SyncSeries[T, R]) SetName(name string) {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	ss.s.SetName(name)
+}
+
+// Values returns a copy of the values
+func (ss *SyncSeries[T, R]) Values() []T {
+	ss.mu.RLock()
+	defer ss.mu.RUnlock()
+	return ss.s.Values()
+}
+
+// Index returns a copy of the index
+func (ss *SyncSeries[T, R]) Index() []R {
+	ss.mu.RLock()
+	defer ss.mu.RUnlock()
+	return ss.s.Index()
+}
+
+// Get returns the value for the given label
+func (ss *SyncSeries[T, R]) Get(label R) T {
+	ss.mu.RLock()
+	defer ss.mu.RUnlock()
+	return ss.s.Get(label)
+}
+
+// At returns the value at the given index of the slice
+func (ss *SyncSeries[T, R]) At(i int) T {
+	ss.mu.RLock()
+	defer ss.mu.RUnlock()
+	return ss.s.At(i)
+}
+
+// AtIndex returns the label and value at the given index of the slice
+func (ss *SyncSeries[T, R]) AtIndex(i int) (R, T) {
+	ss.mu.RLock()
+	defer ss.mu.RUnlock()
+	return ss.s.AtIndex(i)
+}
+
+// String returns a string representation of the Series
+func (ss *SyncSeries[T, R]) String() string {
+	ss.mu.RLock()
+	defer ss.mu.RUnlock()
+	return ss.s.String()
+}
+
+// Append appends another Series to the end of this Series
+func (ss *SyncSeries[T, R]) Append(o *Series[T, R]) {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	ss.s.values = append(ss.s.values, o.values...)
+	ss.s.index = append(ss.s.index, o.index...)
+}
+
+// Push appends a single value with its label to the end of this Series
+func (ss *SyncSeries[T, R]) Push(label R, value T) {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	ss.s.values = append(ss.s.values, value)
+	ss.s.index = append(ss.s.index, label)
+}
+
+// Prepend prepends another Series to the beginning of this Series
+// it always allocates new slices so snapshots taken before stay unchanged
+func (ss *SyncSeries[T, R]) Prepend(o *Series[T, R]) {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	ss.s.values = slices.Concat(o.values, ss.s.values)
+	ss.s.index = slices.Concat(o.index, ss.s.index)
+}
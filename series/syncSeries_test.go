@@ -0,0 +1,102 @@
+package series
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+// the tests in this file are meant to be run with the race detector: go test -race
+
+func TestNewSyncSeries(t *testing.T) {
+	s := NewSeries("test", []int{1, 2}, []string{"a", "b"})
+	ss := NewSyncSeries(s)
+
+	s.Append(NewSeries("more", []int{3}, []string{"c"}))
+	if ss.Len() != 2 {
+		t.Errorf("expected length 2, got %d", ss.Len())
+	}
+	if ss.Get("b") != 2 || ss.At(0) != 1 {
+		t.Error("unexpected values")
+	}
+}
+
+func TestSyncSeries_Snapshot(t *testing.T) {
+	ss := NewSyncSeries(NewIndexSeries("test", []int{1, 2, 3}))
+
+	snapshot := ss.Snapshot()
+	ss.Push(3, 4)
+	ss.Append(NewSeries("more", []int{5}, []int{4}))
+	ss.Prepend(NewSeries("less", []int{0}, []int{-1}))
+	ss.SetName("renamed")
+
+	if snapshot.Len() != 3 || snapshot.Name() != "test" {
+		t.Errorf("snapshot should not change, got %v", snapshot)
+	}
+	for i, v := range []int{1, 2, 3} {
+		if snapshot.At(i) != v {
+			t.Errorf("expected %d at position %d, got %d", v, i, snapshot.At(i))
+		}
+	}
+
+	if ss.Len() != 6 || ss.At(0) != 0 || ss.At(5) != 5 {
+		t.Errorf("unexpected values %v", ss.Values())
+	}
+
+	// appending to a snapshot must not write into the SyncSeries
+	snapshot.Append(NewSeries("other", []int{99}, []int{99}))
+	if ss.Len() != 6 {
+		t.Errorf("expected length 6, got %d", ss.Len())
+	}
+}
+
+func TestSyncSeries_Concurrent(t *testing.T) {
+	ss := NewSyncSeries(NewIndexSeries("stream", []int{0}))
+
+	var wg sync.WaitGroup
+	const writers, writes = 4, 250
+
+	for w := range writers {
+		wg.Go(func() {
+			for i := range writes {
+				ss.Push(w*writes+i+1, i)
+				if i%50 == 0 {
+					ss.SetName(fmt.Sprintf("writer %d", w))
+				}
+			}
+		})
+	}
+
+	for range 4 {
+		wg.Go(func() {
+			for range writes {
+				snapshot := ss.Snapshot()
+				// every snapshot must be internally consistent
+				if len(snapshot.values) != len(snapshot.index) {
+					t.Error("snapshot values and index differ in length")
+					return
+				}
+				sum := 0
+				for i := range snapshot.Len() {
+					sum += snapshot.At(i)
+				}
+				_ = ss.Name()
+				_ = ss.Len()
+				_ = ss.Values()
+				_ = ss.String()
+			}
+		})
+	}
+
+	wg.Go(func() {
+		for range 10 {
+			ss.Prepend(NewSeries("head", []int{-1}, []int{-1}))
+		}
+	})
+
+	wg.Wait()
+
+	if ss.Len() != 1+writers*writes+10 {
+		t.Errorf("expected length %d, got %d", 1+writers*writes+10, ss.Len())
+	}
+}
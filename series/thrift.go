@@ -0,0 +1,400 @@
+package series
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// This file holds the Thrift compact protocol used by the Parquet file metadata and page headers.
+// Structs are written from a thriftStruct whose field values decide the Thrift type:
+// bool, int8, int16, int32, int64, float64, string, []byte, thriftStruct and slices of
+// int32, int64, string and thriftStruct for lists.
+// Structs are read back into a thriftStruct holding int64 for every integer, float64, bool,
+// []byte for binaries, []any for lists and maps and thriftStruct for nested structs.
+
+// types of the compact protocol
+const (
+	thriftStop      = 0
+	thriftTrue      = 1
+	thriftFalse     = 2
+	thriftByte      = 3
+	thriftI16       = 4
+	thriftI32       = 5
+	thriftI64       = 6
+	thriftDouble    = 7
+	thriftBinary    = 8
+	thriftList      = 9
+	thriftSet       = 10
+	thriftMap       = 11
+	thriftStructTyp = 12
+)
+
+// thriftMaxDepth limits the nesting of structs and lists when reading
+const thriftMaxDepth = 64
+
+// thriftField is a field of a struct with its id
+type thriftField struct {
+	id    int16
+	value any
+}
+
+// thriftStruct is a Thrift struct, fields are written in the given order which must be increasing by id
+type thriftStruct []thriftField
+
+// get returns the value of the field with the given id
+func (s thriftStruct) get(id int16) (any, bool) {
+	for _, f := range s {
+		if f.id == id {
+			return f.value, true
+		}
+	}
+	return nil, false
+}
+
+// int returns the integer field with the given id or def if it is not set
+func (s thriftStruct) int(id int16, def int64) int64 {
+	if v, ok := s.get(id); ok {
+		if i, ok := v.(int64); ok {
+			return i
+		}
+	}
+	return def
+}
+
+// bool returns the bool field with the given id or def if it is not set
+func (s thriftStruct) bool(id int16, def bool) bool {
+	if v, ok := s.get(id); ok {
+		if b, ok := v.(bool); ok {
+			return b
+		}
+	}
+	return def
+}
+
+// string returns the binary field with the given id as string or "" if it is not set
+func (s thriftStruct) string(id int16) string {
+	v, _ := s.get(id)
+	b, _ := v.([]byte)
+	return string(b)
+}
+
+// list returns the list field with the given id or nil if it is not set
+func (s thriftStruct) list(id int16) []any {
+	v, _ := s.get(id)
+	l, _ := v.([]any)
+	return l
+}
+
+// strct returns the struct field with the given id or nil if it is not set
+func (s thriftStruct) strct(id int16) thriftStruct {
+	v, _ := s.get(id)
+	st, _ := v.(thriftStruct)
+	return st
+}
+
+// union returns the id of the field which is set in a union or 0 if none is set
+func (s thriftStruct) union() int16 {
+	if len(s) == 0 {
+		return 0
+	}
+	return s[0].id
+}
+
+// has returns if the field with the given id is set
+func (s thriftStruct) has(id int16) bool {
+	_, ok := s.get(id)
+	return ok
+}
+
+// appendThrift appends the struct in the compact protocol
+func appendThrift(buf []byte, s thriftStruct) []byte {
+	last := int16(0)
+	for _, f := range s {
+		typ := thriftTypeOf(f.value)
+		if b, ok := f.value.(bool); ok && !b {
+			typ = thriftFalse
+		}
+		if delta := f.id - last; delta > 0 && delta <= 15 {
+			buf = append(buf, byte(delta)<<4|typ)
+		} else {
+			buf = append(buf, typ)
+			buf = binary.AppendVarint(buf, int64(f.id))
+		}
+		last = f.id
+
+		if _, ok := f.value.(bool); !ok {
+			buf = appendThriftValue(buf, f.value)
+		}
+	}
+	return append(buf, thriftStop)
+}
+
+// thriftTypeOf returns the compact protocol type of a value of a thriftStruct
+func thriftTypeOf(v any) byte {
+	switch v.(type) {
+	case bool:
+		return thriftTrue
+	case int8:
+		return thriftByte
+	case int16:
+		return thriftI16
+	case int32:
+		return thriftI32
+	case int64:
+		return thriftI64
+	case float64:
+		return thriftDouble
+	case string, []byte:
+		return thriftBinary
+	case thriftStruct:
+		return thriftStructTyp
+	case []int32, []int64, []string, []thriftStruct:
+		return thriftList
+	}
+	panic(fmt.Sprintf("cannot write %T to thrift", v))
+}
+
+// appendThriftValue appends a value without its field header
+func appendThriftValue(buf []byte, v any) []byte {
+	switch v := v.(type) {
+	case bool:
+		if v {
+			return append(buf, thriftTrue)
+		}
+		return append(buf, thriftFalse)
+	case int8:
+		return append(buf, byte(v))
+	case int16:
+		return binary.AppendVarint(buf, int64(v))
+	case int32:
+		return binary.AppendVarint(buf, int64(v))
+	case int64:
+		return binary.AppendVarint(buf, v)
+	case float64:
+		return binary.LittleEndian.AppendUint64(buf, math.Float64bits(v))
+	case string:
+		buf = binary.AppendUvarint(buf, uint64(len(v)))
+		return append(buf, v...)
+	case []byte:
+		buf = binary.AppendUvarint(buf, uint64(len(v)))
+		return append(buf, v...)
+	case thriftStruct:
+		return appendThrift(buf, v)
+	case []int32:
+		return appendThriftList(buf, thriftI32, v)
+	case []int64:
+		return appendThriftList(buf, thriftI64, v)
+	case []string:
+		return appendThriftList(buf, thriftBinary, v)
+	case []thriftStruct:
+		return appendThriftList(buf, thriftStructTyp, v)
+	}
+	panic(fmt.Sprintf("cannot write %T to thrift", v))
+}
+
+// appendThriftList appends the list header and its elements
+func appendThriftList[E any](buf []byte, typ byte, items []E) []byte {
+	if len(items) < 15 {
+		buf = append(buf, byte(len(items))<<4|typ)
+	} else {
+		buf = append(buf, 0xF0|typ)
+		buf = binary.AppendUvarint(buf, uint64(len(items)))
+	}
+	for _, item := range items {
+		buf = appendThriftValue(buf, item)
+	}
+	return buf
+}
+
+// errThrift is returned for Thrift data which cannot be decoded
+var errThrift = errors.New("corrupt thrift data")
+
+// thriftReader reads the compact protocol from a buffer
+type thriftReader struct {
+	buf   []byte
+	pos   int
+	depth int
+}
+
+// readThrift reads a struct from the start of buf and returns it with the number of bytes read
+func readThrift(buf []byte) (thriftStruct, int, error) {
+	r := &thriftReader{buf: buf}
+	s, err := r.readStruct()
+	return s, r.pos, err
+}
+
+func (r *thriftReader) byte() (byte, error) {
+	if r.pos >= len(r.buf) {
+		return 0, errThrift
+	}
+	b := r.buf[r.pos]
+	r.pos++
+	return b, nil
+}
+
+func (r *thriftReader) uvarint() (uint64, error) {
+	v, n := binary.Uvarint(r.buf[r.pos:])
+	if n <= 0 {
+		return 0, errThrift
+	}
+	r.pos += n
+	return v, nil
+}
+
+func (r *thriftReader) varint() (int64, error) {
+	v, n := binary.Varint(r.buf[r.pos:])
+	if n <= 0 {
+		return 0, errThrift
+	}
+	r.pos += n
+	return v, nil
+}
+
+func (r *thriftReader) readStruct() (thriftStruct, error) {
+	r.depth++
+	defer func() { r.depth-- }()
+	if r.depth > thriftMaxDepth {
+		return nil, errThrift
+	}
+
+	var s thriftStruct
+	last := int16(0)
+	for {
+		header, err := r.byte()
+		if err != nil {
+			return nil, err
+		}
+		if header == thriftStop {
+			return s, nil
+		}
+
+		typ := header & 0x0F
+		id := last + int16(header>>4)
+		if header>>4 == 0 {
+			v, err := r.varint()
+			if err != nil {
+				return nil, err
+			}
+			id = int16(v)
+		}
+		last = id
+
+		var value any
+		switch typ {
+		case thriftTrue:
+			value = true
+		case thriftFalse:
+			value = false
+		default:
+			if value, err = r.readValue(typ); err != nil {
+				return nil, err
+			}
+		}
+		s = append(s, thriftField{id: id, value: value})
+	}
+}
+
+func (r *thriftReader) readValue(typ byte) (any, error) {
+	switch typ {
+	case thriftTrue, thriftFalse:
+		// bools inside of lists and maps take a byte
+		b, err := r.byte()
+		return b == thriftTrue, err
+	case thriftByte:
+		b, err := r.byte()
+		return int64(int8(b)), err
+	case thriftI16, thriftI32, thriftI64:
+		return r.varint()
+	case thriftDouble:
+		if r.pos+8 > len(r.buf) {
+			return nil, errThrift
+		}
+		v := math.Float64frombits(binary.LittleEndian.Uint64(r.buf[r.pos:]))
+		r.pos += 8
+		return v, nil
+	case thriftBinary:
+		n, err := r.uvarint()
+		if err != nil {
+			return nil, err
+		}
+		if n > uint64(len(r.buf)-r.pos) {
+			return nil, errThrift
+		}
+		b := r.buf[r.pos : r.pos+int(n)]
+		r.pos += int(n)
+		return b, nil
+	case thriftList, thriftSet:
+		return r.readList()
+	case thriftMap:
+		return r.readMap()
+	case thriftStructTyp:
+		return r.readStruct()
+	}
+	return nil, errThrift
+}
+
+func (r *thriftReader) readList() (any, error) {
+	r.depth++
+	defer func() { r.depth-- }()
+	if r.depth > thriftMaxDepth {
+		return nil, errThrift
+	}
+
+	header, err := r.byte()
+	if err != nil {
+		return nil, err
+	}
+	n := uint64(header >> 4)
+	if n == 15 {
+		if n, err = r.uvarint(); err != nil {
+			return nil, err
+		}
+	}
+	// every element takes at least one byte
+	if n > uint64(len(r.buf)-r.pos) {
+		return nil, errThrift
+	}
+
+	items := make([]any, n)
+	for i := range items {
+		if items[i], err = r.readValue(header & 0x0F); err != nil {
+			return nil, err
+		}
+	}
+	return items, nil
+}
+
+// readMap reads a map as a list of alternating keys and values
+func (r *thriftReader) readMap() (any, error) {
+	r.depth++
+	defer func() { r.depth-- }()
+	if r.depth > thriftMaxDepth {
+		return nil, errThrift
+	}
+
+	n, err := r.uvarint()
+	if err != nil || n == 0 {
+		return []any{}, err
+	}
+	if n > uint64(len(r.buf)-r.pos) {
+		return nil, errThrift
+	}
+	types, err := r.byte()
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]any, 2*n)
+	for i := range items {
+		typ := types >> 4
+		if i%2 == 1 {
+			typ = types & 0x0F
+		}
+		if items[i], err = r.readValue(typ); err != nil {
+			return nil, err
+		}
+	}
+	return items, nil
+}
@@ -0,0 +1,90 @@
+package series
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestThrift(t *testing.T) {
+	t.Run("writes the compact protocol", func(t *testing.T) {
+		got := appendThrift(nil, thriftStruct{
+			{1, int32(-3)},
+			{2, true},
+			{3, "ab"},
+			{20, false},
+			{21, []int32{1, 2}},
+		})
+		want := []byte{
+			0x15, 0x05, // field 1 i32, zigzag -3
+			0x11,              // field 2 true
+			0x18, 2, 'a', 'b', // field 3 binary
+			0x02, 0x28, // field 20 false, long form with zigzag id
+			0x19, 0x25, 0x02, 0x04, // field 21 list of 2 i32
+			0x00,
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("expected % x, got % x", want, got)
+		}
+	})
+
+	t.Run("reads what it writes", func(t *testing.T) {
+		long := make([]string, 20)
+		for i := range long {
+			long[i] = "x"
+		}
+		buf := appendThrift(nil, thriftStruct{
+			{1, int8(-1)},
+			{2, int16(300)},
+			{4, int64(-1 << 40)},
+			{5, 2.5},
+			{6, thriftStruct{{1, []byte("nested")}, {3, false}}},
+			{7, long},
+			{8, []thriftStruct{{{1, int32(1)}}, {{1, int32(2)}}}},
+		})
+		buf = append(buf, 0xAA) // trailing data is not read
+
+		s, n, err := readThrift(buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n != len(buf)-1 {
+			t.Errorf("expected %d bytes read, got %d", len(buf)-1, n)
+		}
+		if s.int(1, 0) != -1 || s.int(2, 0) != 300 || s.int(4, 0) != -1<<40 || s.int(3, 9) != 9 {
+			t.Error("unexpected integers")
+		}
+		if v, _ := s.get(5); v != 2.5 {
+			t.Errorf("expected 2.5, got %v", v)
+		}
+		nested := s.strct(6)
+		if nested.string(1) != "nested" || nested.bool(3, true) || !nested.has(3) {
+			t.Error("unexpected nested struct")
+		}
+		if len(s.list(7)) != 20 {
+			t.Errorf("expected 20 list items, got %d", len(s.list(7)))
+		}
+		structs := s.list(8)
+		if len(structs) != 2 || structs[1].(thriftStruct).int(1, 0) != 2 {
+			t.Error("unexpected list of structs")
+		}
+	})
+
+	t.Run("fails on truncated data", func(t *testing.T) {
+		buf := appendThrift(nil, thriftStruct{{1, "hello"}, {2, []int64{1, 2, 3}}})
+		for n := range len(buf) {
+			if _, _, err := readThrift(buf[:n]); err == nil {
+				t.Errorf("expected error for %d bytes", n)
+			}
+		}
+	})
+
+	t.Run("fails on deep nesting", func(t *testing.T) {
+		s := thriftStruct{}
+		for range 100 {
+			s = thriftStruct{{1, s}}
+		}
+		if _, _, err := readThrift(appendThrift(nil, s)); err == nil {
+			t.Error("expected error for deep nesting")
+		}
+	})
+}